@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/shahzodshafizod/gocloud/internal/migration"
 	"github.com/shahzodshafizod/gocloud/internal/orders"
+	"github.com/shahzodshafizod/gocloud/pkg"
+	"github.com/shahzodshafizod/gocloud/pkg/payment"
 	"go.uber.org/fx"
 )
 
@@ -16,6 +19,8 @@ func main() {
 
 		// fx.Provide(NewPostgres),
 		// fx.Provide(NewNoSQL),
+		// fx.Provide(NewStorage),
+		// fx.Provide(NewEmail),
 		// fx.Provide(func() (pkg.Tracer, error) {
 		// 	return NewTracer(os.Getenv("SERVICE_NAME"))
 		// }),
@@ -23,6 +28,31 @@ func main() {
 		// 	return NewQueue(os.Getenv("SERVICE_NAME"), tracer)
 		// }),
 
+		// payment drivers by banks.driver
+		fx.Provide(func(lifecycle fx.Lifecycle, shutdowner fx.Shutdowner) (map[string]pkg.Payment, error) {
+			var payments = make(map[string]pkg.Payment)
+			if addr := os.Getenv("PAYMENT_SIMULATOR_ADDRESS"); addr != "" {
+				simulator, err := payment.NewSimulator(addr, os.Getenv("PAYMENT_SIMULATOR_URL"), os.Getenv("PAYMENT_SIMULATOR_STATE"))
+				if err != nil {
+					return nil, err
+				}
+				lifecycle.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						go func() {
+							// the checkout URLs given to the customers would lead nowhere
+							if err := simulator.Serve(ctx); err != nil {
+								shutdowner.Shutdown(fx.ExitCode(1))
+							}
+						}()
+						return nil
+					},
+					OnStop: simulator.Shutdown,
+				})
+				payments["simulator"] = simulator
+			}
+			return payments, nil
+		}),
+
 		fx.Provide(orders.NewRepository),
 		fx.Provide(orders.NewService),
 		fx.Invoke(orders.NewHandler),
@@ -22,14 +22,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/orders/assign": {
+        "/banks/create": {
             "post": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "deliver chooses an orders and assigns it to themself",
+                "description": "admin adds a bank (payment method), the callback secret is generated when empty and shown once",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,17 +37,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "banks"
                 ],
-                "summary": "Assign the Order",
+                "summary": "Create a Bank",
                 "parameters": [
                     {
-                        "description": "assign order info",
+                        "description": "bank info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.assignRequest"
+                            "$ref": "#/definitions/gateway.bankRequest"
                         }
                     }
                 ],
@@ -55,78 +55,92 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.bankResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/orders/check": {
-            "post": {
+        "/banks/disable/{bankid}": {
+            "put": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "user checks an order",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "admin stops customers from paying with the bank",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "banks"
                 ],
-                "summary": "Check Order",
+                "summary": "Disable a Bank",
                 "parameters": [
                     {
-                        "description": "check order info",
-                        "name": "Request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/gateway.checkRequest"
-                        }
+                        "type": "string",
+                        "description": "bank id",
+                        "name": "bankid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.bankResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/orders/confirm": {
-            "post": {
+        "/banks/enable/{bankid}": {
+            "put": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "user confirms the order",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "admin lets customers pay with the bank again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "banks"
                 ],
-                "summary": "Confirm an Order",
+                "summary": "Enable a Bank",
                 "parameters": [
                     {
-                        "description": "confirm order info",
-                        "name": "Request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/gateway.confirmRequest"
-                        }
+                        "type": "string",
+                        "description": "bank id",
+                        "name": "bankid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -141,7 +155,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.confirmResponse"
+                                            "$ref": "#/definitions/gateway.bankResponse"
                                         }
                                     }
                                 }
@@ -151,30 +165,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/pay": {
-            "post": {
-                "description": "bank sends order payment callback",
-                "consumes": [
-                    "application/json"
+        "/banks/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
                 ],
+                "description": "admin gets all the banks, including the disabled ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
-                ],
-                "summary": "Pay an Order Callback",
-                "parameters": [
-                    {
-                        "description": "pay order info",
-                        "name": "Request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/gateway.payRequest"
-                        }
-                    }
+                    "banks"
                 ],
+                "summary": "List Banks",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -187,7 +192,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.payResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.bankResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -197,9 +205,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/pickup": {
-            "post": {
-                "description": "partner sends a callback that an order is ready",
+        "/banks/update": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin updates a bank (payment method), the callback secret is kept when empty",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,17 +220,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "banks"
                 ],
-                "summary": "Pick Up the Order",
+                "summary": "Update a Bank",
                 "parameters": [
                     {
-                        "description": "pick up order info",
+                        "description": "bank info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.pickupRequest"
+                            "$ref": "#/definitions/gateway.bankRequest"
                         }
                     }
                 ],
@@ -225,20 +238,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.bankResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/partners/products": {
-            "get": {
+        "/categories/create": {
+            "post": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "Returns available products of every partner",
+                "description": "admin adds a top-level category or a subcategory",
                 "consumes": [
                     "application/json"
                 ],
@@ -246,9 +271,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "partners"
+                    "categories"
+                ],
+                "summary": "Create a Category",
+                "parameters": [
+                    {
+                        "description": "category info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.categoryRequest"
+                        }
+                    }
                 ],
-                "summary": "Get Partner Products",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -261,7 +297,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/products.GetAllResponse"
+                                            "$ref": "#/definitions/gateway.categoryResponse"
                                         }
                                     }
                                 }
@@ -271,109 +307,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/delete": {
+        "/categories/delete/{categoryid}": {
             "delete": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "Allows users to delete their accounts. Performs necessary clean-up actions, such as revoking authentication tokens and deactivating users (not removing user data from the system).",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users-auth"
-                ],
-                "summary": "Delete User Account",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.response"
-                        }
-                    }
-                }
-            }
-        },
-        "/users/password/change": {
-            "put": {
-                "security": [
-                    {
-                        "Authorization Token": []
-                    }
-                ],
-                "description": "Enables users to change their passwords. Validates the old password, ensures password strength for the new password, and updates the password in the database.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "admin deletes a category without subcategories, its products are unlinked from it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "categories"
                 ],
-                "summary": "Change User Password",
+                "summary": "Delete a Category",
                 "parameters": [
                     {
-                        "description": "change password info",
-                        "name": "Request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/gateway.changePassword"
-                        }
+                        "type": "integer",
+                        "description": "category id",
+                        "name": "categoryid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.categoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/users/password/forgot": {
+        "/categories/tree": {
             "get": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "Initiates the process for resetting a forgotten password. Sends a password reset link or temporary password to the user's email, allowing them to set a new password.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "the categories tree with the number of the products on sale in each, the subcategories' included",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "categories"
                 ],
-                "summary": "Forgot User Password",
+                "summary": "Get Categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.categoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/users/password/reset": {
+        "/categories/update": {
             "put": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "resets user's password with confirmation code",
+                "description": "admin renames a category or moves it under another parent",
                 "consumes": [
                     "application/json"
                 ],
@@ -381,17 +408,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "categories"
                 ],
-                "summary": "Reset User Password",
+                "summary": "Update a Category",
                 "parameters": [
                     {
-                        "description": "reset password info",
+                        "description": "category info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.resetPassword"
+                            "$ref": "#/definitions/gateway.categoryRequest"
                         }
                     }
                 ],
@@ -399,30 +426,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.categoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/users/profile": {
+        "/deliverers/earnings": {
             "get": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "get user profile",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "deliverer gets their daily and weekly earnings (delivery fee shares and tips) and the pending payout",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "deliverers"
+                ],
+                "summary": "Deliverer Earnings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD, the last day of the week, today by default",
+                        "name": "date",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -435,7 +479,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.user"
+                                            "$ref": "#/definitions/gateway.earningsResponse"
                                         }
                                     }
                                 }
@@ -445,9 +489,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/signin": {
+        "/deliverers/location": {
             "post": {
-                "description": "Verifies user credentials (username/email and password) during login. If the credentials are valid, generates and returns an authentication token.",
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "deliverer posts a GPS ping while delivering their order, the customer sees it with the ETA",
                 "consumes": [
                     "application/json"
                 ],
@@ -455,17 +504,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "deliverers"
                 ],
-                "summary": "User SignIn",
+                "summary": "Send the Location",
                 "parameters": [
                     {
-                        "description": "sign in info",
+                        "description": "deliverer's position",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.signIn"
+                            "$ref": "#/definitions/gateway.locationRequest"
                         }
                     }
                 ],
@@ -481,7 +530,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.token"
+                                            "$ref": "#/definitions/gateway.deliveryLocation"
                                         }
                                     }
                                 }
@@ -491,14 +540,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/signout": {
-            "post": {
+        "/deliverers/reviews": {
+            "get": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "log out",
+                "description": "deliverer gets the latest reviews of their deliveries with their rating",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deliverers"
+                ],
+                "summary": "Deliverer Reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 by default",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.reviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/address": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer changes the delivery address of their order before it is picked up, no farther from the partner than the delivery fee is priced for",
                 "consumes": [
                     "application/json"
                 ],
@@ -506,17 +606,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "User SignOut",
+                "summary": "Update Order Delivery Address",
                 "parameters": [
                     {
-                        "description": "sign out info",
+                        "description": "update order address info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.refreshToken"
+                            "$ref": "#/definitions/gateway.updateAddressRequest"
                         }
                     }
                 ],
@@ -530,14 +630,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/signup": {
+        "/orders/assign": {
             "post": {
                 "security": [
                     {
-                        "Request Signature": []
+                        "Authorization Token": []
                     }
                 ],
-                "description": "create user account",
+                "description": "deliver chooses an orders and assigns it to themself",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,24 +645,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "User SignUp",
+                "summary": "Assign the Order",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "hash_hmac('sha256', first_name+last_name+email+password+role, apiSecretKey)",
-                        "name": "Signature",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "sign up info",
+                        "description": "assign order info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.signUp"
+                            "$ref": "#/definitions/gateway.assignRequest"
                         }
                     }
                 ],
@@ -578,49 +671,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.signUpResponse"
+                                            "$ref": "#/definitions/gateway.assignResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request"
-                    },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    },
-                    "409": {
-                        "description": "Already Exists"
-                    },
-                    "410": {
-                        "description": "Wrong Verification"
-                    },
-                    "411": {
-                        "description": "Wrong Password"
-                    },
-                    "412": {
-                        "description": "Invalid Token"
-                    },
-                    "415": {
-                        "description": "Unsupported Avatar Format"
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
-                    },
-                    "504": {
-                        "description": "External Service Error"
                     }
                 }
             }
         },
-        "/users/signup/confirm": {
+        "/orders/cancel": {
             "post": {
-                "description": "Verifies a user's email address using a verification code. Activates the user's account after successful verification.",
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer cancels their order before paying it, the applied promo code can be used again",
                 "consumes": [
                     "application/json"
                 ],
@@ -628,17 +696,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "Confirm User SignUp",
+                "summary": "Cancel an Order",
                 "parameters": [
                     {
-                        "description": "confirm sign up info",
+                        "description": "cancel order info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.confirmSignUp"
+                            "$ref": "#/definitions/gateway.cancelRequest"
                         }
                     }
                 ],
@@ -652,9 +720,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/token/refresh/{userid}": {
+        "/orders/cart/check": {
             "post": {
-                "description": "refresh user tokens",
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "user checks a cart with the products of several partners and gets the price breakdown of each partner's order",
                 "consumes": [
                     "application/json"
                 ],
@@ -662,24 +735,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "Refresh User Tokens",
+                "summary": "Check a Multi-Partner Cart",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user id",
-                        "name": "userid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "refresh token info",
+                        "description": "check cart info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.refreshToken"
+                            "$ref": "#/definitions/gateway.cartRequest"
                         }
                     }
                 ],
@@ -695,7 +761,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/gateway.token"
+                                            "$ref": "#/definitions/gateway.cartPricing"
                                         }
                                     }
                                 }
@@ -705,14 +771,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/update": {
-            "put": {
+        "/orders/cart/confirm": {
+            "post": {
                 "security": [
                     {
                         "Authorization Token": []
                     }
                 ],
-                "description": "Allows users to update their profile information, such as name, contact information, or profile picture. Validates input data and updates the corresponding user record in the database.",
+                "description": "user confirms the checked cart, it is saved as linked orders, one per partner, paid in a single payment",
                 "consumes": [
                     "application/json"
                 ],
@@ -720,17 +786,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "Update User Profile",
+                "summary": "Confirm a Multi-Partner Cart",
                 "parameters": [
                     {
-                        "description": "update user info",
+                        "description": "confirm cart info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.updateUser"
+                            "$ref": "#/definitions/gateway.confirmRequest"
                         }
                     }
                 ],
@@ -738,52 +804,109 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.cartResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/users/update/avatar": {
-            "put": {
-                "security": [
-                    {
-                        "Authorization Token": []
-                    }
-                ],
-                "description": "updates user profile picture",
+        "/orders/cart/pay": {
+            "post": {
+                "description": "bank sends the payment callback of a multi-partner cart, order_id is the checkout id",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "Update User Profile Picture",
+                "summary": "Pay a Cart Callback",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "avatar file",
-                        "name": "avatar",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "bank id (paytype)",
+                        "name": "X-Bank-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix seconds when the callback was signed",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unique callback id",
+                        "name": "X-Nonce",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "pay cart info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.payRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.payResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
                     }
                 }
             }
         },
-        "/users/update/confirm": {
-            "put": {
-                "description": "Verifies a user's email address using a verification code. Activates the user's account after successful verification.",
+        "/orders/check": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "user checks an order and gets its price breakdown",
                 "consumes": [
                     "application/json"
                 ],
@@ -791,17 +914,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users-auth"
+                    "orders"
                 ],
-                "summary": "Confirm Change Email",
+                "summary": "Check Order",
                 "parameters": [
                     {
-                        "description": "confirm change email info",
+                        "description": "check order info",
                         "name": "Request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/gateway.confirmChangeEmail"
+                            "$ref": "#/definitions/gateway.checkRequest"
                         }
                     }
                 ],
@@ -809,230 +932,4400 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.pricing"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "gateway.assignRequest": {
-            "type": "object",
-            "properties": {
-                "order_id": {
-                    "type": "integer"
-                }
-            }
         },
-        "gateway.changePassword": {
+        "/orders/complete": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "deliverer hands the order over to the customer and gets its delivery fee share and tip credited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Complete the Order",
+                "parameters": [
+                    {
+                        "description": "complete order info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.completeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.completeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "user confirms the order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Confirm an Order",
+                "parameters": [
+                    {
+                        "description": "confirm order info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.confirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.confirmResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/location/{orderid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer gets the latest position of the deliverer of their order on the way and the estimated minutes to the delivery address",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Locate the Deliverer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "orderid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.deliveryLocation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/pay": {
+            "post": {
+                "description": "bank sends order payment callback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Pay an Order Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bank id (paytype)",
+                        "name": "X-Bank-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix seconds when the callback was signed",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unique callback id",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "pay order info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.payRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.payResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/orders/pickup": {
+            "post": {
+                "description": "partner sends a callback that an order is ready",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Pick Up the Order",
+                "parameters": [
+                    {
+                        "description": "pick up order info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.pickupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/receipt/{orderid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer downloads the PDF receipt of their paid order, the same one that is emailed after the payment",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Download the Receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "orderid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "redirect to the receipt"
+                    }
+                }
+            }
+        },
+        "/orders/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "finance gets the payment discrepancies (overpayments, missing and orphan payments) detected in the period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Payment Reconciliation Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date_from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date_to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.discrepancy"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/reconciliation/csv": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "finance downloads the payment discrepancies detected in the period as a CSV file",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Export Payment Reconciliation Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date_from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD",
+                        "name": "date_to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/refund": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin refunds the whole order or some of its products to the customer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Refund the Order",
+                "parameters": [
+                    {
+                        "description": "refund info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.refundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.refundResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/reorder": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer checks the products of their past order again at the current prices, the products no longer available are left out; the checked order is confirmed as usual",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Reorder",
+                "parameters": [
+                    {
+                        "description": "reorder info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.reorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.reorderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/review": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer rates the partner and the deliverer of their delivered order, once per order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Review the Order",
+                "parameters": [
+                    {
+                        "description": "review info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.reviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/tip": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer tips the deliverer of a delivered order and gets the web checkout page to pay the tip",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Tip the Deliverer",
+                "parameters": [
+                    {
+                        "description": "tip info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.tipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.tipResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/tip/pay": {
+            "post": {
+                "description": "bank sends tip payment callback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Pay a Tip Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "bank id (paytype)",
+                        "name": "X-Bank-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix seconds when the callback was signed",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unique callback id",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "pay tip info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.payRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.payResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/orders/track/{orderid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "customer follows their order as server-sent events: the current state first, then every status change, deliverer assignment and ETA update until the order is finished",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Track the Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "orderid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gateway.orderEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/create": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin adds a partner, the paid orders are sent to its api url",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Create a Partner",
+                "parameters": [
+                    {
+                        "description": "partner info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.partnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/disable/{partnerid}": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin takes the partner's products off sale",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Disable a Partner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partnerid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/enable/{partnerid}": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin puts the partner's products on sale again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Enable a Partner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partnerid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/get/{partnerid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin gets the partner's details",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Get a Partner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partnerid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/hours/get": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "partner gets its weekly opening hours and the upcoming closures, admin gets the partner's of the query",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Get Opening Hours",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.openingHoursResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/hours/set": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "replaces the partner's weekly opening hours and closures, e.g. holidays, orders are taken within them only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Set Opening Hours",
+                "parameters": [
+                    {
+                        "description": "opening hours in the partner's timezone",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.openingHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.openingHoursResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin gets all the partners, including the disabled ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "List Partners",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.partnerResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/options": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "the variants and the modifiers a partner's product is ordered with, their IDs are chosen on the order check",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Get Product Options",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "product id",
+                        "name": "product_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partner_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.productOptions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/pause": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "partner stops taking orders at once, whatever its opening hours are, until resumed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Pause a Partner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/products": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Returns available products of the partners with the partner's rating, a page at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Get Partner Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "searched in the products' title and description",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the lowest price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the highest price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "category id, its subcategories included",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "rating - the best rated partners first; price_asc, price_desc, relevance - of a partner's products",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/products.GetAllResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/resume": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "partner takes orders again within its opening hours",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Resume a Partner",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/reviews/{partnerid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Returns the latest reviews of the partner with its rating",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Get Partner Reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partnerid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 by default",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.reviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/update": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin updates the partner's details and verification, see enable/disable for the rest",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Update a Partner",
+                "parameters": [
+                    {
+                        "description": "partner info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.partnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.partnerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/webhook/secret": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "replaces the secret the webhooks to the partner API are signed with and shows it once: partner of its own, admin of the partner's of the query",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "partners"
+                ],
+                "summary": "Rotate Webhook Secret",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.webhookSecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/methods": {
+            "get": {
+                "description": "get the active payment methods, their IDs are the paytypes of the orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get Payment Methods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.paymentMethod"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/available/delete/{productid}": {
+            "delete": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "takes the product off sale by the partner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a Partner Listing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "product id",
+                        "name": "productid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.availableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/available/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "partner gets the products it sells with prices, admin gets the partner's of the query",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List Partner Listings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id, admins only",
+                        "name": "partner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.availableResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/available/set": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "puts the product on sale by the partner or changes its price and activity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set a Partner Listing",
+                "parameters": [
+                    {
+                        "description": "listing info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.availableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.availableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/create": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin adds a product to the catalog or for a partner, partner adds its own product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a Product",
+                "parameters": [
+                    {
+                        "description": "product info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.productRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.productResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/delete/{productid}": {
+            "delete": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin deletes any product, partner its own products only; the product is taken off sale by all the partners",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a Product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "product id",
+                        "name": "productid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/labels": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "replaces the categories and the tags of a product: admin of any product, partner of its own products only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set Product Labels",
+                "parameters": [
+                    {
+                        "description": "the product's categories and tags",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.labelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.labelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin gets the whole catalog, partner gets the admins' catalog and its own products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List Products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.productResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/options": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "replaces the variants and the modifier groups of a product a partner sells: partner of its own listings, admin of the partner's of the body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set Product Options",
+                "parameters": [
+                    {
+                        "description": "the product's options",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.productOptions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.productOptions"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/picture/{productid}": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin updates any product's picture, partner its own products' only",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update Product Picture",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "product id",
+                        "name": "productid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "picture file",
+                        "name": "picture",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.productResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/update": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin updates any product, partner updates its own products only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update a Product",
+                "parameters": [
+                    {
+                        "description": "product info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.productRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.productResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/promos/create": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin creates a percent or fixed promo code with its validity window and usage limits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promos"
+                ],
+                "summary": "Create a Promo Code",
+                "parameters": [
+                    {
+                        "description": "promo code info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.promoCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.promoCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/hide/{orderid}": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin hides an abusive review, it is no longer shown nor counted in the ratings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Hide a Review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id of the review",
+                        "name": "orderid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin lists the latest reviews, hidden ones too, to moderate them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List Reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deliverer id",
+                        "name": "deliverer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include the hidden reviews",
+                        "name": "include_hidden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 by default",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.reviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/show/{orderid}": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin shows a hidden review back",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Show a Review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "order id of the review",
+                        "name": "orderid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Allows users to delete their accounts. Performs necessary clean-up actions, such as revoking authentication tokens and deactivating users (not removing user data from the system).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Delete User Account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password/change": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Enables users to change their passwords. Validates the old password, ensures password strength for the new password, and updates the password in the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Change User Password",
+                "parameters": [
+                    {
+                        "description": "change password info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.changePassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password/forgot": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Initiates the process for resetting a forgotten password. Sends a password reset link or temporary password to the user's email, allowing them to set a new password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Forgot User Password",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password/reset": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "resets user's password with confirmation code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Reset User Password",
+                "parameters": [
+                    {
+                        "description": "reset password info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.resetPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "get user profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.user"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/signin": {
+            "post": {
+                "description": "Verifies user credentials (username/email and password) during login. If the credentials are valid, generates and returns an authentication token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "User SignIn",
+                "parameters": [
+                    {
+                        "description": "sign in info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.signIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.token"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/signout": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "log out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "User SignOut",
+                "parameters": [
+                    {
+                        "description": "sign out info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.refreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/signup": {
+            "post": {
+                "security": [
+                    {
+                        "Request Signature": []
+                    }
+                ],
+                "description": "create user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "User SignUp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hash_hmac('sha256', first_name+last_name+email+password+role, apiSecretKey)",
+                        "name": "Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "sign up info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.signUp"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.signUpResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Already Exists"
+                    },
+                    "410": {
+                        "description": "Wrong Verification"
+                    },
+                    "411": {
+                        "description": "Wrong Password"
+                    },
+                    "412": {
+                        "description": "Invalid Token"
+                    },
+                    "415": {
+                        "description": "Unsupported Avatar Format"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "504": {
+                        "description": "External Service Error"
+                    }
+                }
+            }
+        },
+        "/users/signup/confirm": {
+            "post": {
+                "description": "Verifies a user's email address using a verification code. Activates the user's account after successful verification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Confirm User SignUp",
+                "parameters": [
+                    {
+                        "description": "confirm sign up info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.confirmSignUp"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/token/refresh/{userid}": {
+            "post": {
+                "description": "refresh user tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Refresh User Tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "userid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "refresh token info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.refreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.token"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "Allows users to update their profile information, such as name, contact information, or profile picture. Validates input data and updates the corresponding user record in the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Update User Profile",
+                "parameters": [
+                    {
+                        "description": "update user info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.updateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "updates user profile picture",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Update User Profile Picture",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "avatar file",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update/confirm": {
+            "put": {
+                "description": "Verifies a user's email address using a verification code. Activates the user's account after successful verification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-auth"
+                ],
+                "summary": "Confirm Change Email",
+                "parameters": [
+                    {
+                        "description": "confirm change email info",
+                        "name": "Request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gateway.confirmChangeEmail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/get/{deliveryid}": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin gets a webhook sent to a partner API with the log of its attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a Webhook Delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "delivery id",
+                        "name": "deliveryid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.webhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/list": {
+            "get": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin lists the latest webhooks sent to the partner APIs, the dead ones to replay them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "partner id",
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, delivered or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "order id",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "20 by default, 100 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "0 by default",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/gateway.webhookDelivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/replay/{deliveryid}": {
+            "post": {
+                "security": [
+                    {
+                        "Authorization Token": []
+                    }
+                ],
+                "description": "admin sends a webhook to the partner API again at once, e.g. a dead one once the API is fixed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Replay a Webhook Delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "delivery id",
+                        "name": "deliveryid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/gateway.webhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "gateway.address": {
+            "type": "object",
+            "required": [
+                "city",
+                "postal_code",
+                "street"
+            ],
+            "properties": {
+                "apartment": {
+                    "description": "optional: apartment, suite, floor, etc.",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "instructions": {
+                    "description": "optional: how to find the door, entrance code, etc.",
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "gateway.assignRequest": {
+            "type": "object",
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.assignResponse": {
+            "type": "object",
+            "properties": {
+                "delivery_address": {
+                    "$ref": "#/definitions/gateway.address"
+                },
+                "pickup_address": {
+                    "$ref": "#/definitions/gateway.address"
+                }
+            }
+        },
+        "gateway.availableRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "product_id"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "partner_id": {
+                    "description": "admins only, a partner lists its own",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "description": "left on sale, the reserved quantities are taken off; null - not tracked",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "gateway.availableResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "description": "-1 - not tracked",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.bankRequest": {
+            "type": "object",
+            "required": [
+                "currencies",
+                "driver",
+                "id",
+                "title"
+            ],
+            "properties": {
+                "active": {
+                    "description": "used on create only, see enable/disable",
+                    "type": "boolean"
+                },
+                "allowed_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "callback_secret": {
+                    "description": "generated on create and kept on update when empty",
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 32
+                },
+                "currencies": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "driver": {
+                    "description": "payment provider driver that serves the bank",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "the paytype of the orders",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "webcheckout_url": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "gateway.bankResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "allowed_ips": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "callback_secret": {
+                    "description": "the generated one, shown once on create",
+                    "type": "string"
+                },
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "driver": {
+                    "type": "string"
+                },
+                "has_callback_secret": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "webcheckout_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.cancelRequest": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.cartOrder": {
+            "type": "object",
+            "properties": {
+                "partner_brand": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "partner_title": {
+                    "type": "string"
+                },
+                "pricing": {
+                    "$ref": "#/definitions/gateway.pricing"
+                }
+            }
+        },
+        "gateway.cartPartner": {
+            "type": "object",
+            "required": [
+                "partner_id",
+                "products",
+                "total_amount"
+            ],
+            "properties": {
+                "partner_id": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.product"
+                    }
+                },
+                "total_amount": {
+                    "description": "sum of the partner's products",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.cartPricing": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "description": "one per partner",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.cartOrder"
+                    }
+                },
+                "total": {
+                    "description": "the amount to pay for the whole cart",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.cartRequest": {
+            "type": "object",
+            "required": [
+                "delivery_address",
+                "order_id",
+                "partners",
+                "paytype"
+            ],
+            "properties": {
+                "customer_phone": {
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "$ref": "#/definitions/gateway.address"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "partners": {
+                    "type": "array",
+                    "minItems": 2,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/gateway.cartPartner"
+                    }
+                },
+                "paytype": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "description": "delivery slot in RFC3339, empty for ASAP",
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.cartResponse": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "checkout_id": {
+                    "type": "integer"
+                },
+                "order_ids": {
+                    "description": "the child orders, each is picked up and delivered on its own",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "webcheckout_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.categoryRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "description": "used on update only",
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "0 - a top-level category",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "gateway.categoryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "product_count": {
+                    "description": "of the subcategories too, on the tree only",
+                    "type": "integer"
+                },
+                "subcategories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.categoryResponse"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.changePassword": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "The new password the user wants to set.",
+                    "type": "string",
+                    "minLength": 6
+                },
+                "old_password": {
+                    "description": "The user's current password.",
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "gateway.checkRequest": {
+            "type": "object",
+            "required": [
+                "delivery_address",
+                "order_id",
+                "partner_id",
+                "paytype",
+                "products",
+                "total_amount"
+            ],
+            "properties": {
+                "customer_phone": {
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "$ref": "#/definitions/gateway.address"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "paytype": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.product"
+                    }
+                },
+                "promo_code": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "scheduled_at": {
+                    "description": "delivery slot in RFC3339, empty for ASAP",
+                    "type": "string"
+                },
+                "tip": {
+                    "description": "for the deliverer, added to the total",
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "description": "sum of the products, fees are added by the pricing",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.closure": {
+            "type": "object",
+            "required": [
+                "ends_on",
+                "starts_on"
+            ],
+            "properties": {
+                "ends_on": {
+                    "description": "YYYY-MM-DD, inclusive",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "starts_on": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.completeRequest": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.completeResponse": {
+            "type": "object",
+            "properties": {
+                "earned": {
+                    "description": "delivery fee share and the checkout tip",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.confirmChangeEmail": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "code",
+                "user_id"
+            ],
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.confirmRequest": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.confirmResponse": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "partner_brand": {
+                    "type": "string"
+                },
+                "partner_title": {
+                    "type": "string"
+                },
+                "pricing": {
+                    "$ref": "#/definitions/gateway.pricing"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "webcheckout_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.confirmSignUp": {
+            "type": "object",
+            "required": [
+                "code",
+                "user_id"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.deliveryLocation": {
+            "type": "object",
+            "properties": {
+                "distance_km": {
+                    "description": "straight line to the delivery address",
+                    "type": "number"
+                },
+                "eta_minutes": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.discrepancy": {
+            "type": "object",
+            "properties": {
+                "actual_amount": {
+                    "type": "integer"
+                },
+                "bank_id": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "detected_at": {
+                    "type": "string"
+                },
+                "expected_amount": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "overpayment, missing_payment, orphan_payment, amount_mismatch",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.earnings": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD, empty for the week",
+                    "type": "string"
+                },
+                "deliveries": {
+                    "type": "integer"
+                },
+                "delivery_fees": {
+                    "type": "integer"
+                },
+                "tips": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.earningsResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.earnings"
+                    }
+                },
+                "pending_payout": {
+                    "description": "earned and not paid out yet, all time",
+                    "type": "integer"
+                },
+                "week": {
+                    "$ref": "#/definitions/gateway.earnings"
+                }
+            }
+        },
+        "gateway.labelsRequest": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "gateway.labelsResponse": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "gateway.locationRequest": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "order_id"
+            ],
+            "properties": {
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.modifier": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "description": "read-only",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "added to the product's, 0 - free",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "gateway.modifierGroup": {
+            "type": "object",
+            "required": [
+                "max_selections",
+                "modifiers",
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "description": "read-only",
+                    "type": "integer"
+                },
+                "max_selections": {
+                    "type": "integer"
+                },
+                "min_selections": {
+                    "description": "0 - optional",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "modifiers": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/gateway.modifier"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "gateway.openingHoursRequest": {
+            "type": "object",
+            "properties": {
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.closure"
+                    }
+                },
+                "partner_id": {
+                    "description": "admins only, a partner sets its own",
+                    "type": "integer"
+                },
+                "weekly": {
+                    "description": "empty - open at any time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.openingPeriod"
+                    }
+                }
+            }
+        },
+        "gateway.openingHoursResponse": {
+            "type": "object",
+            "properties": {
+                "closures": {
+                    "description": "the upcoming ones",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.closure"
+                    }
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.openingPeriod"
+                    }
+                }
+            }
+        },
+        "gateway.openingPeriod": {
+            "type": "object",
+            "required": [
+                "closes_at",
+                "opens_at"
+            ],
+            "properties": {
+                "closes_at": {
+                    "description": "later than opens_at on the same day",
+                    "type": "string"
+                },
+                "opens_at": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "0 - Sunday",
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0
+                }
+            }
+        },
+        "gateway.orderEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deliverer_id": {
+                    "type": "string"
+                },
+                "eta_minutes": {
+                    "type": "integer"
+                },
+                "event": {
+                    "description": "current, paid, ready, delivering, address_updated, refunded, cancelled, expired, delivered",
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "of the deliverer, on location events",
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.partnerRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "api_url",
+                "brand",
+                "email",
+                "phone",
+                "title"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "api_url": {
+                    "description": "the paid orders are sent to",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "brand": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "enabled": {
+                    "description": "used on create only, see enable/disable",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "used on update only",
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "of the opening hours, Asia/Dushanbe by default",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "user_id": {
+                    "description": "of the partner's staff, who manage its own listings",
+                    "type": "string",
+                    "maxLength": 36
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "gateway.partnerResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "api_url": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "paused": {
+                    "description": "takes no orders until resumed",
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "gateway.payRequest": {
+            "type": "object",
+            "required": [
+                "order_id",
+                "paid_amount",
+                "payment_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid_amount": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.payResponse": {
+            "type": "object",
+            "properties": {
+                "payment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.paymentMethod": {
+            "type": "object",
+            "properties": {
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.pickupRequest": {
             "type": "object",
             "required": [
-                "new_password",
-                "old_password"
+                "pickup_address"
             ],
             "properties": {
-                "new_password": {
-                    "description": "The new password the user wants to set.",
+                "order_id": {
+                    "type": "integer"
+                },
+                "pickup_address": {
+                    "$ref": "#/definitions/gateway.address"
+                }
+            }
+        },
+        "gateway.pricing": {
+            "type": "object",
+            "properties": {
+                "delivery_fee": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "description": "given by the promo code",
+                    "type": "integer"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "service_fee": {
+                    "type": "integer"
+                },
+                "small_order_fee": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "description": "sum of the products",
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "tip": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "the amount to pay",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.product": {
+            "type": "object",
+            "required": [
+                "id",
+                "quantity"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "modifier_ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "variant_id": {
+                    "description": "required if the partner sells the product in variants",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.productOptions": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "modifier_groups": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/gateway.modifierGroup"
+                    }
+                },
+                "partner_id": {
+                    "description": "admins only, a partner sets its own",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "empty - sold at the listing's price",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/gateway.variant"
+                    }
+                }
+            }
+        },
+        "gateway.productRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
                     "type": "string",
-                    "minLength": 6
+                    "maxLength": 256
                 },
-                "old_password": {
-                    "description": "The user's current password.",
+                "id": {
+                    "description": "used on update only",
+                    "type": "integer"
+                },
+                "partner_id": {
+                    "description": "admins only: the partner the product is added for, a partner adds its own",
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string",
-                    "minLength": 6
+                    "maxLength": 100
                 }
             }
         },
-        "gateway.checkRequest": {
+        "gateway.productResponse": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "partner_id": {
+                    "description": "0 - of the admins' catalog",
+                    "type": "integer"
+                },
+                "picture_url": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.promoCodeRequest": {
             "type": "object",
             "required": [
-                "delivery_address",
-                "order_id",
-                "partner_id",
-                "paytype",
-                "products",
-                "total_amount"
+                "code",
+                "kind",
+                "value"
             ],
             "properties": {
-                "customer_phone": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "delivery_address": {
+                "code": {
+                    "description": "case-insensitive",
                     "type": "string",
-                    "minLength": 6
+                    "maxLength": 32
                 },
-                "order_id": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ]
+                },
+                "max_discount": {
+                    "description": "caps percent discounts",
+                    "type": "integer"
+                },
+                "min_order_amount": {
+                    "description": "of the products subtotal",
+                    "type": "integer"
+                },
+                "partner_ids": {
+                    "description": "empty - any partner",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "per_user_limit": {
+                    "description": "empty - unlimited",
+                    "type": "integer"
+                },
+                "usage_limit": {
+                    "description": "empty - unlimited",
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "description": "RFC3339, empty - from now on",
                     "type": "string"
                 },
-                "partner_id": {
+                "valid_to": {
+                    "description": "RFC3339, empty - no end",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "percent: basis points, fixed: minor units of the currency",
                     "type": "integer"
+                }
+            }
+        },
+        "gateway.promoCodeResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
                 },
-                "paytype": {
+                "code": {
                     "type": "string"
                 },
-                "products": {
+                "kind": {
+                    "type": "string"
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_order_amount": {
+                    "type": "integer"
+                },
+                "partner_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/gateway.product"
+                        "type": "integer"
                     }
                 },
-                "total_amount": {
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                },
+                "value": {
                     "type": "integer"
                 }
             }
         },
-        "gateway.confirmChangeEmail": {
+        "gateway.refreshToken": {
             "type": "object",
             "required": [
-                "access_token",
-                "code",
-                "user_id"
+                "refresh_token"
             ],
             "properties": {
-                "access_token": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.refundRequest": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "integer"
+                },
+                "products": {
+                    "description": "the refunded lines, with the options they're ordered with; empty to refund the whole order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.product"
+                    }
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "gateway.refundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "refund_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "refunded, partially_refunded",
                     "type": "string"
+                }
+            }
+        },
+        "gateway.reorderProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.selectedOption"
+                    }
+                },
+                "past_price": {
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "the current one",
+                    "type": "integer"
+                },
+                "price_changed": {
+                    "type": "boolean"
+                },
+                "quantity": {
+                    "type": "integer"
                 },
-                "code": {
+                "title": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "unavailable": {
+                    "description": "no longer sold by the partner or with the options, left out of the new order",
+                    "type": "boolean"
                 }
             }
         },
-        "gateway.confirmRequest": {
+        "gateway.reorderRequest": {
             "type": "object",
             "required": [
-                "order_id"
+                "order_id",
+                "past_order_id"
             ],
             "properties": {
+                "delivery_address": {
+                    "description": "the past order's by default",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gateway.address"
+                        }
+                    ]
+                },
                 "order_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "gateway.confirmResponse": {
-            "type": "object",
-            "properties": {
-                "callback_url": {
+                    "description": "of the new order",
                     "type": "string"
                 },
-                "order_id": {
+                "past_order_id": {
                     "type": "integer"
                 },
-                "partner_brand": {
+                "paytype": {
+                    "description": "the past order's by default",
                     "type": "string"
                 },
-                "partner_title": {
+                "scheduled_at": {
+                    "description": "delivery slot in RFC3339, empty for ASAP",
                     "type": "string"
+                }
+            }
+        },
+        "gateway.reorderResponse": {
+            "type": "object",
+            "properties": {
+                "pricing": {
+                    "$ref": "#/definitions/gateway.pricing"
                 },
-                "total_amount": {
-                    "type": "integer"
-                },
-                "webcheckout_url": {
-                    "type": "string"
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.reorderProduct"
+                    }
                 }
             }
         },
-        "gateway.confirmSignUp": {
+        "gateway.resetPassword": {
             "type": "object",
             "required": [
                 "code",
+                "password",
                 "user_id"
             ],
             "properties": {
                 "code": {
                     "type": "string"
                 },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "gateway.payRequest": {
+        "gateway.review": {
             "type": "object",
-            "required": [
-                "order_id",
-                "paid_amount",
-                "payment_id"
-            ],
             "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deliverer_id": {
+                    "type": "string"
+                },
+                "deliverer_rating": {
+                    "type": "integer"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
                 "order_id": {
                     "type": "integer"
                 },
-                "paid_amount": {
+                "partner_id": {
                     "type": "integer"
                 },
-                "payment_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "gateway.payResponse": {
-            "type": "object",
-            "properties": {
-                "payment_id": {
-                    "type": "string"
+                "partner_rating": {
+                    "type": "integer"
                 }
             }
         },
-        "gateway.pickupRequest": {
+        "gateway.reviewRequest": {
             "type": "object",
             "required": [
-                "pickup_address"
+                "deliverer_rating",
+                "order_id",
+                "partner_rating"
             ],
             "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "deliverer_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
                 "order_id": {
                     "type": "integer"
                 },
-                "pickup_address": {
-                    "type": "string"
+                "partner_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 }
             }
         },
-        "gateway.product": {
+        "gateway.reviewsResponse": {
             "type": "object",
-            "required": [
-                "id",
-                "quantity"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "rating": {
+                    "description": "average of the partner or the deliverer, hidden reviews aren't counted",
+                    "type": "number"
                 },
-                "quantity": {
+                "rating_count": {
                     "type": "integer"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.review"
+                    }
                 }
             }
         },
-        "gateway.refreshToken": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
-                    "type": "string"
-                }
-            }
-        },
-        "gateway.resetPassword": {
+        "gateway.selectedOption": {
             "type": "object",
-            "required": [
-                "code",
-                "password",
-                "user_id"
-            ],
             "properties": {
-                "code": {
+                "group": {
+                    "description": "the modifier group's title, empty - the variant",
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "price": {
+                    "description": "the variant's, or added by the modifier",
+                    "type": "integer"
                 },
-                "user_id": {
+                "title": {
                     "type": "string"
                 }
             }
@@ -1115,6 +5408,35 @@ const docTemplate = `{
                 }
             }
         },
+        "gateway.tipRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "order_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.tipResponse": {
+            "type": "object",
+            "properties": {
+                "callback_url": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "webcheckout_url": {
+                    "type": "string"
+                }
+            }
+        },
         "gateway.token": {
             "type": "object",
             "properties": {
@@ -1129,6 +5451,21 @@ const docTemplate = `{
                 }
             }
         },
+        "gateway.updateAddressRequest": {
+            "type": "object",
+            "required": [
+                "delivery_address",
+                "order_id"
+            ],
+            "properties": {
+                "delivery_address": {
+                    "$ref": "#/definitions/gateway.address"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "gateway.updateUser": {
             "type": "object",
             "properties": {
@@ -1200,14 +5537,150 @@ const docTemplate = `{
                 }
             }
         },
+        "gateway.variant": {
+            "type": "object",
+            "required": [
+                "price",
+                "title"
+            ],
+            "properties": {
+                "id": {
+                    "description": "read-only, new ones are given on every update",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "gateway.webhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "attempted_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "0 - no response, e.g. a timeout",
+                    "type": "integer"
+                }
+            }
+        },
+        "gateway.webhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "description": "0 - no response",
+                    "type": "integer"
+                },
+                "log": {
+                    "description": "the latest first, of a single delivery only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gateway.webhookAttempt"
+                    }
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, delivered, dead",
+                    "type": "string"
+                }
+            }
+        },
+        "gateway.webhookSecretResponse": {
+            "type": "object",
+            "properties": {
+                "partner_id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "shown once, the webhooks are signed with it from now on",
+                    "type": "string"
+                }
+            }
+        },
+        "products.Facet": {
+            "type": "object",
+            "properties": {
+                "ID": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "products.GetAllResponse": {
             "type": "object",
             "properties": {
+                "categories": {
+                    "description": "of the products of all the pages",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/products.Facet"
+                    }
+                },
+                "nextCursor": {
+                    "description": "empty - the last page",
+                    "type": "string"
+                },
                 "partners": {
+                    "description": "a partner's products may continue on the next page",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/products.Partner"
                     }
+                },
+                "tags": {
+                    "description": "of the products of all the pages",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/products.Facet"
+                    }
                 }
             }
         },
@@ -1220,12 +5693,23 @@ const docTemplate = `{
                 "brand": {
                     "type": "string"
                 },
+                "closed": {
+                    "description": "why the partner takes no orders now: paused, holiday or closed; empty - open",
+                    "type": "string"
+                },
                 "products": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/products.PartnerProduct"
                     }
                 },
+                "rating": {
+                    "description": "average of the customers' reviews, 1-5 stars",
+                    "type": "number"
+                },
+                "ratingCount": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string"
                 }
@@ -7,7 +7,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	_ "github.com/shahzodshafizod/gocloud/docs"
@@ -20,8 +23,10 @@ import (
 )
 
 const (
-	_USER_ID_KEY      = "userID"
-	_MAX_AVATAR_BYTES = 5 << 20 // 101 << 20 => 10100000000000000000000 => 2^22+2^20 => 5242880/1024/1024 = 5mb
+	_USER_ID_KEY             = "userID"
+	_BANK_ID_KEY             = "bankID"
+	_MAX_PICTURE_BYTES       = 5 << 20 // 101 << 20 => 10100000000000000000000 => 2^22+2^20 => 5242880/1024/1024 = 5mb
+	_PAYMENT_CALLBACK_WINDOW = time.Minute * 5
 )
 
 type handler struct {
@@ -61,7 +66,12 @@ func (h *handler) registerRoutes(tracer pkg.Tracer) pkg.Router {
 		prefixFmt = "/api/v1/%s" // %s - group
 	)
 
-	router := http.New(addr, tracer, validator.OptionalDateOnly())
+	router := http.New(addr, tracer,
+		validator.OptionalDateOnly(),
+		validator.PostalCode(),
+		validator.Latitude(),
+		validator.Longitude(),
+	)
 
 	router.GET("/docs/", "Swagger", router.WrapHandler(httpSwagger.WrapHandler))
 
@@ -82,13 +92,88 @@ func (h *handler) registerRoutes(tracer pkg.Tracer) pkg.Router {
 
 	prefix = fmt.Sprintf(prefixFmt, "partners")
 	router.GET(prefix+"/products", "GetProducts", h.getPartnerProducts, h.authorize)
+	router.GET(prefix+"/reviews/:partnerid", "GetPartnerReviews", h.getPartnerReviews, h.authorize)
+	router.GET(prefix+"/options", "GetProductOptions", h.getProductOptions, h.authorize)
+	router.GET(prefix+"/list", "ListPartners", h.listPartners, h.allowRoles("admin"), h.authorize)
+	router.GET(prefix+"/get/:partnerid", "GetPartner", h.getPartner, h.allowRoles("admin"), h.authorize)
+	router.POST(prefix+"/create", "CreatePartner", h.createPartner, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/update", "UpdatePartner", h.updatePartner, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/enable/:partnerid", "EnablePartner", h.enablePartner, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/disable/:partnerid", "DisablePartner", h.disablePartner, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/pause", "PausePartner", h.pausePartner, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/resume", "ResumePartner", h.resumePartner, h.allowRoles("admin", "partner"), h.authorize)
+	router.GET(prefix+"/hours/get", "GetOpeningHours", h.getOpeningHours, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/hours/set", "SetOpeningHours", h.setOpeningHours, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/webhook/secret", "RotateWebhookSecret", h.rotateWebhookSecret, h.allowRoles("admin", "partner"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "products")
+	router.GET(prefix+"/list", "ListProducts", h.listProducts, h.allowRoles("admin", "partner"), h.authorize)
+	router.POST(prefix+"/create", "CreateProduct", h.createProduct, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/update", "UpdateProduct", h.updateProduct, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/picture/:productid", "UpdateProductPicture", h.updateProductPicture, h.allowRoles("admin", "partner"), h.authorize)
+	router.DELETE(prefix+"/delete/:productid", "DeleteProduct", h.deleteProduct, h.allowRoles("admin", "partner"), h.authorize)
+	router.GET(prefix+"/available/list", "ListAvailable", h.listAvailable, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/available/set", "SetAvailable", h.setAvailable, h.allowRoles("admin", "partner"), h.authorize)
+	router.DELETE(prefix+"/available/delete/:productid", "DeleteAvailable", h.deleteAvailable, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/labels", "SetProductLabels", h.setProductLabels, h.allowRoles("admin", "partner"), h.authorize)
+	router.PUT(prefix+"/options", "SetProductOptions", h.setProductOptions, h.allowRoles("admin", "partner"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "categories")
+	router.GET(prefix+"/tree", "GetCategories", h.getCategories, h.authorize)
+	router.POST(prefix+"/create", "CreateCategory", h.createCategory, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/update", "UpdateCategory", h.updateCategory, h.allowRoles("admin"), h.authorize)
+	router.DELETE(prefix+"/delete/:categoryid", "DeleteCategory", h.deleteCategory, h.allowRoles("admin"), h.authorize)
 
 	prefix = fmt.Sprintf(prefixFmt, "orders")
 	router.POST(prefix+"/check", "CheckOrder", h.checkOrder, h.authorize)
 	router.POST(prefix+"/confirm", "ConfirmOrder", h.confirmOrder, h.authorize)
-	router.POST(prefix+"/pay", "PayOrder", h.payOrder)
+	router.POST(prefix+"/reorder", "Reorder", h.reorder, h.allowRoles("customer"), h.authorize)
+	router.POST(prefix+"/pay", "PayOrder", h.payOrder, h.verifyPaymentCallback)
+	router.POST(prefix+"/cart/check", "CheckCart", h.checkCart, h.authorize)
+	router.POST(prefix+"/cart/confirm", "ConfirmCart", h.confirmCart, h.authorize)
+	router.POST(prefix+"/cart/pay", "PayCart", h.payCart, h.verifyPaymentCallback)
 	router.POST(prefix+"/pickup", "PickUpOrder", h.pickUpOrder)
 	router.POST(prefix+"/assign", "AssignOrder", h.assignOrder, h.authorize)
+	router.PUT(prefix+"/address", "UpdateOrderAddress", h.updateOrderAddress, h.authorize)
+	router.POST(prefix+"/cancel", "CancelOrder", h.cancelOrder, h.allowRoles("customer"), h.authorize)
+	router.GET(prefix+"/track/:orderid", "TrackOrder", h.trackOrder, h.allowRoles("customer"), h.authorize)
+	router.GET(prefix+"/receipt/:orderid", "DownloadReceipt", h.downloadReceipt, h.allowRoles("customer"), h.authorize)
+	router.GET(prefix+"/location/:orderid", "GetOrderLocation", h.getOrderLocation, h.allowRoles("customer"), h.authorize)
+	router.POST(prefix+"/complete", "CompleteOrder", h.completeOrder, h.allowRoles("deliverer"), h.authorize)
+	router.POST(prefix+"/tip", "AddTip", h.addTip, h.allowRoles("customer"), h.authorize)
+	router.POST(prefix+"/tip/pay", "PayTip", h.payTip, h.verifyPaymentCallback)
+	router.POST(prefix+"/review", "ReviewOrder", h.reviewOrder, h.allowRoles("customer"), h.authorize)
+	router.POST(prefix+"/refund", "RefundOrder", h.refundOrder, h.allowRoles("admin"), h.authorize)
+	router.GET(prefix+"/reconciliation", "ReconciliationReport", h.reconciliationReport, h.allowRoles("admin"), h.authorize)
+	router.GET(prefix+"/reconciliation/csv", "ExportReconciliationReport", h.exportReconciliationReport, h.allowRoles("admin"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "banks")
+	router.GET(prefix+"/list", "ListBanks", h.listBanks, h.allowRoles("admin"), h.authorize)
+	router.POST(prefix+"/create", "CreateBank", h.createBank, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/update", "UpdateBank", h.updateBank, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/enable/:bankid", "EnableBank", h.enableBank, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/disable/:bankid", "DisableBank", h.disableBank, h.allowRoles("admin"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "deliverers")
+	router.GET(prefix+"/earnings", "GetEarnings", h.getEarnings, h.allowRoles("deliverer"), h.authorize)
+	router.POST(prefix+"/location", "TrackLocation", h.trackLocation, h.allowRoles("deliverer"), h.authorize)
+	router.GET(prefix+"/reviews", "GetDelivererReviews", h.getDelivererReviews, h.allowRoles("deliverer"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "reviews")
+	router.GET(prefix+"/list", "ListReviews", h.listReviews, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/hide/:orderid", "HideReview", h.hideReview, h.allowRoles("admin"), h.authorize)
+	router.PUT(prefix+"/show/:orderid", "ShowReview", h.showReview, h.allowRoles("admin"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "webhooks")
+	router.GET(prefix+"/list", "ListWebhookDeliveries", h.listWebhookDeliveries, h.allowRoles("admin"), h.authorize)
+	router.GET(prefix+"/get/:deliveryid", "GetWebhookDelivery", h.getWebhookDelivery, h.allowRoles("admin"), h.authorize)
+	router.POST(prefix+"/replay/:deliveryid", "ReplayWebhookDelivery", h.replayWebhookDelivery, h.allowRoles("admin"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "promos")
+	router.POST(prefix+"/create", "CreatePromoCode", h.createPromoCode, h.allowRoles("admin"), h.authorize)
+
+	prefix = fmt.Sprintf(prefixFmt, "payments")
+	router.GET(prefix+"/methods", "GetPaymentMethods", h.getPaymentMethods)
 
 	return router
 }
@@ -614,17 +699,43 @@ func (h *handler) deleteUser(c pkg.Context) {
 //
 //	@Summary		Get Partner Products
 //	@Tags			partners
-//	@Description	Returns available products of every partner
+//	@Description	Returns available products of the partners with the partner's rating, a page at a time
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	response.response{payload=products.GetAllResponse}
+//	@Param			q			query		string	false	"searched in the products' title and description"
+//	@Param			partner_id	query		int		false	"partner id"
+//	@Param			min_price	query		int		false	"the lowest price"
+//	@Param			max_price	query		int		false	"the highest price"
+//	@Param			category_id	query		int		false	"category id, its subcategories included"
+//	@Param			tag			query		string	false	"tag"
+//	@Param			sort		query		string	false	"rating - the best rated partners first; price_asc, price_desc, relevance - of a partner's products"
+//	@Param			limit		query		int		false	"page size, 20 by default, 100 at most"
+//	@Param			cursor		query		string	false	"next_cursor of the previous page"
+//	@Success		200			{object}	response.response{payload=products.GetAllResponse}
 //	@Router			/partners/products [get]
 //	@Security		Authorization Token
 func (h *handler) getPartnerProducts(c pkg.Context) {
 	ctx, span := c.StartSpan()
 	defer span.End()
 
-	products, err := h.service.getPartnerProducts(ctx)
+	var req = &productsRequest{
+		Query:      c.GetQueryValue("q"),
+		PartnerID:  c.GetQueryValue("partner_id"),
+		MinPrice:   c.GetQueryValue("min_price"),
+		MaxPrice:   c.GetQueryValue("max_price"),
+		CategoryID: c.GetQueryValue("category_id"),
+		Tag:        c.GetQueryValue("tag"),
+		Sort:       c.GetQueryValue("sort"),
+		Limit:      c.GetQueryValue("limit"),
+		Cursor:     c.GetQueryValue("cursor"),
+	}
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	products, err := h.service.getPartnerProducts(ctx, req)
 	if err != nil {
 		span.RecordError(errors.Wrap(err, "h.service.getPartnerProducts"))
 		c.Respond(response.Make(response.InternalServerErrorCode))
@@ -634,15 +745,35 @@ func (h *handler) getPartnerProducts(c pkg.Context) {
 	c.Respond(response.Make(response.OKCode).WithPayload(products))
 }
 
+// GetPartnerReviews godoc
+//
+//	@Summary		Get Partner Reviews
+//	@Tags			partners
+//	@Description	Returns the latest reviews of the partner with its rating
+//	@Produce		json
+//	@Param			partnerid	path		int	true	"partner id"
+//	@Param			limit		query		int	false	"20 by default, 100 at most"
+//	@Param			offset		query		int	false	"0 by default"
+//	@Success		200			{object}	response.response{payload=reviewsResponse}
+//	@Router			/partners/reviews/{partnerid} [get]
+//	@Security		Authorization Token
+func (h *handler) getPartnerReviews(c pkg.Context) {
+	h.respondReviews(c, &listReviewsRequest{
+		PartnerID: c.GetParam("partnerid"),
+		Limit:     c.GetQueryValue("limit"),
+		Offset:    c.GetQueryValue("offset"),
+	})
+}
+
 // CheckOrder godoc
 //
 //	@Summary		Check Order
 //	@Tags			orders
-//	@Description	user checks an order
+//	@Description	user checks an order and gets its price breakdown
 //	@Accept			json
 //	@Produce		json
 //	@Param			Request	body		checkRequest	true	"check order info"
-//	@Success		200		{object}	response.response
+//	@Success		200		{object}	response.response{payload=pricing}
 //	@Router			/orders/check [post]
 //	@Security		Authorization Token
 func (h *handler) checkOrder(c pkg.Context) {
@@ -669,14 +800,81 @@ func (h *handler) checkOrder(c pkg.Context) {
 		return
 	}
 
-	err = h.service.checkOrder(ctx, user, req)
+	pricing, err := h.service.checkOrder(ctx, user, req)
+	if checkFailed(c, err) {
+		return
+	}
 	if err != nil {
 		span.RecordError(errors.Wrap(err, "h.service.checkOrder"))
 		c.Respond(response.Make(response.InternalServerErrorCode))
 		return
 	}
 
-	c.Respond(response.Make(response.OKCode))
+	c.Respond(response.Make(response.OKCode).WithPayload(pricing))
+}
+
+// checkFailed responds with why the order can't be placed if the customer can fix it:
+// the partner is closed at the time or short of the products' stock.
+func checkFailed(c pkg.Context, err error) bool {
+	var closed *closedError
+	if errors.As(err, &closed) {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("partner is closed").WithPayload(closed))
+		return true
+	}
+	var shortage *shortageError
+	if errors.As(err, &shortage) {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("insufficient stock").WithPayload(shortage.Shortages))
+		return true
+	}
+	return false
+}
+
+// Reorder godoc
+//
+//	@Summary		Reorder
+//	@Tags			orders
+//	@Description	customer checks the products of their past order again at the current prices, the products no longer available are left out; the checked order is confirmed as usual
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		reorderRequest	true	"reorder info"
+//	@Success		200		{object}	response.response{payload=reorderResponse}
+//	@Router			/orders/reorder [post]
+//	@Security		Authorization Token
+func (h *handler) reorder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &reorderRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.reorder(ctx, user, req)
+	if checkFailed(c, err) {
+		return
+	}
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.reorder"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
 }
 
 // ConfirmOrder godoc
@@ -724,6 +922,263 @@ func (h *handler) confirmOrder(c pkg.Context) {
 	c.Respond(response.Make(response.PendingCode).WithPayload(resp))
 }
 
+// CheckCart godoc
+//
+//	@Summary		Check a Multi-Partner Cart
+//	@Tags			orders
+//	@Description	user checks a cart with the products of several partners and gets the price breakdown of each partner's order
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		cartRequest	true	"check cart info"
+//	@Success		200		{object}	response.response{payload=cartPricing}
+//	@Router			/orders/cart/check [post]
+//	@Security		Authorization Token
+func (h *handler) checkCart(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &cartRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.checkCart(ctx, user, req)
+	if checkFailed(c, err) {
+		return
+	}
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.checkCart"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ConfirmCart godoc
+//
+//	@Summary		Confirm a Multi-Partner Cart
+//	@Tags			orders
+//	@Description	user confirms the checked cart, it is saved as linked orders, one per partner, paid in a single payment
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		confirmRequest	true	"confirm cart info"
+//	@Success		200		{object}	response.response{payload=cartResponse}
+//	@Router			/orders/cart/confirm [post]
+//	@Security		Authorization Token
+func (h *handler) confirmCart(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &confirmRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.confirmCart(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.confirmCart"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.PendingCode).WithPayload(resp))
+}
+
+// PayCart godoc
+//
+//	@Summary		Pay a Cart Callback
+//	@Tags			orders
+//	@Description	bank sends the payment callback of a multi-partner cart, order_id is the checkout id
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Bank-ID	header		string		true	"bank id (paytype)"
+//	@Param			X-Timestamp	header		string		true	"unix seconds when the callback was signed"
+//	@Param			X-Nonce		header		string		true	"unique callback id"
+//	@Param			X-Signature	header		string		true	"hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)"
+//	@Param			Request		body		payRequest	true	"pay cart info"
+//	@Success		200			{object}	response.response{payload=payResponse}
+//	@Failure		401			"Unauthorized"
+//	@Router			/orders/cart/pay [post]
+func (h *handler) payCart(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	bankID, found := c.GetValue(_BANK_ID_KEY).(string)
+	if !found || bankID == "" {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &payRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.payCart(ctx, bankID, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.payCart"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// TrackOrder godoc
+//
+//	@Summary		Track the Order
+//	@Tags			orders
+//	@Description	customer follows their order as server-sent events: the current state first, then every status change, deliverer assignment and ETA update until the order is finished
+//	@Produce		text/event-stream
+//	@Param			orderid	path		int	true	"order id"
+//	@Success		200		{object}	orderEvent
+//	@Router			/orders/track/{orderid} [get]
+//	@Security		Authorization Token
+func (h *handler) trackOrder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.GetParam("orderid"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid order id"))
+		return
+	}
+
+	var streaming bool
+	err = h.service.watchOrder(ctx, user, orderID, func(event *orderEvent) error {
+		streaming = true
+		return c.SendEvent(event.Event, event)
+	})
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.watchOrder"))
+		// once the stream is open, the client sees the end of it
+		if !streaming {
+			c.Respond(response.Make(response.InternalServerErrorCode))
+		}
+	}
+}
+
+// DownloadReceipt godoc
+//
+//	@Summary		Download the Receipt
+//	@Tags			orders
+//	@Description	customer downloads the PDF receipt of their paid order, the same one that is emailed after the payment
+//	@Produce		application/pdf
+//	@Param			orderid	path	int	true	"order id"
+//	@Success		302		"redirect to the receipt"
+//	@Router			/orders/receipt/{orderid} [get]
+//	@Security		Authorization Token
+func (h *handler) downloadReceipt(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.GetParam("orderid"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid order id"))
+		return
+	}
+
+	receiptURL, err := h.service.getReceiptURL(ctx, user, orderID)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getReceiptURL"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Redirect(receiptURL, 0)
+}
+
+// GetOrderLocation godoc
+//
+//	@Summary		Locate the Deliverer
+//	@Tags			orders
+//	@Description	customer gets the latest position of the deliverer of their order on the way and the estimated minutes to the delivery address
+//	@Produce		json
+//	@Param			orderid	path		int	true	"order id"
+//	@Success		200		{object}	response.response{payload=deliveryLocation}
+//	@Router			/orders/location/{orderid} [get]
+//	@Security		Authorization Token
+func (h *handler) getOrderLocation(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.GetParam("orderid"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid order id"))
+		return
+	}
+
+	resp, err := h.service.getOrderLocation(ctx, user, orderID)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getOrderLocation"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
 // PayOrder godoc
 //
 //	@Summary		Pay an Order Callback
@@ -731,13 +1186,25 @@ func (h *handler) confirmOrder(c pkg.Context) {
 //	@Description	bank sends order payment callback
 //	@Accept			json
 //	@Produce		json
-//	@Param			Request	body		payRequest	true	"pay order info"
-//	@Success		200		{object}	response.response{payload=payResponse}
+//	@Param			X-Bank-ID	header		string		true	"bank id (paytype)"
+//	@Param			X-Timestamp	header		string		true	"unix seconds when the callback was signed"
+//	@Param			X-Nonce		header		string		true	"unique callback id"
+//	@Param			X-Signature	header		string		true	"hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)"
+//	@Param			Request		body		payRequest	true	"pay order info"
+//	@Success		200			{object}	response.response{payload=payResponse}
+//	@Failure		401			"Unauthorized"
 //	@Router			/orders/pay [post]
 func (h *handler) payOrder(c pkg.Context) {
 	ctx, span := c.StartSpan()
 	defer span.End()
 
+	bankID, found := c.GetValue(_BANK_ID_KEY).(string)
+	if !found || bankID == "" {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
 	var req = &payRequest{}
 	err := c.ParseBody(req)
 	if err != nil {
@@ -751,7 +1218,7 @@ func (h *handler) payOrder(c pkg.Context) {
 		return
 	}
 
-	resp, err := h.service.payOrder(ctx, req)
+	resp, err := h.service.payOrder(ctx, bankID, req)
 	if err != nil {
 		span.RecordError(errors.Wrap(err, "h.service.payOrder"))
 		c.Respond(response.Make(response.InternalServerErrorCode))
@@ -806,7 +1273,7 @@ func (h *handler) pickUpOrder(c pkg.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			Request	body		assignRequest	true	"assign order info"
-//	@Success		200		{object}	response.response
+//	@Success		200		{object}	response.response{payload=assignResponse}
 //	@Router			/orders/assign [post]
 //	@Security		Authorization Token
 func (h *handler) assignOrder(c pkg.Context) {
@@ -833,26 +1300,1874 @@ func (h *handler) assignOrder(c pkg.Context) {
 		return
 	}
 
-	err = h.service.assignOrder(ctx, user, assignRequest)
+	resp, err := h.service.assignOrder(ctx, user, assignRequest)
 	if err != nil {
 		span.RecordError(errors.Wrap(err, "h.service.assignOrder"))
 		c.Respond(response.Make(response.InternalServerErrorCode))
 		return
 	}
 
-	c.Respond(response.Make(response.OKCode))
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
 }
 
-func (h *handler) authorize(actionName string, next pkg.Handler) pkg.Handler {
-	return pkg.Handler(func(c pkg.Context) {
-		header := c.GetHeader("Authorization") // check user role in Token and give access => authorize
-		if header == "" {
-			message := "empty auth header"
-			statusCode := response.UnauthorizedCode
-			c.Respond(response.Make(statusCode).WithMessage(message))
-			return
-		}
-
+// UpdateOrderAddress godoc
+//
+//	@Summary		Update Order Delivery Address
+//	@Tags			orders
+//	@Description	customer changes the delivery address of their order before it is picked up, no farther from the partner than the delivery fee is priced for
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		updateAddressRequest	true	"update order address info"
+//	@Success		200		{object}	response.response
+//	@Router			/orders/address [put]
+//	@Security		Authorization Token
+func (h *handler) updateOrderAddress(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &updateAddressRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	err = h.service.updateOrderAddress(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updateOrderAddress"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode))
+}
+
+// CancelOrder godoc
+//
+//	@Summary		Cancel an Order
+//	@Tags			orders
+//	@Description	customer cancels their order before paying it, the applied promo code can be used again
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		cancelRequest	true	"cancel order info"
+//	@Success		200		{object}	response.response
+//	@Router			/orders/cancel [post]
+//	@Security		Authorization Token
+func (h *handler) cancelOrder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &cancelRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	err = h.service.cancelOrder(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.cancelOrder"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode))
+}
+
+// CompleteOrder godoc
+//
+//	@Summary		Complete the Order
+//	@Tags			orders
+//	@Description	deliverer hands the order over to the customer and gets its delivery fee share and tip credited
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		completeRequest	true	"complete order info"
+//	@Success		200		{object}	response.response{payload=completeResponse}
+//	@Router			/orders/complete [post]
+//	@Security		Authorization Token
+func (h *handler) completeOrder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &completeRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.completeOrder(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.completeOrder"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// AddTip godoc
+//
+//	@Summary		Tip the Deliverer
+//	@Tags			orders
+//	@Description	customer tips the deliverer of a delivered order and gets the web checkout page to pay the tip
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		tipRequest	true	"tip info"
+//	@Success		200		{object}	response.response{payload=tipResponse}
+//	@Router			/orders/tip [post]
+//	@Security		Authorization Token
+func (h *handler) addTip(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &tipRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.addTip(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.addTip"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// PayTip godoc
+//
+//	@Summary		Pay a Tip Callback
+//	@Tags			orders
+//	@Description	bank sends tip payment callback
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Bank-ID	header		string		true	"bank id (paytype)"
+//	@Param			X-Timestamp	header		string		true	"unix seconds when the callback was signed"
+//	@Param			X-Nonce		header		string		true	"unique callback id"
+//	@Param			X-Signature	header		string		true	"hash_hmac('sha256', timestamp+'.'+nonce+'.'+raw_body, callbackSecret)"
+//	@Param			Request		body		payRequest	true	"pay tip info"
+//	@Success		200			{object}	response.response{payload=payResponse}
+//	@Failure		401			"Unauthorized"
+//	@Router			/orders/tip/pay [post]
+func (h *handler) payTip(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	bankID, found := c.GetValue(_BANK_ID_KEY).(string)
+	if !found || bankID == "" {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &payRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.payTip(ctx, bankID, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.payTip"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ReviewOrder godoc
+//
+//	@Summary		Review the Order
+//	@Tags			orders
+//	@Description	customer rates the partner and the deliverer of their delivered order, once per order
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		reviewRequest	true	"review info"
+//	@Success		200		{object}	response.response{payload=review}
+//	@Router			/orders/review [post]
+//	@Security		Authorization Token
+func (h *handler) reviewOrder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &reviewRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createReview(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createReview"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// RefundOrder godoc
+//
+//	@Summary		Refund the Order
+//	@Tags			orders
+//	@Description	admin refunds the whole order or some of its products to the customer
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		refundRequest	true	"refund info"
+//	@Success		200		{object}	response.response{payload=refundResponse}
+//	@Router			/orders/refund [post]
+//	@Security		Authorization Token
+func (h *handler) refundOrder(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &refundRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.refundOrder(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.refundOrder"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ReconciliationReport godoc
+//
+//	@Summary		Payment Reconciliation Report
+//	@Tags			orders
+//	@Description	finance gets the payment discrepancies (overpayments, missing and orphan payments) detected in the period
+//	@Produce		json
+//	@Param			date_from	query		string	true	"YYYY-MM-DD"
+//	@Param			date_to		query		string	true	"YYYY-MM-DD"
+//	@Success		200			{object}	response.response{payload=[]discrepancy}
+//	@Router			/orders/reconciliation [get]
+//	@Security		Authorization Token
+func (h *handler) reconciliationReport(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &reportRequest{
+		DateFrom: c.GetQueryValue("date_from"),
+		DateTo:   c.GetQueryValue("date_to"),
+	}
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.reconciliationReport(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.reconciliationReport"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ExportReconciliationReport godoc
+//
+//	@Summary		Export Payment Reconciliation Report
+//	@Tags			orders
+//	@Description	finance downloads the payment discrepancies detected in the period as a CSV file
+//	@Produce		text/csv
+//	@Param			date_from	query	string	true	"YYYY-MM-DD"
+//	@Param			date_to		query	string	true	"YYYY-MM-DD"
+//	@Success		200			{file}	file
+//	@Router			/orders/reconciliation/csv [get]
+//	@Security		Authorization Token
+func (h *handler) exportReconciliationReport(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &reportRequest{
+		DateFrom: c.GetQueryValue("date_from"),
+		DateTo:   c.GetQueryValue("date_to"),
+	}
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	content, err := h.service.reconciliationCSV(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.reconciliationCSV"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Attach("reconciliation_"+req.DateFrom+"_"+req.DateTo+".csv", "text/csv", content)
+}
+
+// ListBanks godoc
+//
+//	@Summary		List Banks
+//	@Tags			banks
+//	@Description	admin gets all the banks, including the disabled ones
+//	@Produce		json
+//	@Success		200	{object}	response.response{payload=[]bankResponse}
+//	@Router			/banks/list [get]
+//	@Security		Authorization Token
+func (h *handler) listBanks(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	resp, err := h.service.listBanks(ctx)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listBanks"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetEarnings godoc
+//
+//	@Summary		Deliverer Earnings
+//	@Tags			deliverers
+//	@Description	deliverer gets their daily and weekly earnings (delivery fee shares and tips) and the pending payout
+//	@Produce		json
+//	@Param			date	query		string	false	"YYYY-MM-DD, the last day of the week, today by default"
+//	@Success		200		{object}	response.response{payload=earningsResponse}
+//	@Router			/deliverers/earnings [get]
+//	@Security		Authorization Token
+func (h *handler) getEarnings(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &earningsRequest{Date: c.GetQueryValue("date")}
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.getEarnings(ctx, user, req.Date)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getEarnings"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// TrackLocation godoc
+//
+//	@Summary		Send the Location
+//	@Tags			deliverers
+//	@Description	deliverer posts a GPS ping while delivering their order, the customer sees it with the ETA
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		locationRequest	true	"deliverer's position"
+//	@Success		200		{object}	response.response{payload=deliveryLocation}
+//	@Router			/deliverers/location [post]
+//	@Security		Authorization Token
+func (h *handler) trackLocation(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &locationRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.trackLocation(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.trackLocation"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetDelivererReviews godoc
+//
+//	@Summary		Deliverer Reviews
+//	@Tags			deliverers
+//	@Description	deliverer gets the latest reviews of their deliveries with their rating
+//	@Produce		json
+//	@Param			limit	query		int	false	"20 by default, 100 at most"
+//	@Param			offset	query		int	false	"0 by default"
+//	@Success		200		{object}	response.response{payload=reviewsResponse}
+//	@Router			/deliverers/reviews [get]
+//	@Security		Authorization Token
+func (h *handler) getDelivererReviews(c pkg.Context) {
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+	h.respondReviews(c, &listReviewsRequest{
+		DelivererID: user.ID,
+		Limit:       c.GetQueryValue("limit"),
+		Offset:      c.GetQueryValue("offset"),
+	})
+}
+
+// ListReviews godoc
+//
+//	@Summary		List Reviews
+//	@Tags			reviews
+//	@Description	admin lists the latest reviews, hidden ones too, to moderate them
+//	@Produce		json
+//	@Param			partner_id		query		int		false	"partner id"
+//	@Param			deliverer_id	query		string	false	"deliverer id"
+//	@Param			include_hidden	query		bool	false	"include the hidden reviews"
+//	@Param			limit			query		int		false	"20 by default, 100 at most"
+//	@Param			offset			query		int		false	"0 by default"
+//	@Success		200				{object}	response.response{payload=reviewsResponse}
+//	@Router			/reviews/list [get]
+//	@Security		Authorization Token
+func (h *handler) listReviews(c pkg.Context) {
+	h.respondReviews(c, &listReviewsRequest{
+		PartnerID:     c.GetQueryValue("partner_id"),
+		DelivererID:   c.GetQueryValue("deliverer_id"),
+		IncludeHidden: c.GetQueryValue("include_hidden"),
+		Limit:         c.GetQueryValue("limit"),
+		Offset:        c.GetQueryValue("offset"),
+	})
+}
+
+func (h *handler) respondReviews(c pkg.Context, req *listReviewsRequest) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.listReviews(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listReviews"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// HideReview godoc
+//
+//	@Summary		Hide a Review
+//	@Tags			reviews
+//	@Description	admin hides an abusive review, it is no longer shown nor counted in the ratings
+//	@Produce		json
+//	@Param			orderid	path		int	true	"order id of the review"
+//	@Success		200		{object}	response.response{payload=review}
+//	@Router			/reviews/hide/{orderid} [put]
+//	@Security		Authorization Token
+func (h *handler) hideReview(c pkg.Context) {
+	h.setReviewHidden(c, true)
+}
+
+// ShowReview godoc
+//
+//	@Summary		Show a Review
+//	@Tags			reviews
+//	@Description	admin shows a hidden review back
+//	@Produce		json
+//	@Param			orderid	path		int	true	"order id of the review"
+//	@Success		200		{object}	response.response{payload=review}
+//	@Router			/reviews/show/{orderid} [put]
+//	@Security		Authorization Token
+func (h *handler) showReview(c pkg.Context) {
+	h.setReviewHidden(c, false)
+}
+
+func (h *handler) setReviewHidden(c pkg.Context, hidden bool) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	orderID, err := strconv.ParseInt(c.GetParam("orderid"), 10, 64)
+	if err != nil || orderID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid order id"))
+		return
+	}
+
+	resp, err := h.service.setReviewHidden(ctx, orderID, hidden)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setReviewHidden"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// CreatePromoCode godoc
+//
+//	@Summary		Create a Promo Code
+//	@Tags			promos
+//	@Description	admin creates a percent or fixed promo code with its validity window and usage limits
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		promoCodeRequest	true	"promo code info"
+//	@Success		200		{object}	response.response{payload=promoCodeResponse}
+//	@Router			/promos/create [post]
+//	@Security		Authorization Token
+func (h *handler) createPromoCode(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &promoCodeRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createPromoCode(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createPromoCode"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// CreateBank godoc
+//
+//	@Summary		Create a Bank
+//	@Tags			banks
+//	@Description	admin adds a bank (payment method), the callback secret is generated when empty and shown once
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		bankRequest	true	"bank info"
+//	@Success		200		{object}	response.response{payload=bankResponse}
+//	@Router			/banks/create [post]
+//	@Security		Authorization Token
+func (h *handler) createBank(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &bankRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createBank(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createBank"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// UpdateBank godoc
+//
+//	@Summary		Update a Bank
+//	@Tags			banks
+//	@Description	admin updates a bank (payment method), the callback secret is kept when empty
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		bankRequest	true	"bank info"
+//	@Success		200		{object}	response.response{payload=bankResponse}
+//	@Router			/banks/update [put]
+//	@Security		Authorization Token
+func (h *handler) updateBank(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &bankRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.updateBank(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updateBank"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// EnableBank godoc
+//
+//	@Summary		Enable a Bank
+//	@Tags			banks
+//	@Description	admin lets customers pay with the bank again
+//	@Produce		json
+//	@Param			bankid	path		string	true	"bank id"
+//	@Success		200		{object}	response.response{payload=bankResponse}
+//	@Router			/banks/enable/{bankid} [put]
+//	@Security		Authorization Token
+func (h *handler) enableBank(c pkg.Context) {
+	h.setBankActive(c, true)
+}
+
+// DisableBank godoc
+//
+//	@Summary		Disable a Bank
+//	@Tags			banks
+//	@Description	admin stops customers from paying with the bank
+//	@Produce		json
+//	@Param			bankid	path		string	true	"bank id"
+//	@Success		200		{object}	response.response{payload=bankResponse}
+//	@Router			/banks/disable/{bankid} [put]
+//	@Security		Authorization Token
+func (h *handler) disableBank(c pkg.Context) {
+	h.setBankActive(c, false)
+}
+
+func (h *handler) setBankActive(c pkg.Context, active bool) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	bankID := c.GetParam("bankid")
+	errMessage := c.ValidateVar(bankID, "required,alphanum,max=10")
+	if errMessage != "" {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateVar: " + errMessage))
+		return
+	}
+
+	resp, err := h.service.setBankActive(ctx, bankID, active)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setBankActive"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ListPartners godoc
+//
+//	@Summary		List Partners
+//	@Tags			partners
+//	@Description	admin gets all the partners, including the disabled ones
+//	@Produce		json
+//	@Success		200	{object}	response.response{payload=[]partnerResponse}
+//	@Router			/partners/list [get]
+//	@Security		Authorization Token
+func (h *handler) listPartners(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	resp, err := h.service.listPartners(ctx)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listPartners"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetPartner godoc
+//
+//	@Summary		Get a Partner
+//	@Tags			partners
+//	@Description	admin gets the partner's details
+//	@Produce		json
+//	@Param			partnerid	path		int	true	"partner id"
+//	@Success		200			{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/get/{partnerid} [get]
+//	@Security		Authorization Token
+func (h *handler) getPartner(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	partnerID, err := strconv.ParseInt(c.GetParam("partnerid"), 10, 32)
+	if err != nil || partnerID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+		return
+	}
+
+	resp, err := h.service.getPartner(ctx, int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getPartner"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// CreatePartner godoc
+//
+//	@Summary		Create a Partner
+//	@Tags			partners
+//	@Description	admin adds a partner, the paid orders are sent to its api url
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		partnerRequest	true	"partner info"
+//	@Success		200		{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/create [post]
+//	@Security		Authorization Token
+func (h *handler) createPartner(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &partnerRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createPartner(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createPartner"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// UpdatePartner godoc
+//
+//	@Summary		Update a Partner
+//	@Tags			partners
+//	@Description	admin updates the partner's details and verification, see enable/disable for the rest
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		partnerRequest	true	"partner info"
+//	@Success		200		{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/update [put]
+//	@Security		Authorization Token
+func (h *handler) updatePartner(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &partnerRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 || req.ID == 0 {
+		errs = append(errs, "id is required")
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.updatePartner(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updatePartner"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// EnablePartner godoc
+//
+//	@Summary		Enable a Partner
+//	@Tags			partners
+//	@Description	admin puts the partner's products on sale again
+//	@Produce		json
+//	@Param			partnerid	path		int	true	"partner id"
+//	@Success		200			{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/enable/{partnerid} [put]
+//	@Security		Authorization Token
+func (h *handler) enablePartner(c pkg.Context) {
+	h.setPartnerEnabled(c, true)
+}
+
+// DisablePartner godoc
+//
+//	@Summary		Disable a Partner
+//	@Tags			partners
+//	@Description	admin takes the partner's products off sale
+//	@Produce		json
+//	@Param			partnerid	path		int	true	"partner id"
+//	@Success		200			{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/disable/{partnerid} [put]
+//	@Security		Authorization Token
+func (h *handler) disablePartner(c pkg.Context) {
+	h.setPartnerEnabled(c, false)
+}
+
+func (h *handler) setPartnerEnabled(c pkg.Context, enabled bool) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	partnerID, err := strconv.ParseInt(c.GetParam("partnerid"), 10, 32)
+	if err != nil || partnerID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+		return
+	}
+
+	resp, err := h.service.setPartnerEnabled(ctx, int32(partnerID), enabled)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setPartnerEnabled"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// PausePartner godoc
+//
+//	@Summary		Pause a Partner
+//	@Tags			partners
+//	@Description	partner stops taking orders at once, whatever its opening hours are, until resumed
+//	@Produce		json
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/pause [put]
+//	@Security		Authorization Token
+func (h *handler) pausePartner(c pkg.Context) {
+	h.setPartnerPaused(c, true)
+}
+
+// ResumePartner godoc
+//
+//	@Summary		Resume a Partner
+//	@Tags			partners
+//	@Description	partner takes orders again within its opening hours
+//	@Produce		json
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=partnerResponse}
+//	@Router			/partners/resume [put]
+//	@Security		Authorization Token
+func (h *handler) resumePartner(c pkg.Context) {
+	h.setPartnerPaused(c, false)
+}
+
+func (h *handler) setPartnerPaused(c pkg.Context, paused bool) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var partnerID int64
+	if value := c.GetQueryValue("partner_id"); value != "" {
+		var err error
+		partnerID, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || partnerID <= 0 {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+			return
+		}
+	}
+
+	resp, err := h.service.setPartnerPaused(ctx, user, int32(partnerID), paused)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setPartnerPaused"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetOpeningHours godoc
+//
+//	@Summary		Get Opening Hours
+//	@Tags			partners
+//	@Description	partner gets its weekly opening hours and the upcoming closures, admin gets the partner's of the query
+//	@Produce		json
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=openingHoursResponse}
+//	@Router			/partners/hours/get [get]
+//	@Security		Authorization Token
+func (h *handler) getOpeningHours(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var partnerID int64
+	if value := c.GetQueryValue("partner_id"); value != "" {
+		var err error
+		partnerID, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || partnerID <= 0 {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+			return
+		}
+	}
+
+	resp, err := h.service.getOpeningHours(ctx, user, int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getOpeningHours"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// SetOpeningHours godoc
+//
+//	@Summary		Set Opening Hours
+//	@Tags			partners
+//	@Description	replaces the partner's weekly opening hours and closures, e.g. holidays, orders are taken within them only
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		openingHoursRequest	true	"opening hours in the partner's timezone"
+//	@Success		200		{object}	response.response{payload=openingHoursResponse}
+//	@Router			/partners/hours/set [put]
+//	@Security		Authorization Token
+func (h *handler) setOpeningHours(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &openingHoursRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.setOpeningHours(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setOpeningHours"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ListProducts godoc
+//
+//	@Summary		List Products
+//	@Tags			products
+//	@Description	admin gets the whole catalog, partner gets the admins' catalog and its own products
+//	@Produce		json
+//	@Success		200	{object}	response.response{payload=[]productResponse}
+//	@Router			/products/list [get]
+//	@Security		Authorization Token
+func (h *handler) listProducts(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	resp, err := h.service.listProducts(ctx, user)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listProducts"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// CreateProduct godoc
+//
+//	@Summary		Create a Product
+//	@Tags			products
+//	@Description	admin adds a product to the catalog or for a partner, partner adds its own product
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		productRequest	true	"product info"
+//	@Success		200		{object}	response.response{payload=productResponse}
+//	@Router			/products/create [post]
+//	@Security		Authorization Token
+func (h *handler) createProduct(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &productRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createProduct(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createProduct"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// UpdateProduct godoc
+//
+//	@Summary		Update a Product
+//	@Tags			products
+//	@Description	admin updates any product, partner updates its own products only
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		productRequest	true	"product info"
+//	@Success		200		{object}	response.response{payload=productResponse}
+//	@Router			/products/update [put]
+//	@Security		Authorization Token
+func (h *handler) updateProduct(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &productRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 || req.ID == 0 {
+		errs = append(errs, "id is required")
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.updateProduct(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updateProduct"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// UpdateProductPicture godoc
+//
+//	@Summary		Update Product Picture
+//	@Tags			products
+//	@Description	admin updates any product's picture, partner its own products' only
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			productid	path		int		true	"product id"
+//	@Param			picture		formData	file	true	"picture file"
+//	@Success		200			{object}	response.response{payload=productResponse}
+//	@Router			/products/picture/{productid} [put]
+//	@Security		Authorization Token
+func (h *handler) updateProductPicture(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.GetParam("productid"), 10, 32)
+	if err != nil || productID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid product id"))
+		return
+	}
+
+	file, fileInfo, err := c.OpenFormFile("picture")
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "c.OpenFormFile"))
+		c.Respond(response.Make(response.NotFoundCode).WithMessage("OpenFormFile: " + err.Error()))
+		return
+	}
+	defer file.Close()
+
+	resp, err := h.service.updateProductPicture(ctx, user, int32(productID), file, fileInfo)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updateProductPicture"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// DeleteProduct godoc
+//
+//	@Summary		Delete a Product
+//	@Tags			products
+//	@Description	admin deletes any product, partner its own products only; the product is taken off sale by all the partners
+//	@Produce		json
+//	@Param			productid	path		int	true	"product id"
+//	@Success		200			{object}	response.response
+//	@Router			/products/delete/{productid} [delete]
+//	@Security		Authorization Token
+func (h *handler) deleteProduct(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.GetParam("productid"), 10, 32)
+	if err != nil || productID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid product id"))
+		return
+	}
+
+	err = h.service.deleteProduct(ctx, user, int32(productID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.deleteProduct"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode))
+}
+
+// ListAvailable godoc
+//
+//	@Summary		List Partner Listings
+//	@Tags			products
+//	@Description	partner gets the products it sells with prices, admin gets the partner's of the query
+//	@Produce		json
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=[]availableResponse}
+//	@Router			/products/available/list [get]
+//	@Security		Authorization Token
+func (h *handler) listAvailable(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var partnerID int64
+	if value := c.GetQueryValue("partner_id"); value != "" {
+		var err error
+		partnerID, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || partnerID <= 0 {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+			return
+		}
+	}
+
+	resp, err := h.service.listAvailable(ctx, user, int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listAvailable"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// SetAvailable godoc
+//
+//	@Summary		Set a Partner Listing
+//	@Tags			products
+//	@Description	puts the product on sale by the partner or changes its price and activity
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		availableRequest	true	"listing info"
+//	@Success		200		{object}	response.response{payload=availableResponse}
+//	@Router			/products/available/set [put]
+//	@Security		Authorization Token
+func (h *handler) setAvailable(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &availableRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.setAvailable(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setAvailable"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// DeleteAvailable godoc
+//
+//	@Summary		Delete a Partner Listing
+//	@Tags			products
+//	@Description	takes the product off sale by the partner
+//	@Produce		json
+//	@Param			productid	path		int	true	"product id"
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=availableResponse}
+//	@Router			/products/available/delete/{productid} [delete]
+//	@Security		Authorization Token
+func (h *handler) deleteAvailable(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.GetParam("productid"), 10, 32)
+	if err != nil || productID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid product id"))
+		return
+	}
+
+	var partnerID int64
+	if value := c.GetQueryValue("partner_id"); value != "" {
+		partnerID, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || partnerID <= 0 {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+			return
+		}
+	}
+
+	resp, err := h.service.deleteAvailable(ctx, user, int32(productID), int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.deleteAvailable"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// SetProductLabels godoc
+//
+//	@Summary		Set Product Labels
+//	@Tags			products
+//	@Description	replaces the categories and the tags of a product: admin of any product, partner of its own products only
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		labelsRequest	true	"the product's categories and tags"
+//	@Success		200		{object}	response.response{payload=labelsResponse}
+//	@Router			/products/labels [put]
+//	@Security		Authorization Token
+func (h *handler) setProductLabels(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &labelsRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.setProductLabels(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setProductLabels"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetProductOptions godoc
+//
+//	@Summary		Get Product Options
+//	@Tags			partners
+//	@Description	the variants and the modifiers a partner's product is ordered with, their IDs are chosen on the order check
+//	@Produce		json
+//	@Param			product_id	query		int	true	"product id"
+//	@Param			partner_id	query		int	true	"partner id"
+//	@Success		200			{object}	response.response{payload=productOptions}
+//	@Router			/partners/options [get]
+//	@Security		Authorization Token
+func (h *handler) getProductOptions(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	productID, err := strconv.ParseInt(c.GetQueryValue("product_id"), 10, 32)
+	if err != nil || productID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid product id"))
+		return
+	}
+	partnerID, err := strconv.ParseInt(c.GetQueryValue("partner_id"), 10, 32)
+	if err != nil || partnerID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+		return
+	}
+
+	resp, err := h.service.getProductOptions(ctx, int32(productID), int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getProductOptions"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// SetProductOptions godoc
+//
+//	@Summary		Set Product Options
+//	@Tags			products
+//	@Description	replaces the variants and the modifier groups of a product a partner sells: partner of its own listings, admin of the partner's of the body
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		productOptions	true	"the product's options"
+//	@Success		200		{object}	response.response{payload=productOptions}
+//	@Router			/products/options [put]
+//	@Security		Authorization Token
+func (h *handler) setProductOptions(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var req = &productOptions{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.setProductOptions(ctx, user, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.setProductOptions"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// RotateWebhookSecret godoc
+//
+//	@Summary		Rotate Webhook Secret
+//	@Tags			partners
+//	@Description	replaces the secret the webhooks to the partner API are signed with and shows it once: partner of its own, admin of the partner's of the query
+//	@Produce		json
+//	@Param			partner_id	query		int	false	"partner id, admins only"
+//	@Success		200			{object}	response.response{payload=webhookSecretResponse}
+//	@Router			/partners/webhook/secret [put]
+//	@Security		Authorization Token
+func (h *handler) rotateWebhookSecret(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	user, found := c.GetValue(_USER_ID_KEY).(*user)
+	if !found || user == nil {
+		span.RecordError(errors.New("c.GetValue"))
+		c.Respond(response.Make(response.UnauthorizedCode))
+		return
+	}
+
+	var partnerID int64
+	if value := c.GetQueryValue("partner_id"); value != "" {
+		var err error
+		partnerID, err = strconv.ParseInt(value, 10, 32)
+		if err != nil || partnerID <= 0 {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid partner id"))
+			return
+		}
+	}
+
+	resp, err := h.service.rotateWebhookSecret(ctx, user, int32(partnerID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.rotateWebhookSecret"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ListWebhookDeliveries godoc
+//
+//	@Summary		List Webhook Deliveries
+//	@Tags			webhooks
+//	@Description	admin lists the latest webhooks sent to the partner APIs, the dead ones to replay them
+//	@Produce		json
+//	@Param			partner_id	query		int		false	"partner id"
+//	@Param			status		query		string	false	"pending, delivered or dead"
+//	@Param			order_id	query		int		false	"order id"
+//	@Param			limit		query		int		false	"20 by default, 100 at most"
+//	@Param			offset		query		int		false	"0 by default"
+//	@Success		200			{object}	response.response{payload=[]webhookDelivery}
+//	@Router			/webhooks/list [get]
+//	@Security		Authorization Token
+func (h *handler) listWebhookDeliveries(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &listDeliveriesRequest{
+		PartnerID: c.GetQueryValue("partner_id"),
+		Status:    c.GetQueryValue("status"),
+		OrderID:   c.GetQueryValue("order_id"),
+		Limit:     c.GetQueryValue("limit"),
+		Offset:    c.GetQueryValue("offset"),
+	}
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.listWebhookDeliveries(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.listWebhookDeliveries"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetWebhookDelivery godoc
+//
+//	@Summary		Get a Webhook Delivery
+//	@Tags			webhooks
+//	@Description	admin gets a webhook sent to a partner API with the log of its attempts
+//	@Produce		json
+//	@Param			deliveryid	path		int	true	"delivery id"
+//	@Success		200			{object}	response.response{payload=webhookDelivery}
+//	@Router			/webhooks/get/{deliveryid} [get]
+//	@Security		Authorization Token
+func (h *handler) getWebhookDelivery(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	deliveryID, err := strconv.ParseInt(c.GetParam("deliveryid"), 10, 64)
+	if err != nil || deliveryID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid delivery id"))
+		return
+	}
+
+	resp, err := h.service.getWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getWebhookDelivery"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// ReplayWebhookDelivery godoc
+//
+//	@Summary		Replay a Webhook Delivery
+//	@Tags			webhooks
+//	@Description	admin sends a webhook to the partner API again at once, e.g. a dead one once the API is fixed
+//	@Produce		json
+//	@Param			deliveryid	path		int	true	"delivery id"
+//	@Success		200			{object}	response.response{payload=webhookDelivery}
+//	@Router			/webhooks/replay/{deliveryid} [post]
+//	@Security		Authorization Token
+func (h *handler) replayWebhookDelivery(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	deliveryID, err := strconv.ParseInt(c.GetParam("deliveryid"), 10, 64)
+	if err != nil || deliveryID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid delivery id"))
+		return
+	}
+
+	resp, err := h.service.replayWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.replayWebhookDelivery"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetCategories godoc
+//
+//	@Summary		Get Categories
+//	@Tags			categories
+//	@Description	the categories tree with the number of the products on sale in each, the subcategories' included
+//	@Produce		json
+//	@Success		200	{object}	response.response{payload=[]categoryResponse}
+//	@Router			/categories/tree [get]
+//	@Security		Authorization Token
+func (h *handler) getCategories(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	resp, err := h.service.getCategories(ctx)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getCategories"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// CreateCategory godoc
+//
+//	@Summary		Create a Category
+//	@Tags			categories
+//	@Description	admin adds a top-level category or a subcategory
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		categoryRequest	true	"category info"
+//	@Success		200		{object}	response.response{payload=categoryResponse}
+//	@Router			/categories/create [post]
+//	@Security		Authorization Token
+func (h *handler) createCategory(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &categoryRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.createCategory(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.createCategory"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// UpdateCategory godoc
+//
+//	@Summary		Update a Category
+//	@Tags			categories
+//	@Description	admin renames a category or moves it under another parent
+//	@Accept			json
+//	@Produce		json
+//	@Param			Request	body		categoryRequest	true	"category info"
+//	@Success		200		{object}	response.response{payload=categoryResponse}
+//	@Router			/categories/update [put]
+//	@Security		Authorization Token
+func (h *handler) updateCategory(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	var req = &categoryRequest{}
+	err := c.ParseBody(req)
+	if err != nil {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ParseBody: " + err.Error()))
+		return
+	}
+
+	errs := c.ValidateStruct(req)
+	if len(errs) != 0 || req.ID == 0 {
+		errs = append(errs, "id is required")
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("ValidateStruct: " + strings.Join(errs, "; ")))
+		return
+	}
+
+	resp, err := h.service.updateCategory(ctx, req)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.updateCategory"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// DeleteCategory godoc
+//
+//	@Summary		Delete a Category
+//	@Tags			categories
+//	@Description	admin deletes a category without subcategories, its products are unlinked from it
+//	@Produce		json
+//	@Param			categoryid	path		int	true	"category id"
+//	@Success		200			{object}	response.response{payload=categoryResponse}
+//	@Router			/categories/delete/{categoryid} [delete]
+//	@Security		Authorization Token
+func (h *handler) deleteCategory(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	categoryID, err := strconv.ParseInt(c.GetParam("categoryid"), 10, 32)
+	if err != nil || categoryID <= 0 {
+		c.Respond(response.Make(response.BadRequestCode).WithMessage("invalid category id"))
+		return
+	}
+
+	resp, err := h.service.deleteCategory(ctx, int32(categoryID))
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.deleteCategory"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+// GetPaymentMethods godoc
+//
+//	@Summary		Get Payment Methods
+//	@Tags			payments
+//	@Description	get the active payment methods, their IDs are the paytypes of the orders
+//	@Produce		json
+//	@Success		200	{object}	response.response{payload=[]paymentMethod}
+//	@Router			/payments/methods [get]
+func (h *handler) getPaymentMethods(c pkg.Context) {
+	ctx, span := c.StartSpan()
+	defer span.End()
+
+	resp, err := h.service.getPaymentMethods(ctx)
+	if err != nil {
+		span.RecordError(errors.Wrap(err, "h.service.getPaymentMethods"))
+		c.Respond(response.Make(response.InternalServerErrorCode))
+		return
+	}
+
+	c.Respond(response.Make(response.OKCode).WithPayload(resp))
+}
+
+func (h *handler) authorize(actionName string, next pkg.Handler) pkg.Handler {
+	return pkg.Handler(func(c pkg.Context) {
+		header := c.GetHeader("Authorization") // check user role in Token and give access => authorize
+		if header == "" {
+			message := "empty auth header"
+			statusCode := response.UnauthorizedCode
+			c.Respond(response.Make(statusCode).WithMessage(message))
+			return
+		}
+
 		authPrefix := "Bearer " // with a trailing space
 		if !strings.Contains(header, authPrefix) {
 			message := "invalid auth header"
@@ -897,6 +3212,59 @@ func (h *handler) authorize(actionName string, next pkg.Handler) pkg.Handler {
 	})
 }
 
+// allowRoles lets through only the users authorized with one of the roles.
+// It must run after authorize.
+func (h *handler) allowRoles(roles ...string) pkg.Middleware {
+	return func(actionName string, next pkg.Handler) pkg.Handler {
+		return pkg.Handler(func(c pkg.Context) {
+			user, found := c.GetValue(_USER_ID_KEY).(*user)
+			if !found || user == nil {
+				c.Respond(response.Make(response.UnauthorizedCode))
+				return
+			}
+			for _, role := range user.Roles {
+				if slices.Contains(roles, role) {
+					next(c)
+					return
+				}
+			}
+			c.Respond(response.Make(response.UnauthorizedCode).WithMessage("access denied for " + actionName))
+		})
+	}
+}
+
+func (h *handler) verifyPaymentCallback(actionName string, next pkg.Handler) pkg.Handler {
+	return pkg.Handler(func(c pkg.Context) {
+		ctx, span := c.StartSpan()
+		defer span.End()
+
+		body, err := c.ReadBody()
+		if err != nil {
+			c.Respond(response.Make(response.BadRequestCode).WithMessage("ReadBody: " + err.Error()))
+			return
+		}
+
+		callback := &paymentCallback{
+			BankID:    c.GetHeader("X-Bank-ID"),
+			Timestamp: c.GetHeader("X-Timestamp"),
+			Nonce:     c.GetHeader("X-Nonce"),
+			Signature: c.GetHeader("X-Signature"),
+			ClientIP:  c.GetClientIP(),
+			Body:      body,
+		}
+		err = h.service.verifyPaymentCallback(ctx, callback)
+		if err != nil {
+			span.RecordError(errors.Wrap(err, "h.service.verifyPaymentCallback"))
+			c.Respond(response.Make(response.UnauthorizedCode).WithMessage("wrong payment callback"))
+			return
+		}
+
+		c.SaveValue(_BANK_ID_KEY, callback.BankID)
+
+		next(c)
+	})
+}
+
 func generateHashByKey(key, value string) (string, error) {
 	var h = hmac.New(sha256.New, []byte(key))
 	_, err := h.Write([]byte(value))
@@ -3,11 +3,18 @@ package gateway
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"encoding/csv"
 	"encoding/json"
 	"image"
 	"image/jpeg"
 	"image/png"
 	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/disintegration/imaging"
@@ -34,12 +41,66 @@ type Service interface {
 	signOut(context.Context, string, *refreshToken) error
 	deleteUser(context.Context, string) error
 
-	getPartnerProducts(context.Context) (*products.GetAllResponse, error)
-	checkOrder(context.Context, *user, *checkRequest) error
+	getPartnerProducts(context.Context, *productsRequest) (*products.GetAllResponse, error)
+	checkOrder(context.Context, *user, *checkRequest) (*pricing, error)
 	confirmOrder(context.Context, *user, *confirmRequest) (*confirmResponse, error)
-	payOrder(context.Context, *payRequest) (*payResponse, error)
+	reorder(context.Context, *user, *reorderRequest) (*reorderResponse, error)
+	checkCart(context.Context, *user, *cartRequest) (*cartPricing, error)
+	confirmCart(context.Context, *user, *confirmRequest) (*cartResponse, error)
+	payCart(context.Context, string, *payRequest) (*payResponse, error)
+	verifyPaymentCallback(context.Context, *paymentCallback) error
+	payOrder(context.Context, string, *payRequest) (*payResponse, error)
 	pickUpOrder(context.Context, *pickupRequest) error
-	assignOrder(context.Context, *user, *assignRequest) error
+	assignOrder(context.Context, *user, *assignRequest) (*assignResponse, error)
+	updateOrderAddress(context.Context, *user, *updateAddressRequest) error
+	cancelOrder(context.Context, *user, *cancelRequest) error
+	watchOrder(ctx context.Context, user *user, orderID int64, send func(*orderEvent) error) error
+	trackLocation(context.Context, *user, *locationRequest) (*deliveryLocation, error)
+	getOrderLocation(ctx context.Context, user *user, orderID int64) (*deliveryLocation, error)
+	getReceiptURL(ctx context.Context, user *user, orderID int64) (string, error)
+	completeOrder(context.Context, *user, *completeRequest) (*completeResponse, error)
+	addTip(context.Context, *user, *tipRequest) (*tipResponse, error)
+	payTip(context.Context, string, *payRequest) (*payResponse, error)
+	getEarnings(ctx context.Context, user *user, date string) (*earningsResponse, error)
+	refundOrder(context.Context, *refundRequest) (*refundResponse, error)
+	createReview(context.Context, *user, *reviewRequest) (*review, error)
+	listReviews(context.Context, *listReviewsRequest) (*reviewsResponse, error)
+	setReviewHidden(ctx context.Context, orderID int64, hidden bool) (*review, error)
+	createPromoCode(context.Context, *promoCodeRequest) (*promoCodeResponse, error)
+	listBanks(context.Context) ([]*bankResponse, error)
+	createBank(context.Context, *bankRequest) (*bankResponse, error)
+	updateBank(context.Context, *bankRequest) (*bankResponse, error)
+	setBankActive(ctx context.Context, bankID string, active bool) (*bankResponse, error)
+	getPaymentMethods(context.Context) ([]*paymentMethod, error)
+	listPartners(context.Context) ([]*partnerResponse, error)
+	getPartner(ctx context.Context, partnerID int32) (*partnerResponse, error)
+	createPartner(context.Context, *partnerRequest) (*partnerResponse, error)
+	updatePartner(context.Context, *partnerRequest) (*partnerResponse, error)
+	setPartnerEnabled(ctx context.Context, partnerID int32, enabled bool) (*partnerResponse, error)
+	setPartnerPaused(ctx context.Context, user *user, partnerID int32, paused bool) (*partnerResponse, error)
+	getOpeningHours(ctx context.Context, user *user, partnerID int32) (*openingHoursResponse, error)
+	setOpeningHours(context.Context, *user, *openingHoursRequest) (*openingHoursResponse, error)
+	getProductOptions(ctx context.Context, productID int32, partnerID int32) (*productOptions, error)
+	setProductOptions(context.Context, *user, *productOptions) (*productOptions, error)
+	rotateWebhookSecret(ctx context.Context, user *user, partnerID int32) (*webhookSecretResponse, error)
+	listWebhookDeliveries(context.Context, *listDeliveriesRequest) ([]*webhookDelivery, error)
+	getWebhookDelivery(ctx context.Context, deliveryID int64) (*webhookDelivery, error)
+	replayWebhookDelivery(ctx context.Context, deliveryID int64) (*webhookDelivery, error)
+	listProducts(context.Context, *user) ([]*productResponse, error)
+	createProduct(context.Context, *user, *productRequest) (*productResponse, error)
+	updateProduct(context.Context, *user, *productRequest) (*productResponse, error)
+	updateProductPicture(ctx context.Context, user *user, productID int32, file pkg.File, fileInfo pkg.FileInfo) (*productResponse, error)
+	deleteProduct(ctx context.Context, user *user, productID int32) error
+	listAvailable(ctx context.Context, user *user, partnerID int32) ([]*availableResponse, error)
+	setAvailable(context.Context, *user, *availableRequest) (*availableResponse, error)
+	deleteAvailable(ctx context.Context, user *user, productID int32, partnerID int32) (*availableResponse, error)
+	setProductLabels(context.Context, *user, *labelsRequest) (*labelsResponse, error)
+	getCategories(context.Context) ([]*categoryResponse, error)
+	createCategory(context.Context, *categoryRequest) (*categoryResponse, error)
+	updateCategory(context.Context, *categoryRequest) (*categoryResponse, error)
+	deleteCategory(ctx context.Context, categoryID int32) (*categoryResponse, error)
+	reconciliationReport(context.Context, *reportRequest) ([]*discrepancy, error)
+	reconciliationCSV(context.Context, *reportRequest) ([]byte, error)
 }
 
 type service struct {
@@ -177,16 +238,47 @@ func (s *service) confirmChangeEmail(ctx context.Context, verify *confirmChangeE
 func (s *service) updateAvatar(ctx context.Context, user *user, file pkg.File, fileInfo pkg.FileInfo) error {
 	oldPhotoURL := user.PhotoURL
 
-	img, format, err := image.Decode(io.LimitReader(file, _MAX_AVATAR_BYTES))
+	photoURL, err := s.uploadPicture(ctx, file, fileInfo, 400, 400)
 	if err != nil {
-		return errors.Wrap(err, "image.Decode")
+		return errors.Wrap(err, "s.uploadPicture")
 	}
 
-	img = imaging.Fill(img, 400, 400, imaging.Center, imaging.CatmullRom)
+	err = s.authManager.UpdateUser(ctx, user.AccessToken, &pkg.UpdateUser{
+		ID:       user.ID,
+		PhotoURL: &photoURL,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "s.authManager.UpdateUser")
+		err2 := s.storage.Delete(ctx, photoURL)
+		if err2 != nil {
+			err2 = errors.Wrap(err2, "s.storage.Delete")
+			err = errors.Wrap(err, err2.Error())
+		}
+		return err
+	}
+
+	if oldPhotoURL != "" {
+		err = s.storage.Delete(ctx, oldPhotoURL)
+		if err != nil {
+			return errors.Wrap(err, "s.storage.Delete")
+		}
+	}
+
+	return nil
+}
+
+// uploadPicture crops the image to the size and stores it under a random name, returning its url.
+func (s *service) uploadPicture(ctx context.Context, file pkg.File, fileInfo pkg.FileInfo, width int, height int) (string, error) {
+	img, format, err := image.Decode(io.LimitReader(file, _MAX_PICTURE_BYTES))
+	if err != nil {
+		return "", errors.Wrap(err, "image.Decode")
+	}
+
+	img = imaging.Fill(img, width, height, imaging.Center, imaging.CatmullRom)
 
 	fileName, err := gonanoid.New(20)
 	if err != nil {
-		return errors.Wrap(err, "gonanoid.New")
+		return "", errors.Wrap(err, "gonanoid.New")
 	}
 
 	buffer := new(bytes.Buffer)
@@ -198,47 +290,25 @@ func (s *service) updateAvatar(ctx context.Context, user *user, file pkg.File, f
 		fileName += ".jpg"
 		err = jpeg.Encode(buffer, img, nil)
 	default:
-		return errors.New("UnsupportedAvatarFormat: " + format)
+		return "", errors.New("UnsupportedPictureFormat: " + format)
 	}
 
 	if err != nil {
-		return errors.Wrap(err, "format.Encode")
+		return "", errors.Wrap(err, "format.Encode")
 	}
 
 	data := buffer.Bytes()
 
-	photoURL, err := s.storage.Upload(ctx, pkg.UploadInput{
+	url, err := s.storage.Upload(ctx, pkg.UploadInput{
 		File:        bytes.NewReader(data),
 		Name:        fileName,
 		Size:        int64(len(data)),
 		ContentType: fileInfo.ContentType(),
 	})
 	if err != nil {
-		return errors.Wrap(err, "s.storage.Upload")
-	}
-
-	err = s.authManager.UpdateUser(ctx, user.AccessToken, &pkg.UpdateUser{
-		ID:       user.ID,
-		PhotoURL: &photoURL,
-	})
-	if err != nil {
-		err = errors.Wrap(err, "s.authManager.UpdateUser")
-		err2 := s.storage.Delete(ctx, photoURL)
-		if err2 != nil {
-			err2 = errors.Wrap(err2, "s.storage.Delete")
-			err = errors.Wrap(err, err2.Error())
-		}
-		return err
-	}
-
-	if oldPhotoURL != "" {
-		err = s.storage.Delete(ctx, oldPhotoURL)
-		if err != nil {
-			return errors.Wrap(err, "s.storage.Delete")
-		}
+		return "", errors.Wrap(err, "s.storage.Upload")
 	}
-
-	return nil
+	return url, nil
 }
 
 func (s *service) forgotPassword(ctx context.Context, userID string) error {
@@ -291,61 +361,260 @@ func (s *service) deleteUser(ctx context.Context, userID string) error {
 	return nil
 }
 
-func (s *service) getPartnerProducts(ctx context.Context) (*products.GetAllResponse, error) {
-	products, err := s.partners.GetPartnerProducts(ctx, &products.GetAllRequest{})
+func (s *service) getPartnerProducts(ctx context.Context, req *productsRequest) (*products.GetAllResponse, error) {
+	// the values are validated to be numbers already
+	partnerID, _ := strconv.ParseInt(req.PartnerID, 10, 32)
+	minPrice, _ := strconv.ParseInt(req.MinPrice, 10, 32)
+	maxPrice, _ := strconv.ParseInt(req.MaxPrice, 10, 32)
+	categoryID, _ := strconv.ParseInt(req.CategoryID, 10, 32)
+	limit, _ := strconv.ParseInt(req.Limit, 10, 32)
+	products, err := s.partners.GetPartnerProducts(ctx, &products.GetAllRequest{
+		SortBy:     req.Sort,
+		Query:      req.Query,
+		PartnerID:  int32(partnerID),
+		MinPrice:   int32(minPrice),
+		MaxPrice:   int32(maxPrice),
+		CategoryID: int32(categoryID),
+		Tag:        req.Tag,
+		Limit:      int32(limit),
+		Cursor:     req.Cursor,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "s.partners.GetPartnerProducts")
 	}
 	return products, nil
 }
 
-func (s *service) checkOrder(ctx context.Context, user *user, req *checkRequest) error {
+func (s *service) checkOrder(ctx context.Context, user *user, req *checkRequest) (*pricing, error) {
 	// TODO: Ensure no duplicate OrderID exists in the Orders Service before processing the request.
 
 	cacheKey := "ORDER::" + user.ID + req.OrderID
 	var details = &orders.Order{}
 	err := s.cache.GetStruct(ctx, cacheKey, details)
 	if err == nil {
-		return nil
+		return fromOrderPricing(details.Pricing), nil
 	}
 
 	// check the partner and products for availability
 	checkReq := &partners.CheckRequest{
-		PartnerID:   int32(req.PartnerID),
-		TotalAmount: req.TotalAmount,
-		Products:    make([]*orders.Product, len(req.Products)),
+		PartnerID:       int32(req.PartnerID),
+		TotalAmount:     req.TotalAmount,
+		Products:        make([]*orders.Product, len(req.Products)),
+		DeliveryAddress: toOrderAddress(req.DeliveryAddress),
+		ScheduledAt:     req.ScheduledAt,
+		ReservationID:   cacheKey, // the reservation expires with the cached order
 	}
 	for idx, product := range req.Products {
 		checkReq.Products[idx] = &orders.Product{
-			ID:       int32(product.ID),
-			Quantity: int32(product.Quantity),
+			ID:          int32(product.ID),
+			Quantity:    int32(product.Quantity),
+			VariantID:   product.VariantID,
+			ModifierIDs: product.ModifierIDs,
+		}
+	}
+	checkResp, err := s.partners.CheckPartnerProducts(ctx, checkReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.CheckPartnerProducts")
+	}
+	if checkResp.Closed != "" {
+		return nil, &closedError{PartnerID: req.PartnerID, Reason: checkResp.Closed}
+	}
+	if len(checkResp.Shortages) > 0 {
+		return nil, newShortageError(req.PartnerID, checkResp.Shortages)
+	}
+
+	details, err = s.saveCheckedOrder(ctx, user, req, checkResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.saveCheckedOrder")
+	}
+	return fromOrderPricing(details.Pricing), nil
+}
+
+// shortageError fails the check of an order the partner doesn't have enough stock for,
+// the customer is shown what is short.
+type shortageError struct {
+	Shortages []*stockShortage
+}
+
+func newShortageError(partnerID int32, shortages []*partners.StockShortage) *shortageError {
+	var err = &shortageError{Shortages: make([]*stockShortage, len(shortages))}
+	for idx, shortage := range shortages {
+		err.Shortages[idx] = &stockShortage{
+			PartnerID: partnerID,
+			ProductID: shortage.ProductID,
+			Title:     shortage.Title,
+			Requested: shortage.Requested,
+			Available: shortage.Available,
+		}
+	}
+	return err
+}
+
+func (e *shortageError) Error() string {
+	return strconv.Itoa(len(e.Shortages)) + " products are short of stock"
+}
+
+// closedError fails the check of an order the partner takes no orders for at the time.
+type closedError struct {
+	PartnerID int32  `json:"partner_id"`
+	Reason    string `json:"reason"` // paused, holiday or closed (out of the opening hours)
+}
+
+func (e *closedError) Error() string {
+	return "partner " + strconv.Itoa(int(e.PartnerID)) + " is closed: " + e.Reason
+}
+
+// reorder checks the products of the customer's past order again at the current prices,
+// the checked order is confirmed as a new one with confirmOrder.
+func (s *service) reorder(ctx context.Context, user *user, req *reorderRequest) (*reorderResponse, error) {
+	past, err := s.orders.GetOrder(ctx, &orders.GetOrderRequest{
+		OrderID:    req.PastOrderID,
+		CustomerID: user.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.GetOrder")
+	}
+
+	check := &checkRequest{
+		OrderID:         req.OrderID,
+		PartnerID:       past.PartnerID,
+		CustomerPhone:   past.CustomerPhone,
+		DeliveryAddress: req.DeliveryAddress,
+		Paytype:         req.Paytype,
+		ScheduledAt:     req.ScheduledAt,
+	}
+	if check.DeliveryAddress == nil {
+		check.DeliveryAddress = fromOrderAddress(past.DeliveryAddress)
+	}
+	if check.Paytype == "" {
+		check.Paytype = past.Paytype
+	}
+
+	checkReq := &partners.CheckRequest{
+		PartnerID:       past.PartnerID,
+		Products:        make([]*orders.Product, len(past.Products)),
+		DeliveryAddress: toOrderAddress(check.DeliveryAddress),
+		ScheduledAt:     req.ScheduledAt,
+		Reorder:         true,
+		ReservationID:   "ORDER::" + user.ID + req.OrderID,
+	}
+	// a product might be ordered in several lines, each with its own options
+	var pastPrices = make(map[string]int32, len(past.Products))
+	for idx, product := range past.Products {
+		pastPrices[orders.ProductLine(product)] = product.Price
+		checkReq.Products[idx] = &orders.Product{
+			ID:          product.ID,
+			Quantity:    product.Quantity,
+			VariantID:   product.VariantID,
+			ModifierIDs: product.ModifierIDs,
 		}
 	}
 	checkResp, err := s.partners.CheckPartnerProducts(ctx, checkReq)
 	if err != nil {
-		return errors.Wrap(err, "s.partners.CheckPartnerProducts")
+		return nil, errors.Wrap(err, "s.partners.CheckPartnerProducts")
+	}
+	if checkResp.Closed != "" {
+		return nil, &closedError{PartnerID: past.PartnerID, Reason: checkResp.Closed}
+	}
+	if len(checkResp.Shortages) > 0 {
+		return nil, newShortageError(past.PartnerID, checkResp.Shortages)
+	}
+
+	details, err := s.saveCheckedOrder(ctx, user, check, checkResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.saveCheckedOrder")
+	}
+
+	var resp = &reorderResponse{
+		Pricing:  fromOrderPricing(details.Pricing),
+		Products: make([]*reorderProduct, 0, len(past.Products)),
+	}
+	for _, product := range checkResp.Products {
+		pastPrice := pastPrices[orders.ProductLine(product)]
+		resp.Products = append(resp.Products, &reorderProduct{
+			ID:           product.ID,
+			Title:        product.Title,
+			Quantity:     product.Quantity,
+			Price:        product.Price,
+			PastPrice:    pastPrice,
+			PriceChanged: product.Price != pastPrice,
+			Options:      toSelectedOptions(product.Options),
+		})
+	}
+	for _, product := range checkResp.Unavailable {
+		resp.Products = append(resp.Products, &reorderProduct{
+			ID:          product.ID,
+			Title:       product.Title,
+			Quantity:    product.Quantity,
+			PastPrice:   pastPrices[orders.ProductLine(product)],
+			Unavailable: true,
+		})
 	}
+	return resp, nil
+}
+
+func toSelectedOptions(options []*orders.SelectedOption) []*selectedOption {
+	var selected []*selectedOption
+	for _, option := range options {
+		selected = append(selected, &selectedOption{
+			Group: option.Group,
+			Title: option.Title,
+			Price: option.Price,
+		})
+	}
+	return selected
+}
 
-	// save order details in cache and publish in queue for orders service
+// saveCheckedOrder caches the order checked by the partner until it's confirmed.
+func (s *service) saveCheckedOrder(ctx context.Context, user *user, req *checkRequest, checkResp *partners.CheckResponse) (*orders.Order, error) {
+	cacheKey := "ORDER::" + user.ID + req.OrderID
+	var details = &orders.Order{}
 	details.OrderID = req.OrderID
 	details.CustomerID = user.ID
 	details.CustomerName = user.FirstName + " " + user.LastName
 	details.CustomerPhone = req.CustomerPhone
 	details.CustomerNotifToken = user.NotifToken
-	details.DeliveryAddress = req.DeliveryAddress
+	details.CustomerEmail = user.Email
+	details.DeliveryAddress = toOrderAddress(req.DeliveryAddress)
 	details.PartnerID = req.PartnerID
 	details.PartnerTitle = checkResp.PartnerTitle
 	details.PartnerBrand = checkResp.PartnerBrand
+	details.PartnerEmail = checkResp.PartnerEmail
 	details.Products = checkResp.Products
-	details.TotalAmount = req.TotalAmount
+	details.TotalAmount = checkResp.Pricing.GetTotal()
 	details.Paytype = req.Paytype
+	details.Pricing = checkResp.Pricing
+	details.ScheduledAt = req.ScheduledAt
+	details.ReservationID = cacheKey
+
+	if req.PromoCode != "" {
+		discounted, err := s.orders.CheckPromoCode(ctx, &orders.PromoRequest{
+			Code:       req.PromoCode,
+			CustomerID: user.ID,
+			PartnerID:  req.PartnerID,
+			Pricing:    checkResp.Pricing,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "s.orders.CheckPromoCode")
+		}
+		// the code is redeemed when the order is created
+		details.PromoCode = strings.ToUpper(req.PromoCode)
+		details.TotalAmount = discounted.GetTotal()
+		details.Pricing = discounted
+	}
+
+	if req.Tip > 0 {
+		details.Pricing.Tip = req.Tip
+		details.Pricing.Total += req.Tip
+		details.TotalAmount = details.Pricing.Total
+	}
 
-	err = s.cache.SaveStruct(ctx, cacheKey, details, time.Minute*10)
+	err := s.cache.SaveStruct(ctx, cacheKey, details, time.Minute*10)
 	if err != nil {
-		return errors.Wrap(err, "s.cache.SaveStruct")
+		return nil, errors.Wrap(err, "s.cache.SaveStruct")
 	}
 
-	return nil
+	return details, nil
 }
 
 func (s *service) confirmOrder(ctx context.Context, user *user, req *confirmRequest) (*confirmResponse, error) {
@@ -371,14 +640,211 @@ func (s *service) confirmOrder(ctx context.Context, user *user, req *confirmRequ
 		PartnerBrand:   order.PartnerBrand,
 		WebcheckoutURL: resp.WebcheckoutURL,
 		CallbackURL:    resp.CallbackURL,
+		Pricing:        fromOrderPricing(order.Pricing),
+	}, nil
+}
+
+// checkCart checks the orders of every partner of the cart concurrently,
+// they are confirmed and paid together as linked child orders.
+func (s *service) checkCart(ctx context.Context, user *user, req *cartRequest) (*cartPricing, error) {
+	cacheKey := "CART::" + user.ID + req.OrderID
+	var cart = &orders.Cart{}
+	err := s.cache.GetStruct(ctx, cacheKey, cart)
+	if err == nil {
+		return fromCart(cart), nil
+	}
+
+	var (
+		wg        sync.WaitGroup
+		responses = make([]*partners.CheckResponse, len(req.Partners))
+		errs      = make([]error, len(req.Partners))
+	)
+	for idx, partner := range req.Partners {
+		checkReq := &partners.CheckRequest{
+			PartnerID:       partner.PartnerID,
+			TotalAmount:     partner.TotalAmount,
+			Products:        make([]*orders.Product, len(partner.Products)),
+			DeliveryAddress: toOrderAddress(req.DeliveryAddress),
+			ScheduledAt:     req.ScheduledAt,
+			ReservationID:   cacheKey + "::" + strconv.Itoa(int(partner.PartnerID)),
+		}
+		for pidx, product := range partner.Products {
+			checkReq.Products[pidx] = &orders.Product{
+				ID:          int32(product.ID),
+				Quantity:    int32(product.Quantity),
+				VariantID:   product.VariantID,
+				ModifierIDs: product.ModifierIDs,
+			}
+		}
+		wg.Add(1)
+		go func(idx int, checkReq *partners.CheckRequest) {
+			defer wg.Done()
+			responses[idx], errs[idx] = s.partners.CheckPartnerProducts(ctx, checkReq)
+		}(idx, checkReq)
+	}
+	wg.Wait()
+
+	cart.CartID = req.OrderID
+	cart.CustomerID = user.ID
+	cart.Paytype = req.Paytype
+	cart.Orders = make([]*orders.Order, len(req.Partners))
+	var shortage = &shortageError{}
+	for idx, partner := range req.Partners {
+		if errs[idx] != nil {
+			return nil, errors.Wrapf(errs[idx], "s.partners.CheckPartnerProducts: partner %d", partner.PartnerID)
+		}
+		checkResp := responses[idx]
+		if checkResp.Closed != "" {
+			return nil, &closedError{PartnerID: partner.PartnerID, Reason: checkResp.Closed}
+		}
+		if len(checkResp.Shortages) > 0 {
+			shortage.Shortages = append(shortage.Shortages, newShortageError(partner.PartnerID, checkResp.Shortages).Shortages...)
+			continue
+		}
+		cart.Orders[idx] = &orders.Order{
+			OrderID:            req.OrderID + "-" + strconv.Itoa(int(partner.PartnerID)),
+			CustomerID:         user.ID,
+			CustomerName:       user.FirstName + " " + user.LastName,
+			CustomerPhone:      req.CustomerPhone,
+			CustomerNotifToken: user.NotifToken,
+			CustomerEmail:      user.Email,
+			DeliveryAddress:    toOrderAddress(req.DeliveryAddress),
+			PartnerID:          partner.PartnerID,
+			PartnerTitle:       checkResp.PartnerTitle,
+			PartnerBrand:       checkResp.PartnerBrand,
+			PartnerEmail:       checkResp.PartnerEmail,
+			Products:           checkResp.Products,
+			TotalAmount:        checkResp.Pricing.GetTotal(),
+			Paytype:            req.Paytype,
+			Pricing:            checkResp.Pricing,
+			ScheduledAt:        req.ScheduledAt,
+			ReservationID:      cacheKey + "::" + strconv.Itoa(int(partner.PartnerID)),
+		}
+	}
+	if len(shortage.Shortages) > 0 {
+		return nil, shortage
+	}
+
+	err = s.cache.SaveStruct(ctx, cacheKey, cart, time.Minute*10)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.cache.SaveStruct")
+	}
+
+	return fromCart(cart), nil
+}
+
+func fromCart(cart *orders.Cart) *cartPricing {
+	resp := &cartPricing{Orders: make([]*cartOrder, len(cart.Orders))}
+	for idx, order := range cart.Orders {
+		resp.Orders[idx] = &cartOrder{
+			PartnerID:    order.PartnerID,
+			PartnerTitle: order.PartnerTitle,
+			PartnerBrand: order.PartnerBrand,
+			Pricing:      fromOrderPricing(order.Pricing),
+		}
+		resp.Total += order.TotalAmount
+	}
+	return resp
+}
+
+func (s *service) confirmCart(ctx context.Context, user *user, req *confirmRequest) (*cartResponse, error) {
+	var key = "CART::" + user.ID + req.OrderID
+	var cart = &orders.Cart{}
+	err := s.cache.GetStruct(ctx, key, cart)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.cache.GetStruct")
+	}
+
+	resp, err := s.orders.CreateCart(ctx, cart)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.CreateCart")
+	}
+
+	// Don't worry if there is an error, bc cached data will be invalidated in 10 minutes:)
+	s.cache.Del(ctx, key)
+
+	return &cartResponse{
+		CheckoutID:     resp.CheckoutID,
+		OrderIDs:       resp.OrderIDs,
+		TotalAmount:    resp.TotalAmount,
+		WebcheckoutURL: resp.WebcheckoutURL,
+		CallbackURL:    resp.CallbackURL,
 	}, nil
 }
 
-func (s *service) payOrder(ctx context.Context, req *payRequest) (*payResponse, error) {
+func (s *service) verifyPaymentCallback(ctx context.Context, callback *paymentCallback) error {
+	if callback.BankID == "" || callback.Nonce == "" || callback.Signature == "" {
+		return errors.New("missing callback signature headers")
+	}
+
+	unix, err := strconv.ParseInt(callback.Timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "strconv.ParseInt")
+	}
+	age := time.Since(time.Unix(unix, 0))
+	if age > _PAYMENT_CALLBACK_WINDOW || age < -_PAYMENT_CALLBACK_WINDOW {
+		return errors.New("callback timestamp is out of the allowed window")
+	}
+
+	// the bank isn't cached, its callback secret is kept out of the shared cache
+	bank, err := s.orders.GetBank(ctx, &orders.GetBankRequest{ID: callback.BankID})
+	if err != nil {
+		return errors.Wrap(err, "s.orders.GetBank")
+	}
+	if bank.CallbackSecret == "" {
+		return errors.New("bank has no callback secret: " + bank.ID)
+	}
+	if len(bank.AllowedIPs) > 0 && !ipAllowed(callback.ClientIP, bank.AllowedIPs) {
+		return errors.New("callback from a not allowed IP: " + callback.ClientIP)
+	}
+
+	signature, err := generateHashByKey(bank.CallbackSecret,
+		callback.Timestamp+"."+callback.Nonce+"."+string(callback.Body))
+	if err != nil {
+		return errors.Wrap(err, "generateHashByKey")
+	}
+	if !hmac.Equal([]byte(signature), []byte(strings.ToLower(callback.Signature))) {
+		return errors.New("wrong callback signature")
+	}
+
+	// keep the nonce while its timestamp can still pass the window check,
+	// saved atomically so that of the same callback delivered concurrently only one passes
+	nonceKey := "PAYMENT_NONCE::" + callback.BankID + "::" + callback.Nonce
+	saved, err := s.cache.SaveStringIfAbsent(ctx, nonceKey, callback.Timestamp, _PAYMENT_CALLBACK_WINDOW*2)
+	if err != nil {
+		return errors.Wrap(err, "s.cache.SaveStringIfAbsent")
+	}
+	if !saved {
+		return errors.New("callback replay: " + callback.Nonce)
+	}
+
+	return nil
+}
+
+func ipAllowed(clientIP string, allowedIPs []string) bool {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, allowed := range allowedIPs {
+		if strings.Contains(allowed, "/") {
+			_, network, err := net.ParseCIDR(allowed)
+			if err == nil && network.Contains(ip) {
+				return true
+			}
+		} else if ip.Equal(net.ParseIP(allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) payOrder(ctx context.Context, bankID string, req *payRequest) (*payResponse, error) {
 	resp, err := s.orders.PayOrder(ctx, &orders.PayRequest{
 		OrderID:    req.OrderID,
 		PaymentID:  req.PaymentID,
 		PaidAmount: req.PaidAmount,
+		BankID:     bankID,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "s.orders.PayOrder")
@@ -390,7 +856,13 @@ func (s *service) payOrder(ctx context.Context, req *payRequest) (*payResponse,
 }
 
 func (s *service) pickUpOrder(ctx context.Context, req *pickupRequest) error {
-	msgBody, err := json.Marshal(req)
+	msgBody, err := json.Marshal(struct {
+		OrderID       int64           `json:"order_id"`
+		PickupAddress *orders.Address `json:"pickup_address"`
+	}{
+		OrderID:       req.OrderID,
+		PickupAddress: toOrderAddress(req.PickupAddress),
+	})
 	if err != nil {
 		return errors.Wrap(err, "json.Marshal")
 	}
@@ -401,13 +873,1165 @@ func (s *service) pickUpOrder(ctx context.Context, req *pickupRequest) error {
 	return nil
 }
 
-func (s *service) assignOrder(ctx context.Context, user *user, req *assignRequest) error {
-	_, err := s.orders.AssignOrder(ctx, &orders.AssignRequest{
+func (s *service) assignOrder(ctx context.Context, user *user, req *assignRequest) (*assignResponse, error) {
+	resp, err := s.orders.AssignOrder(ctx, &orders.AssignRequest{
+		OrderID:             req.OrderID,
+		DelivererID:         user.ID,
+		DelivererNotifToken: user.NotifToken,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.AssignOrder")
+	}
+	return &assignResponse{
+		PickupAddress:   fromOrderAddress(resp.PickupAddress),
+		DeliveryAddress: fromOrderAddress(resp.DeliveryAddress),
+	}, nil
+}
+
+func (s *service) updateOrderAddress(ctx context.Context, user *user, req *updateAddressRequest) error {
+	_, err := s.orders.UpdateOrderAddress(ctx, &orders.UpdateAddressRequest{
+		OrderID:         req.OrderID,
+		CustomerID:      user.ID,
+		DeliveryAddress: toOrderAddress(req.DeliveryAddress),
+	})
+	if err != nil {
+		return errors.Wrap(err, "s.orders.UpdateOrderAddress")
+	}
+	return nil
+}
+
+func (s *service) cancelOrder(ctx context.Context, user *user, req *cancelRequest) error {
+	_, err := s.orders.CancelOrder(ctx, &orders.CancelRequest{
+		OrderID:    req.OrderID,
+		CustomerID: user.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "s.orders.CancelOrder")
+	}
+	return nil
+}
+
+func (s *service) getReceiptURL(ctx context.Context, user *user, orderID int64) (string, error) {
+	resp, err := s.orders.GetReceipt(ctx, &orders.ReceiptRequest{
+		OrderID:    orderID,
+		CustomerID: user.ID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "s.orders.GetReceipt")
+	}
+	return resp.ReceiptURL, nil
+}
+
+// trackLocation passes the deliverer's GPS ping to the orders service, which rejects pings
+// for orders not on the way with the deliverer, and keeps the latest position in the cache.
+func (s *service) trackLocation(ctx context.Context, user *user, req *locationRequest) (*deliveryLocation, error) {
+	resp, err := s.orders.TrackLocation(ctx, &orders.LocationPing{
 		OrderID:     req.OrderID,
 		DelivererID: user.ID,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
 	})
 	if err != nil {
-		return errors.Wrap(err, "s.orders.AssignOrder")
+		return nil, errors.Wrap(err, "s.orders.TrackLocation")
+	}
+
+	location := &deliveryLocation{
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		DistanceKm: resp.DistanceKm,
+		ETAMinutes: resp.EtaMinutes,
+		UpdatedAt:  time.Now().Format(time.RFC3339),
+	}
+	err = s.cache.SaveStruct(ctx, "LOCATION::"+strconv.FormatInt(req.OrderID, 10), &cachedLocation{
+		CustomerID: resp.CustomerID,
+		Location:   location,
+	}, time.Minute*10)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.cache.SaveStruct")
+	}
+	return location, nil
+}
+
+func (s *service) getOrderLocation(ctx context.Context, user *user, orderID int64) (*deliveryLocation, error) {
+	var cached = &cachedLocation{}
+	err := s.cache.GetStruct(ctx, "LOCATION::"+strconv.FormatInt(orderID, 10), cached)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.cache.GetStruct")
+	}
+	if cached.CustomerID != user.ID || cached.Location == nil {
+		return nil, errors.New("the order doesn't belong to the customer")
+	}
+	return cached.Location, nil
+}
+
+func (s *service) completeOrder(ctx context.Context, user *user, req *completeRequest) (*completeResponse, error) {
+	resp, err := s.orders.CompleteOrder(ctx, &orders.CompleteRequest{
+		OrderID:     req.OrderID,
+		DelivererID: user.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.CompleteOrder")
+	}
+	return &completeResponse{Earned: resp.Earned}, nil
+}
+
+func (s *service) addTip(ctx context.Context, user *user, req *tipRequest) (*tipResponse, error) {
+	resp, err := s.orders.AddTip(ctx, &orders.TipRequest{
+		OrderID:    req.OrderID,
+		CustomerID: user.ID,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.AddTip")
+	}
+	return &tipResponse{
+		OrderID:        resp.OrderID,
+		WebcheckoutURL: resp.WebcheckoutURL,
+		CallbackURL:    resp.CallbackURL,
+	}, nil
+}
+
+// watchOrder relays the events of the customer's order from the orders service until the order is finished.
+func (s *service) watchOrder(ctx context.Context, user *user, orderID int64, send func(*orderEvent) error) error {
+	stream, err := s.orders.WatchOrder(ctx, &orders.WatchRequest{
+		OrderID:    orderID,
+		CustomerID: user.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "s.orders.WatchOrder")
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "stream.Recv")
+		}
+		err = send(&orderEvent{
+			OrderID:     event.OrderID,
+			Event:       event.Event,
+			Status:      event.Status,
+			DelivererID: event.DelivererID,
+			ETAMinutes:  event.EtaMinutes,
+			Latitude:    event.Latitude,
+			Longitude:   event.Longitude,
+			CreatedAt:   event.CreatedAt,
+		})
+		if err != nil {
+			return errors.Wrap(err, "send")
+		}
+	}
+}
+
+func (s *service) payCart(ctx context.Context, bankID string, req *payRequest) (*payResponse, error) {
+	resp, err := s.orders.PayCart(ctx, &orders.PayRequest{
+		OrderID:    req.OrderID,
+		PaymentID:  req.PaymentID,
+		PaidAmount: req.PaidAmount,
+		BankID:     bankID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.PayCart")
+	}
+	return &payResponse{PaymentID: resp.PaymentID}, nil
+}
+
+func (s *service) payTip(ctx context.Context, bankID string, req *payRequest) (*payResponse, error) {
+	resp, err := s.orders.PayTip(ctx, &orders.PayRequest{
+		OrderID:    req.OrderID,
+		PaymentID:  req.PaymentID,
+		PaidAmount: req.PaidAmount,
+		BankID:     bankID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.PayTip")
+	}
+	return &payResponse{PaymentID: resp.PaymentID}, nil
+}
+
+func (s *service) getEarnings(ctx context.Context, user *user, date string) (*earningsResponse, error) {
+	resp, err := s.orders.GetEarnings(ctx, &orders.EarningsRequest{
+		DelivererID: user.ID,
+		Date:        date,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.GetEarnings")
+	}
+	var earned = &earningsResponse{
+		Days:          make([]*earnings, 0, len(resp.Days)),
+		Week:          toEarnings(resp.Week),
+		PendingPayout: resp.PendingPayout,
+	}
+	for _, day := range resp.Days {
+		earned.Days = append(earned.Days, toEarnings(day))
+	}
+	return earned, nil
+}
+
+func toEarnings(e *orders.Earnings) *earnings {
+	if e == nil {
+		return &earnings{}
+	}
+	return &earnings{
+		Date:         e.Date,
+		Deliveries:   e.Deliveries,
+		DeliveryFees: e.DeliveryFees,
+		Tips:         e.Tips,
+		Total:        e.Total,
+	}
+}
+
+func (s *service) createReview(ctx context.Context, user *user, req *reviewRequest) (*review, error) {
+	resp, err := s.orders.CreateReview(ctx, &orders.Review{
+		OrderID:         req.OrderID,
+		CustomerID:      user.ID,
+		PartnerRating:   req.PartnerRating,
+		DelivererRating: req.DelivererRating,
+		Comment:         req.Comment,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.CreateReview")
+	}
+	return toReview(resp), nil
+}
+
+func (s *service) listReviews(ctx context.Context, req *listReviewsRequest) (*reviewsResponse, error) {
+	// the values are validated to be numbers and booleans already
+	partnerID, _ := strconv.ParseInt(req.PartnerID, 10, 32)
+	includeHidden, _ := strconv.ParseBool(req.IncludeHidden)
+	limit, _ := strconv.ParseInt(req.Limit, 10, 32)
+	offset, _ := strconv.ParseInt(req.Offset, 10, 32)
+	resp, err := s.orders.ListReviews(ctx, &orders.ListReviewsRequest{
+		PartnerID:     int32(partnerID),
+		DelivererID:   req.DelivererID,
+		IncludeHidden: includeHidden,
+		Limit:         int32(limit),
+		Offset:        int32(offset),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.ListReviews")
+	}
+	var reviews = &reviewsResponse{
+		Reviews:     make([]*review, 0, len(resp.Reviews)),
+		Rating:      resp.Rating,
+		RatingCount: resp.RatingCount,
+	}
+	for _, r := range resp.Reviews {
+		reviews.Reviews = append(reviews.Reviews, toReview(r))
+	}
+	return reviews, nil
+}
+
+func (s *service) setReviewHidden(ctx context.Context, orderID int64, hidden bool) (*review, error) {
+	resp, err := s.orders.SetReviewHidden(ctx, &orders.SetReviewHiddenRequest{
+		OrderID: orderID,
+		Hidden:  hidden,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.SetReviewHidden")
+	}
+	return toReview(resp), nil
+}
+
+func toReview(r *orders.Review) *review {
+	return &review{
+		OrderID:         r.OrderID,
+		PartnerID:       r.PartnerID,
+		DelivererID:     r.DelivererID,
+		PartnerRating:   r.PartnerRating,
+		DelivererRating: r.DelivererRating,
+		Comment:         r.Comment,
+		Hidden:          r.Hidden,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+func (s *service) refundOrder(ctx context.Context, req *refundRequest) (*refundResponse, error) {
+	refundReq := &orders.RefundRequest{
+		OrderID:  req.OrderID,
+		Products: make([]*orders.Product, len(req.Products)),
+		Reason:   req.Reason,
+	}
+	for idx, product := range req.Products {
+		refundReq.Products[idx] = &orders.Product{
+			ID:          int32(product.ID),
+			Quantity:    int32(product.Quantity),
+			VariantID:   product.VariantID,
+			ModifierIDs: product.ModifierIDs,
+		}
+	}
+	resp, err := s.orders.RefundOrder(ctx, refundReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.RefundOrder")
+	}
+	return &refundResponse{
+		RefundID: resp.RefundID,
+		Amount:   resp.Amount,
+		Status:   resp.Status,
+	}, nil
+}
+
+func (s *service) createPromoCode(ctx context.Context, req *promoCodeRequest) (*promoCodeResponse, error) {
+	promo, err := s.orders.CreatePromoCode(ctx, &orders.PromoCode{
+		Code:           req.Code,
+		Kind:           req.Kind,
+		Value:          req.Value,
+		MaxDiscount:    req.MaxDiscount,
+		MinOrderAmount: req.MinOrderAmount,
+		PartnerIDs:     req.PartnerIDs,
+		ValidFrom:      req.ValidFrom,
+		ValidTo:        req.ValidTo,
+		UsageLimit:     req.UsageLimit,
+		PerUserLimit:   req.PerUserLimit,
+		Active:         req.Active,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.CreatePromoCode")
+	}
+	return &promoCodeResponse{
+		Code:           promo.Code,
+		Kind:           promo.Kind,
+		Value:          promo.Value,
+		MaxDiscount:    promo.MaxDiscount,
+		MinOrderAmount: promo.MinOrderAmount,
+		PartnerIDs:     promo.PartnerIDs,
+		ValidFrom:      promo.ValidFrom,
+		ValidTo:        promo.ValidTo,
+		UsageLimit:     promo.UsageLimit,
+		PerUserLimit:   promo.PerUserLimit,
+		Active:         promo.Active,
+	}, nil
+}
+
+func (s *service) listBanks(ctx context.Context) ([]*bankResponse, error) {
+	resp, err := s.orders.ListBanks(ctx, &orders.ListBanksRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.ListBanks")
+	}
+	var banks = make([]*bankResponse, 0, len(resp.Banks))
+	for _, bank := range resp.Banks {
+		banks = append(banks, toBankResponse(bank))
+	}
+	return banks, nil
+}
+
+func (s *service) createBank(ctx context.Context, req *bankRequest) (*bankResponse, error) {
+	bank, err := s.orders.CreateBank(ctx, &orders.Bank{
+		ID:             req.ID,
+		Title:          req.Title,
+		Driver:         req.Driver,
+		WebcheckoutURL: req.WebcheckoutURL,
+		CallbackSecret: req.CallbackSecret,
+		AllowedIPs:     req.AllowedIPs,
+		Currencies:     req.Currencies,
+		Active:         req.Active,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.CreateBank")
+	}
+	resp := toBankResponse(bank)
+	if req.CallbackSecret == "" {
+		// the admin can't learn the generated secret otherwise
+		resp.CallbackSecret = bank.CallbackSecret
+	}
+	return resp, nil
+}
+
+func (s *service) updateBank(ctx context.Context, req *bankRequest) (*bankResponse, error) {
+	bank, err := s.orders.UpdateBank(ctx, &orders.Bank{
+		ID:             req.ID,
+		Title:          req.Title,
+		Driver:         req.Driver,
+		WebcheckoutURL: req.WebcheckoutURL,
+		CallbackSecret: req.CallbackSecret,
+		AllowedIPs:     req.AllowedIPs,
+		Currencies:     req.Currencies,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.UpdateBank")
+	}
+	return toBankResponse(bank), nil
+}
+
+func (s *service) setBankActive(ctx context.Context, bankID string, active bool) (*bankResponse, error) {
+	bank, err := s.orders.SetBankActive(ctx, &orders.SetBankActiveRequest{
+		ID:     bankID,
+		Active: active,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.SetBankActive")
+	}
+	return toBankResponse(bank), nil
+}
+
+func (s *service) getPaymentMethods(ctx context.Context) ([]*paymentMethod, error) {
+	resp, err := s.orders.ListBanks(ctx, &orders.ListBanksRequest{ActiveOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.ListBanks")
+	}
+	var methods = make([]*paymentMethod, 0, len(resp.Banks))
+	for _, bank := range resp.Banks {
+		methods = append(methods, &paymentMethod{
+			ID:         bank.ID,
+			Title:      bank.Title,
+			Currencies: bank.Currencies,
+		})
+	}
+	return methods, nil
+}
+
+// toBankResponse leaves the callback secret out, it's write-only.
+func toBankResponse(bank *orders.Bank) *bankResponse {
+	return &bankResponse{
+		ID:                bank.ID,
+		Title:             bank.Title,
+		Driver:            bank.Driver,
+		WebcheckoutURL:    bank.WebcheckoutURL,
+		HasCallbackSecret: bank.CallbackSecret != "",
+		AllowedIPs:        bank.AllowedIPs,
+		Currencies:        bank.Currencies,
+		Active:            bank.Active,
+	}
+}
+
+func (s *service) listPartners(ctx context.Context) ([]*partnerResponse, error) {
+	resp, err := s.partners.ListPartners(ctx, &partners.ListPartnersRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.ListPartners")
+	}
+	var list = make([]*partnerResponse, 0, len(resp.Partners))
+	for _, partner := range resp.Partners {
+		list = append(list, toPartnerResponse(partner))
+	}
+	return list, nil
+}
+
+func (s *service) getPartner(ctx context.Context, partnerID int32) (*partnerResponse, error) {
+	partner, err := s.partners.GetPartner(ctx, &partners.GetPartnerRequest{ID: partnerID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetPartner")
+	}
+	return toPartnerResponse(partner), nil
+}
+
+func (s *service) createPartner(ctx context.Context, req *partnerRequest) (*partnerResponse, error) {
+	partner, err := s.partners.CreatePartner(ctx, fromPartnerRequest(req))
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.CreatePartner")
+	}
+	return toPartnerResponse(partner), nil
+}
+
+func (s *service) updatePartner(ctx context.Context, req *partnerRequest) (*partnerResponse, error) {
+	if req.ID == 0 {
+		return nil, errors.New("partner id is required")
+	}
+	partner, err := s.partners.UpdatePartner(ctx, fromPartnerRequest(req))
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.UpdatePartner")
+	}
+	return toPartnerResponse(partner), nil
+}
+
+func (s *service) setPartnerEnabled(ctx context.Context, partnerID int32, enabled bool) (*partnerResponse, error) {
+	partner, err := s.partners.SetPartnerEnabled(ctx, &partners.SetPartnerEnabledRequest{
+		ID:      partnerID,
+		Enabled: enabled,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetPartnerEnabled")
+	}
+	return toPartnerResponse(partner), nil
+}
+
+func fromPartnerRequest(req *partnerRequest) *partners.PartnerInfo {
+	return &partners.PartnerInfo{
+		ID:        req.ID,
+		Title:     req.Title,
+		Brand:     req.Brand,
+		Phone:     req.Phone,
+		Email:     req.Email,
+		Address:   req.Address,
+		ApiURL:    req.APIURL,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Timezone:  req.Timezone,
+		Verified:  req.Verified,
+		Enabled:   req.Enabled,
+		UserID:    req.UserID,
+	}
+}
+
+func toPartnerResponse(partner *partners.PartnerInfo) *partnerResponse {
+	return &partnerResponse{
+		ID:        partner.ID,
+		Title:     partner.Title,
+		Brand:     partner.Brand,
+		Phone:     partner.Phone,
+		Email:     partner.Email,
+		Address:   partner.Address,
+		APIURL:    partner.ApiURL,
+		Latitude:  partner.Latitude,
+		Longitude: partner.Longitude,
+		Timezone:  partner.Timezone,
+		Verified:  partner.Verified,
+		Enabled:   partner.Enabled,
+		Rating:    partner.Rating,
+		UserID:    partner.UserID,
+		Paused:    partner.Paused,
+	}
+}
+
+// partnerScope is the partner whose listings the user manages, 0 - any partner's, for an admin.
+func (s *service) partnerScope(ctx context.Context, user *user) (int32, error) {
+	if slices.Contains(user.Roles, "admin") {
+		return 0, nil
+	}
+	partner, err := s.partners.GetPartner(ctx, &partners.GetPartnerRequest{UserID: user.ID})
+	if err != nil {
+		return 0, errors.Wrap(err, "s.partners.GetPartner")
+	}
+	return partner.ID, nil
+}
+
+func (s *service) listProducts(ctx context.Context, user *user) ([]*productResponse, error) {
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partnerScope")
+	}
+	resp, err := s.partners.ListProducts(ctx, &products.ListProductsRequest{PartnerID: partnerID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.ListProducts")
+	}
+	var list = make([]*productResponse, 0, len(resp.Products))
+	for _, product := range resp.Products {
+		list = append(list, toProductResponse(product))
+	}
+	return list, nil
+}
+
+func (s *service) createProduct(ctx context.Context, user *user, req *productRequest) (*productResponse, error) {
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partnerScope")
+	}
+	if partnerID == 0 {
+		partnerID = req.PartnerID
+	}
+	product, err := s.partners.CreateProduct(ctx, &products.ProductInfo{
+		Title:       req.Title,
+		Description: req.Description,
+		PartnerID:   partnerID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.CreateProduct")
+	}
+	return toProductResponse(product), nil
+}
+
+func (s *service) updateProduct(ctx context.Context, user *user, req *productRequest) (*productResponse, error) {
+	if req.ID == 0 {
+		return nil, errors.New("product id is required")
+	}
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partnerScope")
+	}
+	product, err := s.partners.UpdateProduct(ctx, &products.ProductInfo{
+		ID:          req.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		PartnerID:   partnerID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.UpdateProduct")
+	}
+	return toProductResponse(product), nil
+}
+
+func (s *service) updateProductPicture(ctx context.Context, user *user, productID int32, file pkg.File, fileInfo pkg.FileInfo) (*productResponse, error) {
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partnerScope")
+	}
+	product, err := s.partners.GetProduct(ctx, &products.GetProductRequest{ID: productID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetProduct")
+	}
+	if partnerID != 0 && product.PartnerID != partnerID {
+		return nil, errors.New("the partner has no such product")
+	}
+	oldPictureURL := product.PictureURL
+
+	pictureURL, err := s.uploadPicture(ctx, file, fileInfo, 800, 800)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.uploadPicture")
+	}
+
+	product, err = s.partners.SetProductPicture(ctx, &products.ProductPictureRequest{
+		ID:         productID,
+		PartnerID:  partnerID,
+		PictureURL: pictureURL,
+	})
+	if err != nil {
+		err = errors.Wrap(err, "s.partners.SetProductPicture")
+		err2 := s.storage.Delete(ctx, pictureURL)
+		if err2 != nil {
+			err2 = errors.Wrap(err2, "s.storage.Delete")
+			err = errors.Wrap(err, err2.Error())
+		}
+		return nil, err
+	}
+
+	if oldPictureURL != "" {
+		err = s.storage.Delete(ctx, oldPictureURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.storage.Delete")
+		}
+	}
+
+	return toProductResponse(product), nil
+}
+
+func (s *service) deleteProduct(ctx context.Context, user *user, productID int32) error {
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return errors.Wrap(err, "s.partnerScope")
+	}
+	product, err := s.partners.DeleteProduct(ctx, &products.DeleteProductRequest{
+		ID:        productID,
+		PartnerID: partnerID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "s.partners.DeleteProduct")
+	}
+	if product.PictureURL != "" {
+		err = s.storage.Delete(ctx, product.PictureURL)
+		if err != nil {
+			return errors.Wrap(err, "s.storage.Delete")
+		}
+	}
+	return nil
+}
+
+func toProductResponse(product *products.ProductInfo) *productResponse {
+	return &productResponse{
+		ID:          product.ID,
+		Title:       product.Title,
+		Description: product.Description,
+		PictureURL:  product.PictureURL,
+		PartnerID:   product.PartnerID,
+		CategoryIDs: product.CategoryIDs,
+		Tags:        product.Tags,
+	}
+}
+
+// availablePartner is the partner whose listings are managed: a partner manages its own,
+// an admin has to choose one.
+func (s *service) availablePartner(ctx context.Context, user *user, partnerID int32) (int32, error) {
+	scope, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return 0, errors.Wrap(err, "s.partnerScope")
+	}
+	if scope != 0 {
+		return scope, nil
+	}
+	if partnerID <= 0 {
+		return 0, errors.New("partner id is required")
+	}
+	return partnerID, nil
+}
+
+// setPartnerPaused pauses or resumes ordering from the partner at once.
+func (s *service) setPartnerPaused(ctx context.Context, user *user, partnerID int32, paused bool) (*partnerResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	partner, err := s.partners.SetPartnerPaused(ctx, &partners.SetPartnerPausedRequest{
+		ID:     partnerID,
+		Paused: paused,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetPartnerPaused")
+	}
+	return toPartnerResponse(partner), nil
+}
+
+func (s *service) getOpeningHours(ctx context.Context, user *user, partnerID int32) (*openingHoursResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	hours, err := s.partners.GetOpeningHours(ctx, &partners.GetPartnerRequest{ID: partnerID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetOpeningHours")
+	}
+	return toOpeningHoursResponse(hours), nil
+}
+
+func (s *service) setOpeningHours(ctx context.Context, user *user, req *openingHoursRequest) (*openingHoursResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	var hours = &partners.OpeningHours{
+		PartnerID: partnerID,
+		Weekly:    make([]*partners.OpeningPeriod, len(req.Weekly)),
+		Closures:  make([]*partners.Closure, len(req.Closures)),
+	}
+	for idx, period := range req.Weekly {
+		hours.Weekly[idx] = &partners.OpeningPeriod{
+			Weekday:  period.Weekday,
+			OpensAt:  period.OpensAt,
+			ClosesAt: period.ClosesAt,
+		}
+	}
+	for idx, day := range req.Closures {
+		hours.Closures[idx] = &partners.Closure{
+			StartsOn: day.StartsOn,
+			EndsOn:   day.EndsOn,
+			Reason:   day.Reason,
+		}
+	}
+	hours, err = s.partners.SetOpeningHours(ctx, hours)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetOpeningHours")
+	}
+	return toOpeningHoursResponse(hours), nil
+}
+
+func toOpeningHoursResponse(hours *partners.OpeningHours) *openingHoursResponse {
+	var resp = &openingHoursResponse{
+		PartnerID: hours.PartnerID,
+		Weekly:    make([]*openingPeriod, len(hours.Weekly)),
+		Closures:  make([]*closure, len(hours.Closures)),
+		Timezone:  hours.Timezone,
+	}
+	for idx, period := range hours.Weekly {
+		resp.Weekly[idx] = &openingPeriod{
+			Weekday:  period.Weekday,
+			OpensAt:  period.OpensAt,
+			ClosesAt: period.ClosesAt,
+		}
+	}
+	for idx, day := range hours.Closures {
+		resp.Closures[idx] = &closure{
+			StartsOn: day.StartsOn,
+			EndsOn:   day.EndsOn,
+			Reason:   day.Reason,
+		}
+	}
+	return resp
+}
+
+// getProductOptions gets what the partner's product is ordered with, for the customers to choose from.
+func (s *service) getProductOptions(ctx context.Context, productID int32, partnerID int32) (*productOptions, error) {
+	options, err := s.partners.GetProductOptions(ctx, &partners.ProductOptionsRequest{
+		ProductID: productID,
+		PartnerID: partnerID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetProductOptions")
+	}
+	return toProductOptions(options), nil
+}
+
+func (s *service) setProductOptions(ctx context.Context, user *user, req *productOptions) (*productOptions, error) {
+	partnerID, err := s.availablePartner(ctx, user, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	var options = &partners.ProductOptions{
+		ProductID:      req.ProductID,
+		PartnerID:      partnerID,
+		Variants:       make([]*partners.Variant, len(req.Variants)),
+		ModifierGroups: make([]*partners.ModifierGroup, len(req.ModifierGroups)),
+	}
+	for idx, v := range req.Variants {
+		options.Variants[idx] = &partners.Variant{
+			Title: v.Title,
+			Price: v.Price,
+		}
+	}
+	for idx, group := range req.ModifierGroups {
+		options.ModifierGroups[idx] = &partners.ModifierGroup{
+			Title:         group.Title,
+			MinSelections: group.MinSelections,
+			MaxSelections: group.MaxSelections,
+			Modifiers:     make([]*partners.Modifier, len(group.Modifiers)),
+		}
+		for midx, m := range group.Modifiers {
+			options.ModifierGroups[idx].Modifiers[midx] = &partners.Modifier{
+				Title: m.Title,
+				Price: m.Price,
+			}
+		}
+	}
+	options, err = s.partners.SetProductOptions(ctx, options)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetProductOptions")
+	}
+	return toProductOptions(options), nil
+}
+
+func toProductOptions(options *partners.ProductOptions) *productOptions {
+	var resp = &productOptions{
+		ProductID:      options.ProductID,
+		PartnerID:      options.PartnerID,
+		Variants:       make([]*variant, len(options.Variants)),
+		ModifierGroups: make([]*modifierGroup, len(options.ModifierGroups)),
+	}
+	for idx, v := range options.Variants {
+		resp.Variants[idx] = &variant{
+			ID:    v.ID,
+			Title: v.Title,
+			Price: v.Price,
+		}
+	}
+	for idx, group := range options.ModifierGroups {
+		resp.ModifierGroups[idx] = &modifierGroup{
+			ID:            group.ID,
+			Title:         group.Title,
+			MinSelections: group.MinSelections,
+			MaxSelections: group.MaxSelections,
+			Modifiers:     make([]*modifier, len(group.Modifiers)),
+		}
+		for midx, m := range group.Modifiers {
+			resp.ModifierGroups[idx].Modifiers[midx] = &modifier{
+				ID:    m.ID,
+				Title: m.Title,
+				Price: m.Price,
+			}
+		}
+	}
+	return resp
+}
+
+// rotateWebhookSecret replaces the partner's webhook secret, the old one doesn't sign the webhooks anymore.
+func (s *service) rotateWebhookSecret(ctx context.Context, user *user, partnerID int32) (*webhookSecretResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	secret, err := s.partners.RotateWebhookSecret(ctx, &partners.GetPartnerRequest{ID: partnerID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.RotateWebhookSecret")
+	}
+	return &webhookSecretResponse{
+		PartnerID: secret.PartnerID,
+		Secret:    secret.Secret,
+	}, nil
+}
+
+func (s *service) listWebhookDeliveries(ctx context.Context, req *listDeliveriesRequest) ([]*webhookDelivery, error) {
+	// the values are validated to be numbers already
+	partnerID, _ := strconv.ParseInt(req.PartnerID, 10, 32)
+	orderID, _ := strconv.ParseInt(req.OrderID, 10, 64)
+	limit, _ := strconv.ParseInt(req.Limit, 10, 32)
+	offset, _ := strconv.ParseInt(req.Offset, 10, 32)
+	resp, err := s.partners.ListWebhookDeliveries(ctx, &partners.ListDeliveriesRequest{
+		PartnerID: int32(partnerID),
+		Status:    req.Status,
+		OrderID:   orderID,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.ListWebhookDeliveries")
+	}
+	var deliveries = make([]*webhookDelivery, 0, len(resp.Deliveries))
+	for _, delivery := range resp.Deliveries {
+		deliveries = append(deliveries, toWebhookDelivery(delivery))
+	}
+	return deliveries, nil
+}
+
+func (s *service) getWebhookDelivery(ctx context.Context, deliveryID int64) (*webhookDelivery, error) {
+	delivery, err := s.partners.GetWebhookDelivery(ctx, &partners.GetDeliveryRequest{ID: deliveryID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetWebhookDelivery")
+	}
+	return toWebhookDelivery(delivery), nil
+}
+
+// replayWebhookDelivery attempts the delivery again at once, a dead one gets all its attempts back.
+func (s *service) replayWebhookDelivery(ctx context.Context, deliveryID int64) (*webhookDelivery, error) {
+	delivery, err := s.partners.ReplayWebhookDelivery(ctx, &partners.GetDeliveryRequest{ID: deliveryID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.ReplayWebhookDelivery")
+	}
+	return toWebhookDelivery(delivery), nil
+}
+
+func toWebhookDelivery(delivery *partners.WebhookDelivery) *webhookDelivery {
+	var resp = &webhookDelivery{
+		ID:             delivery.ID,
+		PartnerID:      delivery.PartnerID,
+		Event:          delivery.Event,
+		OrderID:        delivery.OrderID,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	for _, attempt := range delivery.Log {
+		resp.Log = append(resp.Log, &webhookAttempt{
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.DurationMs,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+	return resp
+}
+
+func (s *service) listAvailable(ctx context.Context, user *user, partnerID int32) ([]*availableResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	resp, err := s.partners.ListAvailable(ctx, &products.ListAvailableRequest{PartnerID: partnerID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.ListAvailable")
+	}
+	var list = make([]*availableResponse, 0, len(resp.Available))
+	for _, available := range resp.Available {
+		list = append(list, toAvailableResponse(available))
+	}
+	return list, nil
+}
+
+func (s *service) setAvailable(ctx context.Context, user *user, req *availableRequest) (*availableResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	var stock int32 = -1
+	if req.Stock != nil {
+		stock = *req.Stock
+	}
+	available, err := s.partners.SetAvailable(ctx, &products.Availability{
+		ProductID: req.ProductID,
+		PartnerID: partnerID,
+		Price:     req.Price,
+		Active:    req.Active,
+		Stock:     stock,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetAvailable")
+	}
+	return toAvailableResponse(available), nil
+}
+
+func (s *service) deleteAvailable(ctx context.Context, user *user, productID int32, partnerID int32) (*availableResponse, error) {
+	partnerID, err := s.availablePartner(ctx, user, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.availablePartner")
+	}
+	available, err := s.partners.DeleteAvailable(ctx, &products.DeleteAvailableRequest{
+		ProductID: productID,
+		PartnerID: partnerID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.DeleteAvailable")
+	}
+	return toAvailableResponse(available), nil
+}
+
+func (s *service) setProductLabels(ctx context.Context, user *user, req *labelsRequest) (*labelsResponse, error) {
+	partnerID, err := s.partnerScope(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partnerScope")
+	}
+	labels, err := s.partners.SetProductLabels(ctx, &products.ProductLabels{
+		ProductID:   req.ProductID,
+		PartnerID:   partnerID,
+		CategoryIDs: req.CategoryIDs,
+		Tags:        req.Tags,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.SetProductLabels")
+	}
+	return &labelsResponse{
+		ProductID:   labels.ProductID,
+		CategoryIDs: labels.CategoryIDs,
+		Tags:        labels.Tags,
+	}, nil
+}
+
+func (s *service) getCategories(ctx context.Context) ([]*categoryResponse, error) {
+	resp, err := s.partners.GetCategories(ctx, &products.GetCategoriesRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.GetCategories")
+	}
+	var list = make([]*categoryResponse, 0, len(resp.Categories))
+	for _, category := range resp.Categories {
+		list = append(list, toCategoryResponse(category))
+	}
+	return list, nil
+}
+
+func (s *service) createCategory(ctx context.Context, req *categoryRequest) (*categoryResponse, error) {
+	category, err := s.partners.CreateCategory(ctx, &products.Category{
+		Title:    req.Title,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.CreateCategory")
+	}
+	return toCategoryResponse(category), nil
+}
+
+func (s *service) updateCategory(ctx context.Context, req *categoryRequest) (*categoryResponse, error) {
+	if req.ID == 0 {
+		return nil, errors.New("category id is required")
+	}
+	category, err := s.partners.UpdateCategory(ctx, &products.Category{
+		ID:       req.ID,
+		Title:    req.Title,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.UpdateCategory")
+	}
+	return toCategoryResponse(category), nil
+}
+
+func (s *service) deleteCategory(ctx context.Context, categoryID int32) (*categoryResponse, error) {
+	category, err := s.partners.DeleteCategory(ctx, &products.DeleteCategoryRequest{ID: categoryID})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.partners.DeleteCategory")
+	}
+	return toCategoryResponse(category), nil
+}
+
+func toCategoryResponse(category *products.Category) *categoryResponse {
+	var resp = &categoryResponse{
+		ID:           category.ID,
+		Title:        category.Title,
+		ParentID:     category.ParentID,
+		ProductCount: category.ProductCount,
+	}
+	for _, subcategory := range category.Subcategories {
+		resp.Subcategories = append(resp.Subcategories, toCategoryResponse(subcategory))
+	}
+	return resp
+}
+
+func toAvailableResponse(available *products.Availability) *availableResponse {
+	return &availableResponse{
+		ProductID: available.ProductID,
+		PartnerID: available.PartnerID,
+		Title:     available.Title,
+		Price:     available.Price,
+		Active:    available.Active,
+		Stock:     available.Stock,
+	}
+}
+
+func (s *service) reconciliationReport(ctx context.Context, req *reportRequest) ([]*discrepancy, error) {
+	resp, err := s.orders.ReconciliationReport(ctx, &orders.ReportRequest{
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.orders.ReconciliationReport")
+	}
+	var discrepancies = make([]*discrepancy, 0, len(resp.Discrepancies))
+	for _, d := range resp.Discrepancies {
+		discrepancies = append(discrepancies, &discrepancy{
+			OrderID:        d.OrderID,
+			PaymentID:      d.PaymentID,
+			BankID:         d.BankID,
+			Kind:           d.Kind,
+			ExpectedAmount: d.ExpectedAmount,
+			ActualAmount:   d.ActualAmount,
+			Details:        d.Details,
+			DetectedAt:     d.DetectedAt,
+		})
+	}
+	return discrepancies, nil
+}
+
+func (s *service) reconciliationCSV(ctx context.Context, req *reportRequest) ([]byte, error) {
+	discrepancies, err := s.reconciliationReport(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.reconciliationReport")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"detected_at", "kind", "order_id", "payment_id", "bank_id", "expected_amount", "actual_amount", "details"})
+	for _, d := range discrepancies {
+		writer.Write([]string{
+			d.DetectedAt,
+			d.Kind,
+			strconv.FormatInt(d.OrderID, 10),
+			d.PaymentID,
+			d.BankID,
+			strconv.FormatInt(d.ExpectedAmount, 10),
+			strconv.FormatInt(d.ActualAmount, 10),
+			d.Details,
+		})
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return nil, errors.Wrap(err, "writer.Flush")
+	}
+	return buf.Bytes(), nil
+}
+
+func toOrderAddress(addr *address) *orders.Address {
+	if addr == nil {
+		return nil
+	}
+	return &orders.Address{
+		Street:       addr.Street,
+		City:         addr.City,
+		PostalCode:   addr.PostalCode,
+		Apartment:    addr.Apartment,
+		Instructions: addr.Instructions,
+		Latitude:     addr.Latitude,
+		Longitude:    addr.Longitude,
+	}
+}
+
+func fromOrderAddress(addr *orders.Address) *address {
+	if addr == nil {
+		return nil
+	}
+	return &address{
+		Street:       addr.Street,
+		City:         addr.City,
+		PostalCode:   addr.PostalCode,
+		Apartment:    addr.Apartment,
+		Instructions: addr.Instructions,
+		Latitude:     addr.Latitude,
+		Longitude:    addr.Longitude,
+	}
+}
+
+func fromOrderPricing(p *orders.Pricing) *pricing {
+	if p == nil {
+		return nil
+	}
+	return &pricing{
+		Subtotal:      p.Subtotal,
+		DeliveryFee:   p.DeliveryFee,
+		SmallOrderFee: p.SmallOrderFee,
+		ServiceFee:    p.ServiceFee,
+		Discount:      p.Discount,
+		Tip:           p.Tip,
+		Tax:           p.Tax,
+		Total:         p.Total,
+		DistanceKm:    p.DistanceKm,
 	}
-	return nil
 }
@@ -3,7 +3,10 @@ package gateway
 import (
 	"context"
 	"errors"
+	"io"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/shahzodshafizod/gocloud/internal/orders"
 	ordersmocks "github.com/shahzodshafizod/gocloud/internal/orders/mocks"
@@ -14,6 +17,7 @@ import (
 	"github.com/shahzodshafizod/gocloud/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 )
 
 type testConfig struct {
@@ -43,6 +47,16 @@ func __SetupTestConfig(t *testing.T) *testConfig {
 	return cfg
 }
 
+// go test -count=1 -v ./internal/gateway/ -run ^TestRegisterRoutes$
+func TestRegisterRoutes(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	// the router panics on a path registered twice, e.g. the same path for GET and PUT
+	h := &handler{service: cfg.service}
+	assert.NotPanics(t, func() { h.registerRoutes(nil) })
+}
+
 // go test -v -count=1 ./internal/gateway/ -run ^TestSignUp$
 func TestSignUp(t *testing.T) {
 	cfg := __SetupTestConfig(t)
@@ -380,15 +394,26 @@ func TestGetPartnerProducts(t *testing.T) {
 	// Test case 1: Error
 	targetError := errors.New("s.partners.GetPartnerProducts error")
 	cfg.partnersClient.EXPECT().GetPartnerProducts(gomock.Any(), gomock.Any()).Return(nil, targetError)
-	_, err := cfg.service.getPartnerProducts(ctx)
+	_, err := cfg.service.getPartnerProducts(ctx, &productsRequest{})
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.partnersClient.EXPECT().GetPartnerProducts(gomock.Any(), gomock.Any()).Return(&products.GetAllResponse{}, nil).AnyTimes()
-
-	// Test case 2: Success
-	resp, err := cfg.service.getPartnerProducts(ctx)
+	// Test case 2: Success, the query values are passed on
+	cfg.partnersClient.EXPECT().GetPartnerProducts(gomock.Any(), &products.GetAllRequest{
+		SortBy:   "price_asc",
+		Query:    "pizza",
+		MaxPrice: 5000,
+		Limit:    10,
+		Cursor:   "next",
+	}).Return(&products.GetAllResponse{NextCursor: "after"}, nil)
+	resp, err := cfg.service.getPartnerProducts(ctx, &productsRequest{
+		Query:    "pizza",
+		MaxPrice: "5000",
+		Sort:     "price_asc",
+		Limit:    "10",
+		Cursor:   "next",
+	})
 	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+	assert.Equal(t, "after", resp.NextCursor)
 }
 
 // go test -count=1 -v ./internal/gateway/ -run ^TestCheckOrder$
@@ -399,6 +424,7 @@ func TestCheckOrder(t *testing.T) {
 	ctx := context.Background()
 	user := &user{}
 	req := &checkRequest{
+		DeliveryAddress: &address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025"},
 		Products: []*product{
 			{ID: 1, Quantity: 1},
 			{ID: 2, Quantity: 2},
@@ -406,31 +432,185 @@ func TestCheckOrder(t *testing.T) {
 	}
 
 	// Test case 1: Order is already checked
-	cfg.cache.EXPECT().GetStruct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-	err := cfg.service.checkOrder(ctx, user, req)
+	cfg.cache.EXPECT().GetStruct(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, key string, v any) error {
+		v.(*orders.Order).Pricing = &orders.Pricing{Subtotal: 300, Total: 450}
+		return nil
+	})
+	resp, err := cfg.service.checkOrder(ctx, user, req)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(450), resp.Total)
 
 	cfg.cache.EXPECT().GetStruct(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError).AnyTimes()
 
 	// Test case 2
 	targetError := errors.New("s.partners.CheckPartnerProducts error")
 	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).Return(nil, targetError)
-	err = cfg.service.checkOrder(ctx, user, req)
+	_, err = cfg.service.checkOrder(ctx, user, req)
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).Return(&partners.CheckResponse{}, nil).AnyTimes()
-
-	// Test case 3
+	// Test case 3: the partner is short of a product, nothing is cached
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *partners.CheckRequest, opts ...grpc.CallOption) (*partners.CheckResponse, error) {
+			assert.NotEmpty(t, in.ReservationID)
+			return &partners.CheckResponse{
+				Shortages: []*partners.StockShortage{{ProductID: 2, Title: "Burger", Requested: 2, Available: 1}},
+			}, nil
+		})
+	_, err = cfg.service.checkOrder(ctx, user, req)
+	var shortage *shortageError
+	assert.True(t, errors.As(err, &shortage))
+	assert.Equal(t, []*stockShortage{{ProductID: 2, Title: "Burger", Requested: 2, Available: 1}}, shortage.Shortages)
+
+	// Test case 4: the partner has paused ordering
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).Return(&partners.CheckResponse{Closed: "paused"}, nil)
+	_, err = cfg.service.checkOrder(ctx, user, req)
+	var closed *closedError
+	assert.True(t, errors.As(err, &closed))
+	assert.Equal(t, "paused", closed.Reason)
+
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).Return(&partners.CheckResponse{
+		Pricing: &orders.Pricing{Subtotal: 300, DeliveryFee: 100, Tax: 50, Total: 450},
+	}, nil).AnyTimes()
+
+	// Test case 5
 	targetError = errors.New("s.cache.SaveStruct error")
 	cfg.cache.EXPECT().SaveStruct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(targetError)
-	err = cfg.service.checkOrder(ctx, user, req)
+	_, err = cfg.service.checkOrder(ctx, user, req)
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.cache.EXPECT().SaveStruct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	// Test case 6: success, the order is charged with the fees
+	cfg.cache.EXPECT().SaveStruct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, v any, expiration time.Duration) error {
+			assert.Equal(t, int64(450), v.(*orders.Order).TotalAmount)
+			return nil
+		})
+	resp, err = cfg.service.checkOrder(ctx, user, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &pricing{Subtotal: 300, DeliveryFee: 100, Tax: 50, Total: 450}, resp)
+
+	req.PromoCode = "welcome10"
+
+	// Test case 7
+	targetError = errors.New("s.orders.CheckPromoCode error")
+	cfg.ordersClient.EXPECT().CheckPromoCode(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err = cfg.service.checkOrder(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
 
-	// Test case 4: success
-	err = cfg.service.checkOrder(ctx, user, req)
+	// Test case 8: success, the promo code discount is charged
+	cfg.ordersClient.EXPECT().CheckPromoCode(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+		&orders.Pricing{Subtotal: 300, DeliveryFee: 100, Discount: 30, Tax: 47, Total: 417}, nil)
+	cfg.cache.EXPECT().SaveStruct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, v any, expiration time.Duration) error {
+			assert.Equal(t, int64(417), v.(*orders.Order).TotalAmount)
+			assert.Equal(t, "WELCOME10", v.(*orders.Order).PromoCode)
+			return nil
+		})
+	resp, err = cfg.service.checkOrder(ctx, user, req)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(30), resp.Discount)
+
+	// Test case 9: success, the tip is added to the total untaxed
+	req.PromoCode = ""
+	req.Tip = 100
+	cfg.cache.EXPECT().SaveStruct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, v any, expiration time.Duration) error {
+			assert.Equal(t, int64(550), v.(*orders.Order).TotalAmount)
+			return nil
+		})
+	resp, err = cfg.service.checkOrder(ctx, user, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &pricing{Subtotal: 300, DeliveryFee: 100, Tax: 50, Tip: 100, Total: 550}, resp)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestCheckCart$
+func TestCheckCart(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	user := &user{ID: "u1"}
+	req := &cartRequest{
+		OrderID:         "cart1",
+		DeliveryAddress: &address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025"},
+		Partners: []*cartPartner{
+			{PartnerID: 1, Products: []*product{{ID: 1, Quantity: 1}}, TotalAmount: 300},
+			{PartnerID: 2, Products: []*product{{ID: 5, Quantity: 2}}, TotalAmount: 200},
+		},
+		Paytype: "visa",
+	}
+
+	cfg.cache.EXPECT().GetStruct(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError).AnyTimes()
+	checkPartner := func(ctx context.Context, req *partners.CheckRequest, opts ...any) (*partners.CheckResponse, error) {
+		return &partners.CheckResponse{
+			PartnerTitle: "partner " + strconv.Itoa(int(req.PartnerID)),
+			Pricing:      &orders.Pricing{Subtotal: req.TotalAmount, DeliveryFee: 100, Total: req.TotalAmount + 100},
+		}, nil
+	}
+
+	// Test case 1: One of the partners fails the check
+	targetError := errors.New("s.partners.CheckPartnerProducts error")
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *partners.CheckRequest, opts ...any) (*partners.CheckResponse, error) {
+			if req.PartnerID == 2 {
+				return nil, targetError
+			}
+			return checkPartner(ctx, req)
+		}).Times(2)
+	_, err := cfg.service.checkCart(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).DoAndReturn(checkPartner).AnyTimes()
+
+	// Test case 2: success, a child order is made per partner
+	cfg.cache.EXPECT().SaveStruct(gomock.Any(), "CART::u1cart1", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, v any, expiration time.Duration) error {
+			cart := v.(*orders.Cart)
+			assert.Len(t, cart.Orders, 2)
+			assert.Equal(t, "cart1-1", cart.Orders[0].OrderID)
+			assert.Equal(t, int64(300), cart.Orders[1].TotalAmount)
+			return nil
+		})
+	resp, err := cfg.service.checkCart(ctx, user, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(700), resp.Total)
+	assert.Equal(t, "partner 2", resp.Orders[1].PartnerTitle)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestWatchOrder$
+func TestWatchOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	user := &user{ID: "customer"}
+	stream := ordersmocks.NewMockOrders_WatchOrderClient(cfg.ctrl)
+
+	// Test case 1
+	targetError := errors.New("s.orders.WatchOrder error")
+	cfg.ordersClient.EXPECT().WatchOrder(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	err := cfg.service.watchOrder(ctx, user, 1, func(*orderEvent) error { return nil })
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.ordersClient.EXPECT().WatchOrder(gomock.Any(), &orders.WatchRequest{OrderID: 1, CustomerID: "customer"}).Return(stream, nil).AnyTimes()
+
+	// Test case 2: Client is gone
+	targetError = errors.New("send error")
+	stream.EXPECT().Recv().Return(&orders.OrderEvent{OrderID: 1, Event: "current", Status: "paid"}, nil)
+	err = cfg.service.watchOrder(ctx, user, 1, func(*orderEvent) error { return targetError })
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case 3: Success, the events are relayed until the stream ends
+	stream.EXPECT().Recv().Return(&orders.OrderEvent{OrderID: 1, Event: "current", Status: "ready"}, nil)
+	stream.EXPECT().Recv().Return(&orders.OrderEvent{OrderID: 1, Event: "delivering", Status: "delivering", DelivererID: "d1", EtaMinutes: 12}, nil)
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+	var events []*orderEvent
+	err = cfg.service.watchOrder(ctx, user, 1, func(event *orderEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, &orderEvent{OrderID: 1, Event: "delivering", Status: "delivering", DelivererID: "d1", ETAMinutes: 12}, events[1])
 }
 
 // go test -count=1 -v ./internal/gateway/ -run ^TestConfirmOrder$
@@ -476,17 +656,97 @@ func TestPayOrder(t *testing.T) {
 	// Test case 1
 	targetError := errors.New("s.orders.PayOrder error")
 	cfg.ordersClient.EXPECT().PayOrder(gomock.Any(), gomock.Any()).Return(nil, targetError)
-	_, err := cfg.service.payOrder(ctx, req)
+	_, err := cfg.service.payOrder(ctx, "visa", req)
 	assert.True(t, errors.Is(err, targetError))
 
 	cfg.ordersClient.EXPECT().PayOrder(gomock.Any(), gomock.Any()).Return(&orders.PayResponse{}, nil).AnyTimes()
 
 	// Test case 2: Success
-	resp, err := cfg.service.payOrder(ctx, req)
+	resp, err := cfg.service.payOrder(ctx, "visa", req)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 }
 
+// go test -count=1 -v ./internal/gateway/ -run ^TestVerifyPaymentCallback$
+func TestVerifyPaymentCallback(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	bank := &orders.Bank{
+		ID:             "visa",
+		CallbackSecret: "test callback secret",
+		AllowedIPs:     []string{"10.0.0.0/8", "192.168.1.10"},
+	}
+	sign := func(callback *paymentCallback) *paymentCallback {
+		callback.Signature, _ = generateHashByKey(bank.CallbackSecret,
+			callback.Timestamp+"."+callback.Nonce+"."+string(callback.Body))
+		return callback
+	}
+	newCallback := func() *paymentCallback {
+		return sign(&paymentCallback{
+			BankID:    bank.ID,
+			Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			Nonce:     "8baec4ff-e08a-4d4a-bf5e-1e8dc4dc9f55",
+			ClientIP:  "10.1.2.3",
+			Body:      []byte(`{"order_id":1,"payment_id":"pay-1","paid_amount":300}`),
+		})
+	}
+
+	// Test case #1: Missing headers
+	callback := newCallback()
+	callback.Signature = ""
+	err := cfg.service.verifyPaymentCallback(ctx, callback)
+	assert.Error(t, err)
+
+	// Test case #2: Expired timestamp
+	callback = newCallback()
+	callback.Timestamp = strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	err = cfg.service.verifyPaymentCallback(ctx, sign(callback))
+	assert.Error(t, err)
+
+	// Test case #3: Unknown bank
+	targetError := errors.New("s.orders.GetBank error")
+	cfg.ordersClient.EXPECT().GetBank(gomock.Any(), &orders.GetBankRequest{ID: "visa"}).Return(nil, targetError)
+	err = cfg.service.verifyPaymentCallback(ctx, newCallback())
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.ordersClient.EXPECT().GetBank(gomock.Any(), &orders.GetBankRequest{ID: "visa"}).Return(&orders.Bank{
+		ID:             bank.ID,
+		CallbackSecret: bank.CallbackSecret,
+		AllowedIPs:     bank.AllowedIPs,
+	}, nil).AnyTimes()
+
+	// Test case #4: Not allowed IP
+	callback = newCallback()
+	callback.ClientIP = "172.16.0.1"
+	err = cfg.service.verifyPaymentCallback(ctx, callback)
+	assert.Error(t, err)
+
+	// Test case #5: Tampered body
+	callback = newCallback()
+	callback.Body = []byte(`{"order_id":1,"payment_id":"pay-1","paid_amount":3000000}`)
+	err = cfg.service.verifyPaymentCallback(ctx, callback)
+	assert.Error(t, err)
+
+	// Test case #6
+	targetError = errors.New("s.cache.SaveStringIfAbsent error")
+	cfg.cache.EXPECT().SaveStringIfAbsent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, targetError)
+	err = cfg.service.verifyPaymentCallback(ctx, newCallback())
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #7: Replayed nonce, saved by the other delivery of the callback
+	cfg.cache.EXPECT().SaveStringIfAbsent(gomock.Any(), "PAYMENT_NONCE::visa::8baec4ff-e08a-4d4a-bf5e-1e8dc4dc9f55", gomock.Any(), gomock.Any()).Return(false, nil)
+	err = cfg.service.verifyPaymentCallback(ctx, newCallback())
+	assert.Error(t, err)
+
+	cfg.cache.EXPECT().SaveStringIfAbsent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+	// Test case #8: Success
+	err = cfg.service.verifyPaymentCallback(ctx, newCallback())
+	assert.NoError(t, err)
+}
+
 // go test -count=1 -v ./internal/gateway/ -run ^TestPickUpOrder$
 func TestPickUpOrder(t *testing.T) {
 	cfg := __SetupTestConfig(t)
@@ -520,12 +780,396 @@ func TestAssignOrder(t *testing.T) {
 	// Test case #1
 	targetError := errors.New("s.orders.AssignOrder error")
 	cfg.ordersClient.EXPECT().AssignOrder(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
-	err := cfg.service.assignOrder(ctx, user, req)
+	_, err := cfg.service.assignOrder(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.ordersClient.EXPECT().AssignOrder(gomock.Any(), gomock.Any(), gomock.Any()).Return(&orders.AssignResponse{
+		PickupAddress:   &orders.Address{Street: "Partner 1 Address", City: "Dushanbe", PostalCode: "734000"},
+		DeliveryAddress: &orders.Address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025", Apartment: "12"},
+	}, nil).AnyTimes()
+
+	// Test case #2: Success
+	resp, err := cfg.service.assignOrder(ctx, user, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "734025", resp.DeliveryAddress.PostalCode)
+	assert.Equal(t, "12", resp.DeliveryAddress.Apartment)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestUpdateOrderAddress$
+func TestUpdateOrderAddress(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	user := &user{}
+	req := &updateAddressRequest{
+		OrderID:         1,
+		DeliveryAddress: &address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025"},
+	}
+
+	// Test case #1
+	targetError := errors.New("s.orders.UpdateOrderAddress error")
+	cfg.ordersClient.EXPECT().UpdateOrderAddress(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	err := cfg.service.updateOrderAddress(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.ordersClient.EXPECT().UpdateOrderAddress(gomock.Any(), gomock.Any()).Return(&orders.UpdateAddressResponse{}, nil).AnyTimes()
+
+	// Test case #2: Success
+	err = cfg.service.updateOrderAddress(ctx, user, req)
+	assert.NoError(t, err)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestReconciliationCSV$
+func TestReconciliationCSV(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &reportRequest{DateFrom: "2024-01-01", DateTo: "2024-01-31"}
+
+	// Test case #1
+	targetError := errors.New("s.orders.ReconciliationReport error")
+	cfg.ordersClient.EXPECT().ReconciliationReport(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.reconciliationCSV(ctx, req)
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.ordersClient.EXPECT().AssignOrder(gomock.Any(), gomock.Any(), gomock.Any()).Return(&orders.AssignResponse{}, nil).AnyTimes()
+	// Test case #2: Success
+	cfg.ordersClient.EXPECT().ReconciliationReport(gomock.Any(), gomock.Any()).Return(&orders.ReportResponse{
+		Discrepancies: []*orders.Discrepancy{{
+			OrderID:        7,
+			PaymentID:      "pay-7",
+			BankID:         "visa",
+			Kind:           "overpayment",
+			ExpectedAmount: 300,
+			ActualAmount:   350,
+			Details:        "paid amount exceeds the order total",
+			DetectedAt:     "2024-01-02T10:00:00Z",
+		}},
+	}, nil)
+	content, err := cfg.service.reconciliationCSV(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "detected_at,kind,order_id,payment_id,bank_id,expected_amount,actual_amount,details\n"+
+		"2024-01-02T10:00:00Z,overpayment,7,pay-7,visa,300,350,paid amount exceeds the order total\n", string(content))
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestRefundOrder$
+func TestRefundOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &refundRequest{OrderID: 1, Products: []*product{{ID: 1, Quantity: 2}}}
+
+	// Test case #1
+	targetError := errors.New("s.orders.RefundOrder error")
+	cfg.ordersClient.EXPECT().RefundOrder(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.refundOrder(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
 
 	// Test case #2: Success
-	err = cfg.service.assignOrder(ctx, user, req)
+	cfg.ordersClient.EXPECT().RefundOrder(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, in *orders.RefundRequest, opts ...any) (*orders.RefundResponse, error) {
+			assert.Equal(t, int32(1), in.Products[0].ID)
+			assert.Equal(t, int32(2), in.Products[0].Quantity)
+			return &orders.RefundResponse{RefundID: "r1", Amount: 200, Status: "partially_refunded"}, nil
+		})
+	resp, err := cfg.service.refundOrder(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &refundResponse{RefundID: "r1", Amount: 200, Status: "partially_refunded"}, resp)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestUpdateBank$
+func TestUpdateBank(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &bankRequest{ID: "visa", Title: "Visa Inc.", Driver: "simulator", Currencies: []string{"USD"}}
+
+	// Test case #1
+	targetError := errors.New("s.orders.UpdateBank error")
+	cfg.ordersClient.EXPECT().UpdateBank(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.updateBank(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #2: Success, the secret isn't sent back
+	cfg.ordersClient.EXPECT().UpdateBank(gomock.Any(), gomock.Any()).Return(&orders.Bank{ID: "visa", Title: "Visa Inc.", CallbackSecret: "secret"}, nil)
+	resp, err := cfg.service.updateBank(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "visa", resp.ID)
+	assert.True(t, resp.HasCallbackSecret)
+	assert.Empty(t, resp.CallbackSecret)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestCreateBank$
+func TestCreateBank(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &bankRequest{ID: "visa", Title: "Visa Inc.", Driver: "simulator", Currencies: []string{"USD"}}
+	bank := &orders.Bank{ID: "visa", Title: "Visa Inc.", CallbackSecret: "generated secret"}
+
+	// Test case #1: The generated secret is shown once
+	cfg.ordersClient.EXPECT().CreateBank(gomock.Any(), gomock.Any()).Return(bank, nil)
+	resp, err := cfg.service.createBank(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "generated secret", resp.CallbackSecret)
+
+	// Test case #2: The admin's own secret isn't sent back
+	req.CallbackSecret = "admin's secret of at least 32 characters"
+	cfg.ordersClient.EXPECT().CreateBank(gomock.Any(), gomock.Any()).Return(bank, nil)
+	resp, err = cfg.service.createBank(ctx, req)
+	assert.NoError(t, err)
+	assert.True(t, resp.HasCallbackSecret)
+	assert.Empty(t, resp.CallbackSecret)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestGetPaymentMethods$
+func TestGetPaymentMethods(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+
+	// Test case #1
+	targetError := errors.New("s.orders.ListBanks error")
+	cfg.ordersClient.EXPECT().ListBanks(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.getPaymentMethods(ctx)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #2: Success, only active banks are requested
+	cfg.ordersClient.EXPECT().ListBanks(gomock.Any(), &orders.ListBanksRequest{ActiveOnly: true}).Return(&orders.ListBanksResponse{
+		Banks: []*orders.Bank{{ID: "km", Title: "Korti Milli", CallbackSecret: "secret", Currencies: []string{"TJS"}}},
+	}, nil)
+	methods, err := cfg.service.getPaymentMethods(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*paymentMethod{{ID: "km", Title: "Korti Milli", Currencies: []string{"TJS"}}}, methods)
+}
+
+// go test -v -count=1 ./internal/gateway/ -run ^TestGetOrderLocation$
+func TestGetOrderLocation(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	customer := &user{ID: "customer"}
+	stranger := &user{ID: "stranger"}
+
+	cfg.cache.EXPECT().GetStruct(gomock.Any(), "LOCATION::1", gomock.Any()).DoAndReturn(func(ctx context.Context, key string, v any) error {
+		v.(*cachedLocation).CustomerID = "customer"
+		v.(*cachedLocation).Location = &deliveryLocation{Latitude: 41.28, Longitude: 69.24, ETAMinutes: 7}
+		return nil
+	}).Times(2)
+
+	// Test case 1: Someone else's order
+	_, err := cfg.service.getOrderLocation(ctx, stranger, 1)
+	assert.Error(t, err)
+
+	// Test case 2: Success
+	location, err := cfg.service.getOrderLocation(ctx, customer, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), location.ETAMinutes)
+}
+
+// go test -v -count=1 ./internal/gateway/ -run ^TestReorder$
+func TestReorder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	user := &user{ID: "customer"}
+	req := &reorderRequest{OrderID: "new order", PastOrderID: 1}
+
+	// Test case 1: Not the customer's order
+	targetError := errors.New("s.orders.GetOrder error")
+	cfg.ordersClient.EXPECT().GetOrder(gomock.Any(), &orders.GetOrderRequest{OrderID: 1, CustomerID: "customer"}).Return(nil, targetError)
+	_, err := cfg.service.reorder(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.ordersClient.EXPECT().GetOrder(gomock.Any(), gomock.Any()).Return(&orders.Order{
+		PartnerID:       1,
+		DeliveryAddress: &orders.Address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025"},
+		Paytype:         "visa",
+		Products: []*orders.Product{
+			{ID: 1, Quantity: 2, Price: 100},
+			{ID: 2, Quantity: 1, Price: 100},
+			{ID: 3, Quantity: 1, Price: 300},
+		},
+	}, nil).AnyTimes()
+
+	// Test case 2
+	targetError = errors.New("s.partners.CheckPartnerProducts error")
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err = cfg.service.reorder(ctx, user, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case 3: Success, the changed prices and the products no longer available are flagged
+	cfg.partnersClient.EXPECT().CheckPartnerProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *partners.CheckRequest, opts ...any) (*partners.CheckResponse, error) {
+			assert.True(t, req.Reorder)
+			return &partners.CheckResponse{
+				Products: []*orders.Product{
+					{ID: 1, Quantity: 2, Price: 100, Title: "Plov"},
+					{ID: 2, Quantity: 1, Price: 120, Title: "Tea"},
+				},
+				Unavailable: []*orders.Product{{ID: 3, Quantity: 1}},
+				Pricing:     &orders.Pricing{Subtotal: 320, DeliveryFee: 100, Total: 420},
+			}, nil
+		})
+	cfg.cache.EXPECT().SaveStruct(gomock.Any(), "ORDER::customernew order", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key string, v any, expiration time.Duration) error {
+			assert.Equal(t, int64(420), v.(*orders.Order).TotalAmount)
+			assert.Equal(t, "visa", v.(*orders.Order).Paytype)
+			assert.Len(t, v.(*orders.Order).Products, 2)
+			return nil
+		})
+	resp, err := cfg.service.reorder(ctx, user, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(420), resp.Pricing.Total)
+	assert.Equal(t, []*reorderProduct{
+		{ID: 1, Title: "Plov", Quantity: 2, Price: 100, PastPrice: 100},
+		{ID: 2, Title: "Tea", Quantity: 1, Price: 120, PastPrice: 100, PriceChanged: true},
+		{ID: 3, Quantity: 1, PastPrice: 300, Unavailable: true},
+	}, resp.Products)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestSetAvailable$
+func TestSetAvailable(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	admin := &user{ID: "admin", Roles: []string{"admin"}}
+	staff := &user{ID: "staff", Roles: []string{"partner"}}
+	req := &availableRequest{ProductID: 1, Price: 100, Active: true}
+
+	// Test case #1: Admin chooses no partner
+	_, err := cfg.service.setAvailable(ctx, admin, req)
+	assert.Error(t, err)
+
+	// Test case #2: Admin lists for a partner
+	req.PartnerID = 3
+	cfg.partnersClient.EXPECT().SetAvailable(gomock.Any(), &products.Availability{ProductID: 1, PartnerID: 3, Price: 100, Active: true, Stock: -1}).
+		Return(&products.Availability{ProductID: 1, PartnerID: 3, Price: 100, Active: true, Stock: -1}, nil)
+	resp, err := cfg.service.setAvailable(ctx, admin, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), resp.PartnerID)
+
+	// Test case #3
+	targetError := errors.New("s.partners.GetPartner error")
+	cfg.partnersClient.EXPECT().GetPartner(gomock.Any(), &partners.GetPartnerRequest{UserID: "staff"}).Return(nil, targetError)
+	_, err = cfg.service.setAvailable(ctx, staff, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success, a partner lists for itself only with the stock tracked
+	var stock int32 = 5
+	req.Stock = &stock
+	cfg.partnersClient.EXPECT().GetPartner(gomock.Any(), &partners.GetPartnerRequest{UserID: "staff"}).Return(&partners.PartnerInfo{ID: 2}, nil)
+	cfg.partnersClient.EXPECT().SetAvailable(gomock.Any(), &products.Availability{ProductID: 1, PartnerID: 2, Price: 100, Active: true, Stock: 5}).
+		Return(&products.Availability{ProductID: 1, PartnerID: 2, Price: 100, Active: true, Stock: 5}, nil)
+	resp, err = cfg.service.setAvailable(ctx, staff, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.PartnerID)
+	assert.Equal(t, int32(5), resp.Stock)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestSetProductLabels$
+func TestSetProductLabels(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	admin := &user{ID: "admin", Roles: []string{"admin"}}
+	staff := &user{ID: "staff", Roles: []string{"partner"}}
+	req := &labelsRequest{ProductID: 1, CategoryIDs: []int32{3}, Tags: []string{"vegan"}}
+
+	// Test case #1: Admin labels any product
+	cfg.partnersClient.EXPECT().SetProductLabels(gomock.Any(), &products.ProductLabels{ProductID: 1, CategoryIDs: []int32{3}, Tags: []string{"vegan"}}).
+		Return(&products.ProductLabels{ProductID: 1, CategoryIDs: []int32{3}, Tags: []string{"vegan"}}, nil)
+	resp, err := cfg.service.setProductLabels(ctx, admin, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vegan"}, resp.Tags)
+
+	// Test case #2: A partner labels its own products only
+	targetError := errors.New("s.partners.SetProductLabels error")
+	cfg.partnersClient.EXPECT().GetPartner(gomock.Any(), &partners.GetPartnerRequest{UserID: "staff"}).Return(&partners.PartnerInfo{ID: 2}, nil)
+	cfg.partnersClient.EXPECT().SetProductLabels(gomock.Any(), &products.ProductLabels{ProductID: 1, PartnerID: 2, CategoryIDs: []int32{3}, Tags: []string{"vegan"}}).
+		Return(nil, targetError)
+	_, err = cfg.service.setProductLabels(ctx, staff, req)
+	assert.True(t, errors.Is(err, targetError))
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestSetProductOptions$
+func TestSetProductOptions(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	admin := &user{ID: "admin", Roles: []string{"admin"}}
+	staff := &user{ID: "staff", Roles: []string{"partner"}}
+	req := &productOptions{
+		ProductID: 7,
+		Variants:  []*variant{{ID: 99, Title: "large", Price: 150}},
+		ModifierGroups: []*modifierGroup{{
+			Title:         "extras",
+			MaxSelections: 1,
+			Modifiers:     []*modifier{{Title: "cheese", Price: 30}},
+		}},
+	}
+
+	// Test case #1: Admin chooses no partner
+	_, err := cfg.service.setProductOptions(ctx, admin, req)
+	assert.Error(t, err)
+
+	// Test case #2: Success, a partner sets its own product's options, the IDs are given by the partners service
+	cfg.partnersClient.EXPECT().GetPartner(gomock.Any(), &partners.GetPartnerRequest{UserID: "staff"}).Return(&partners.PartnerInfo{ID: 2}, nil)
+	cfg.partnersClient.EXPECT().SetProductOptions(gomock.Any(), &partners.ProductOptions{
+		ProductID: 7,
+		PartnerID: 2,
+		Variants:  []*partners.Variant{{Title: "large", Price: 150}},
+		ModifierGroups: []*partners.ModifierGroup{{
+			Title:         "extras",
+			MaxSelections: 1,
+			Modifiers:     []*partners.Modifier{{Title: "cheese", Price: 30}},
+		}},
+	}).Return(&partners.ProductOptions{
+		ProductID: 7,
+		PartnerID: 2,
+		Variants:  []*partners.Variant{{ID: 11, Title: "large", Price: 150}},
+		ModifierGroups: []*partners.ModifierGroup{{
+			ID:            5,
+			Title:         "extras",
+			MaxSelections: 1,
+			Modifiers:     []*partners.Modifier{{ID: 21, Title: "cheese", Price: 30}},
+		}},
+	}, nil)
+	resp, err := cfg.service.setProductOptions(ctx, staff, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.PartnerID)
+	assert.Equal(t, int32(11), resp.Variants[0].ID)
+	assert.Equal(t, int32(21), resp.ModifierGroups[0].Modifiers[0].ID)
+}
+
+// go test -count=1 -v ./internal/gateway/ -run ^TestRotateWebhookSecret$
+func TestRotateWebhookSecret(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	admin := &user{ID: "admin", Roles: []string{"admin"}}
+	staff := &user{ID: "staff", Roles: []string{"partner"}}
+
+	// Test case #1: Admin chooses no partner
+	_, err := cfg.service.rotateWebhookSecret(ctx, admin, 0)
+	assert.Error(t, err)
+
+	// Test case #2: A partner rotates its own secret, not the one of the query
+	cfg.partnersClient.EXPECT().GetPartner(gomock.Any(), &partners.GetPartnerRequest{UserID: "staff"}).Return(&partners.PartnerInfo{ID: 2}, nil)
+	cfg.partnersClient.EXPECT().RotateWebhookSecret(gomock.Any(), &partners.GetPartnerRequest{ID: 2}).
+		Return(&partners.WebhookSecret{PartnerID: 2, Secret: "new secret"}, nil)
+	resp, err := cfg.service.rotateWebhookSecret(ctx, staff, 5)
 	assert.NoError(t, err)
+	assert.Equal(t, &webhookSecretResponse{PartnerID: 2, Secret: "new secret"}, resp)
 }
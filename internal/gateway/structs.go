@@ -82,10 +82,106 @@ type checkRequest struct {
 	OrderID         string     `json:"order_id" validate:"required"`
 	PartnerID       int32      `json:"partner_id" validate:"required,gt=0"`
 	CustomerPhone   string     `json:"customer_phone" validate:"omitempty,e164"`
-	DeliveryAddress string     `json:"delivery_address" validate:"required,min=6"`
+	DeliveryAddress *address   `json:"delivery_address" validate:"required"`
 	Products        []*product `json:"products" validate:"required"`
-	TotalAmount     int64      `json:"total_amount" validate:"required,gt=0"`
+	TotalAmount     int64      `json:"total_amount" validate:"required,gt=0"` // sum of the products, fees are added by the pricing
 	Paytype         string     `json:"paytype" validate:"required"`
+	PromoCode       string     `json:"promo_code" validate:"omitempty,alphanum,max=32"`
+	Tip             int64      `json:"tip" validate:"omitempty,gt=0"`                                        // for the deliverer, added to the total
+	ScheduledAt     string     `json:"scheduled_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // delivery slot in RFC3339, empty for ASAP
+}
+
+// reorderRequest checks the products of a past order again, to be confirmed as a new order.
+type reorderRequest struct {
+	OrderID         string   `json:"order_id" validate:"required"` // of the new order
+	PastOrderID     int64    `json:"past_order_id" validate:"required,gt=0"`
+	DeliveryAddress *address `json:"delivery_address" validate:"omitempty"`                                // the past order's by default
+	Paytype         string   `json:"paytype" validate:"omitempty"`                                         // the past order's by default
+	ScheduledAt     string   `json:"scheduled_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // delivery slot in RFC3339, empty for ASAP
+}
+
+type reorderResponse struct {
+	Pricing  *pricing          `json:"pricing"`
+	Products []*reorderProduct `json:"products"`
+}
+
+type reorderProduct struct {
+	ID           int32             `json:"id"`
+	Title        string            `json:"title"`
+	Quantity     int32             `json:"quantity"`
+	Price        int32             `json:"price"` // the current one
+	PastPrice    int32             `json:"past_price"`
+	PriceChanged bool              `json:"price_changed"`
+	Unavailable  bool              `json:"unavailable"` // no longer sold by the partner or with the options, left out of the new order
+	Options      []*selectedOption `json:"options,omitempty"`
+}
+
+// selectedOption is the variant or a modifier a product is ordered with.
+type selectedOption struct {
+	Group string `json:"group"` // the modifier group's title, empty - the variant
+	Title string `json:"title"`
+	Price int32  `json:"price"` // the variant's, or added by the modifier
+}
+
+// pricing is the price breakdown of an order, amounts are in the minor units of the currency.
+type pricing struct {
+	Subtotal      int64   `json:"subtotal"` // sum of the products
+	DeliveryFee   int64   `json:"delivery_fee"`
+	SmallOrderFee int64   `json:"small_order_fee"`
+	ServiceFee    int64   `json:"service_fee"`
+	Discount      int64   `json:"discount"` // given by the promo code
+	Tip           int64   `json:"tip"`
+	Tax           int64   `json:"tax"`
+	Total         int64   `json:"total"` // the amount to pay
+	DistanceKm    float64 `json:"distance_km"`
+}
+
+// cartRequest is a cart with the products of several partners, confirmed and paid at once.
+type cartRequest struct {
+	OrderID         string         `json:"order_id" validate:"required"`
+	CustomerPhone   string         `json:"customer_phone" validate:"omitempty,e164"`
+	DeliveryAddress *address       `json:"delivery_address" validate:"required"`
+	Partners        []*cartPartner `json:"partners" validate:"required,min=2,unique=PartnerID,dive"`
+	Paytype         string         `json:"paytype" validate:"required"`
+	ScheduledAt     string         `json:"scheduled_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // delivery slot in RFC3339, empty for ASAP
+}
+
+type cartPartner struct {
+	PartnerID   int32      `json:"partner_id" validate:"required,gt=0"`
+	Products    []*product `json:"products" validate:"required"`
+	TotalAmount int64      `json:"total_amount" validate:"required,gt=0"` // sum of the partner's products
+}
+
+type cartPricing struct {
+	Orders []*cartOrder `json:"orders"` // one per partner
+	Total  int64        `json:"total"`  // the amount to pay for the whole cart
+}
+
+type cartOrder struct {
+	PartnerID    int32    `json:"partner_id"`
+	PartnerTitle string   `json:"partner_title"`
+	PartnerBrand string   `json:"partner_brand"`
+	Pricing      *pricing `json:"pricing"`
+}
+
+type cartResponse struct {
+	CheckoutID     int64   `json:"checkout_id"`
+	OrderIDs       []int64 `json:"order_ids"` // the child orders, each is picked up and delivered on its own
+	TotalAmount    int64   `json:"total_amount"`
+	WebcheckoutURL string  `json:"webcheckout_url"`
+	CallbackURL    string  `json:"callback_url"`
+}
+
+// orderEvent is a server-sent event of the order tracking stream.
+type orderEvent struct {
+	OrderID     int64   `json:"order_id"`
+	Event       string  `json:"event"` // current, paid, ready, delivering, address_updated, refunded, cancelled, expired, delivered
+	Status      string  `json:"status"`
+	DelivererID string  `json:"deliverer_id,omitempty"`
+	ETAMinutes  int32   `json:"eta_minutes,omitempty"`
+	Latitude    float64 `json:"latitude,omitempty"` // of the deliverer, on location events
+	Longitude   float64 `json:"longitude,omitempty"`
+	CreatedAt   string  `json:"created_at"`
 }
 
 type confirmRequest struct {
@@ -93,12 +189,13 @@ type confirmRequest struct {
 }
 
 type confirmResponse struct {
-	OrderID        int64  `json:"order_id"`
-	TotalAmount    int64  `json:"total_amount"`
-	PartnerTitle   string `json:"partner_title"`
-	PartnerBrand   string `json:"partner_brand"`
-	WebcheckoutURL string `json:"webcheckout_url"`
-	CallbackURL    string `json:"callback_url"`
+	OrderID        int64    `json:"order_id"`
+	TotalAmount    int64    `json:"total_amount"`
+	PartnerTitle   string   `json:"partner_title"`
+	PartnerBrand   string   `json:"partner_brand"`
+	WebcheckoutURL string   `json:"webcheckout_url"`
+	CallbackURL    string   `json:"callback_url"`
+	Pricing        *pricing `json:"pricing"`
 }
 
 type payRequest struct {
@@ -107,20 +204,445 @@ type payRequest struct {
 	PaidAmount int64  `json:"paid_amount" validate:"required"`
 }
 
+// paymentCallback holds what a payment provider sends to authenticate its callback.
+type paymentCallback struct {
+	BankID    string // X-Bank-ID header: the bank (paytype) that sends the callback
+	Timestamp string // X-Timestamp header: unix seconds when the callback was signed
+	Nonce     string // X-Nonce header: unique per callback, rejects replays
+	Signature string // X-Signature header: hex(hmac_sha256(timestamp + "." + nonce + "." + body, callbackSecret))
+	ClientIP  string
+	Body      []byte
+}
+
 type payResponse struct {
 	PaymentID string `json:"payment_id"`
 }
 
 type product struct {
-	ID       int `json:"id" validate:"required"`
-	Quantity int `json:"quantity" validate:"required"`
+	ID          int     `json:"id" validate:"required"`
+	Quantity    int     `json:"quantity" validate:"required,min=1"`
+	VariantID   int32   `json:"variant_id" validate:"omitempty,gt=0"` // required if the partner sells the product in variants
+	ModifierIDs []int32 `json:"modifier_ids" validate:"max=50,dive,gt=0"`
 }
 
 type pickupRequest struct {
-	OrderID       int64  `json:"order_id"`
-	PickupAddress string `json:"pickup_address" validate:"required"`
+	OrderID       int64    `json:"order_id"`
+	PickupAddress *address `json:"pickup_address" validate:"required"`
 }
 
 type assignRequest struct {
 	OrderID int64 `json:"order_id"`
 }
+
+type assignResponse struct {
+	PickupAddress   *address `json:"pickup_address"`
+	DeliveryAddress *address `json:"delivery_address"`
+}
+
+type updateAddressRequest struct {
+	OrderID         int64    `json:"order_id" validate:"required"`
+	DeliveryAddress *address `json:"delivery_address" validate:"required"`
+}
+
+type cancelRequest struct {
+	OrderID int64 `json:"order_id" validate:"required"`
+}
+
+type locationRequest struct {
+	OrderID   int64   `json:"order_id" validate:"required"`
+	Latitude  float64 `json:"latitude" validate:"required,lat"`
+	Longitude float64 `json:"longitude" validate:"required,lng"`
+}
+
+// deliveryLocation is the latest position of the deliverer of an order on the way.
+type deliveryLocation struct {
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	DistanceKm float64 `json:"distance_km"` // straight line to the delivery address
+	ETAMinutes int32   `json:"eta_minutes"`
+	UpdatedAt  string  `json:"updated_at"`
+}
+
+// cachedLocation keeps the owner of the order next to the location, the only one who sees it.
+type cachedLocation struct {
+	CustomerID string            `json:"customer_id"`
+	Location   *deliveryLocation `json:"location"`
+}
+
+type completeRequest struct {
+	OrderID int64 `json:"order_id" validate:"required"`
+}
+
+type completeResponse struct {
+	Earned int64 `json:"earned"` // delivery fee share and the checkout tip
+}
+
+type tipRequest struct {
+	OrderID int64 `json:"order_id" validate:"required"`
+	Amount  int64 `json:"amount" validate:"required,gt=0"`
+}
+
+type tipResponse struct {
+	OrderID        int64  `json:"order_id"`
+	WebcheckoutURL string `json:"webcheckout_url"`
+	CallbackURL    string `json:"callback_url"`
+}
+
+type earningsRequest struct {
+	Date string `json:"date" validate:"omitempty,dateonly"` // YYYY-MM-DD
+}
+
+// earnings are what a deliverer earned in a day or a week.
+type earnings struct {
+	Date         string `json:"date,omitempty"` // YYYY-MM-DD, empty for the week
+	Deliveries   int32  `json:"deliveries"`
+	DeliveryFees int64  `json:"delivery_fees"`
+	Tips         int64  `json:"tips"`
+	Total        int64  `json:"total"`
+}
+
+type earningsResponse struct {
+	Days          []*earnings `json:"days"`
+	Week          *earnings   `json:"week"`
+	PendingPayout int64       `json:"pending_payout"` // earned and not paid out yet, all time
+}
+
+type reviewRequest struct {
+	OrderID         int64  `json:"order_id" validate:"required"`
+	PartnerRating   int32  `json:"partner_rating" validate:"required,min=1,max=5"`
+	DelivererRating int32  `json:"deliverer_rating" validate:"required,min=1,max=5"`
+	Comment         string `json:"comment" validate:"omitempty,max=1000"`
+}
+
+// productsRequest is the filters of the products list, as query values.
+type productsRequest struct {
+	Query      string `json:"q" validate:"max=100"` // searched in the products' title and description
+	PartnerID  string `json:"partner_id" validate:"omitempty,number"`
+	MinPrice   string `json:"min_price" validate:"omitempty,number"`
+	MaxPrice   string `json:"max_price" validate:"omitempty,number"`
+	CategoryID string `json:"category_id" validate:"omitempty,number"` // the subcategories' products included
+	Tag        string `json:"tag" validate:"max=50"`
+	Sort       string `json:"sort" validate:"omitempty,oneof=rating price_asc price_desc relevance"`
+	Limit      string `json:"limit" validate:"omitempty,number"` // 20 by default, 100 at most
+	Cursor     string `json:"cursor" validate:"max=200"`         // next_cursor of the previous page
+}
+
+type listReviewsRequest struct {
+	PartnerID     string `json:"partner_id" validate:"omitempty,number"`
+	DelivererID   string `json:"deliverer_id" validate:"omitempty,max=40"`
+	IncludeHidden string `json:"include_hidden" validate:"omitempty,boolean"`
+	Limit         string `json:"limit" validate:"omitempty,number"`  // 20 by default, 100 at most
+	Offset        string `json:"offset" validate:"omitempty,number"` // 0 by default
+}
+
+type review struct {
+	OrderID         int64  `json:"order_id"`
+	PartnerID       int32  `json:"partner_id"`
+	DelivererID     string `json:"deliverer_id"`
+	PartnerRating   int32  `json:"partner_rating"`
+	DelivererRating int32  `json:"deliverer_rating"`
+	Comment         string `json:"comment"`
+	Hidden          bool   `json:"hidden"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type reviewsResponse struct {
+	Reviews     []*review `json:"reviews"`
+	Rating      float64   `json:"rating"` // average of the partner or the deliverer, hidden reviews aren't counted
+	RatingCount int32     `json:"rating_count"`
+}
+
+type refundRequest struct {
+	OrderID  int64      `json:"order_id" validate:"required"`
+	Products []*product `json:"products" validate:"omitempty,dive"` // the refunded lines, with the options they're ordered with; empty to refund the whole order
+	Reason   string     `json:"reason" validate:"omitempty,max=256"`
+}
+
+type refundResponse struct {
+	RefundID string `json:"refund_id"`
+	Amount   int64  `json:"amount"`
+	Status   string `json:"status"` // refunded, partially_refunded
+}
+
+type bankRequest struct {
+	ID             string   `json:"id" validate:"required,alphanum,max=10"` // the paytype of the orders
+	Title          string   `json:"title" validate:"required,max=50"`
+	Driver         string   `json:"driver" validate:"required"` // payment provider driver that serves the bank
+	WebcheckoutURL string   `json:"webcheckout_url" validate:"omitempty,url,max=256"`
+	CallbackSecret string   `json:"callback_secret" validate:"omitempty,min=32,max=64"` // generated on create and kept on update when empty
+	AllowedIPs     []string `json:"allowed_ips" validate:"omitempty,dive,ip|cidr"`
+	Currencies     []string `json:"currencies" validate:"required,min=1,dive,iso4217"`
+	Active         bool     `json:"active"` // used on create only, see enable/disable
+}
+
+type bankResponse struct {
+	ID                string   `json:"id"`
+	Title             string   `json:"title"`
+	Driver            string   `json:"driver"`
+	WebcheckoutURL    string   `json:"webcheckout_url"`
+	HasCallbackSecret bool     `json:"has_callback_secret"`
+	CallbackSecret    string   `json:"callback_secret,omitempty"` // the generated one, shown once on create
+	AllowedIPs        []string `json:"allowed_ips"`
+	Currencies        []string `json:"currencies"`
+	Active            bool     `json:"active"`
+}
+
+type partnerRequest struct {
+	ID        int32   `json:"id" validate:"omitempty,gt=0"` // used on update only
+	Title     string  `json:"title" validate:"required,max=50"`
+	Brand     string  `json:"brand" validate:"required,max=20"`
+	Phone     string  `json:"phone" validate:"required,e164"`
+	Email     string  `json:"email" validate:"required,email,max=100"`
+	Address   string  `json:"address" validate:"required,max=100"`
+	APIURL    string  `json:"api_url" validate:"required,http_url,max=100"` // the paid orders are sent to
+	Latitude  float64 `json:"latitude" validate:"omitempty,lat"`
+	Longitude float64 `json:"longitude" validate:"omitempty,lng"`
+	Timezone  string  `json:"timezone" validate:"omitempty,timezone"` // of the opening hours, Asia/Dushanbe by default
+	Verified  bool    `json:"verified"`
+	Enabled   bool    `json:"enabled"`                             // used on create only, see enable/disable
+	UserID    string  `json:"user_id" validate:"omitempty,max=36"` // of the partner's staff, who manage its own listings
+}
+
+type partnerResponse struct {
+	ID        int32   `json:"id"`
+	Title     string  `json:"title"`
+	Brand     string  `json:"brand"`
+	Phone     string  `json:"phone"`
+	Email     string  `json:"email"`
+	Address   string  `json:"address"`
+	APIURL    string  `json:"api_url"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone"`
+	Verified  bool    `json:"verified"`
+	Enabled   bool    `json:"enabled"`
+	Rating    float64 `json:"rating"`
+	UserID    string  `json:"user_id"`
+	Paused    bool    `json:"paused"` // takes no orders until resumed
+}
+
+// openingHoursRequest replaces the partner's opening hours, in the partner's timezone.
+type openingHoursRequest struct {
+	PartnerID int32            `json:"partner_id" validate:"omitempty,gt=0"` // admins only, a partner sets its own
+	Weekly    []*openingPeriod `json:"weekly" validate:"dive"`               // empty - open at any time
+	Closures  []*closure       `json:"closures" validate:"dive"`
+}
+
+type openingPeriod struct {
+	Weekday  int32  `json:"weekday" validate:"gte=0,lte=6"` // 0 - Sunday
+	OpensAt  string `json:"opens_at" validate:"required,datetime=15:04"`
+	ClosesAt string `json:"closes_at" validate:"required,datetime=15:04"` // later than opens_at on the same day
+}
+
+// closure is the days the partner is closed on despite its opening hours, e.g. holidays.
+type closure struct {
+	StartsOn string `json:"starts_on" validate:"required,dateonly"` // YYYY-MM-DD
+	EndsOn   string `json:"ends_on" validate:"required,dateonly"`   // YYYY-MM-DD, inclusive
+	Reason   string `json:"reason" validate:"max=200"`
+}
+
+type openingHoursResponse struct {
+	PartnerID int32            `json:"partner_id"`
+	Weekly    []*openingPeriod `json:"weekly"`
+	Closures  []*closure       `json:"closures"` // the upcoming ones
+	Timezone  string           `json:"timezone"`
+}
+
+// productOptions are what a partner's product is ordered with: one of the variants (e.g. sizes),
+// priced instead of the listing, and the modifiers (e.g. extras) added to the price.
+type productOptions struct {
+	ProductID      int32            `json:"product_id" validate:"required,gt=0"`
+	PartnerID      int32            `json:"partner_id" validate:"omitempty,gt=0"` // admins only, a partner sets its own
+	Variants       []*variant       `json:"variants" validate:"max=20,dive"`      // empty - sold at the listing's price
+	ModifierGroups []*modifierGroup `json:"modifier_groups" validate:"max=20,dive"`
+}
+
+type variant struct {
+	ID    int32  `json:"id"` // read-only, new ones are given on every update
+	Title string `json:"title" validate:"required,max=50"`
+	Price int32  `json:"price" validate:"required,gt=0"`
+}
+
+type modifierGroup struct {
+	ID            int32       `json:"id"` // read-only
+	Title         string      `json:"title" validate:"required,max=50"`
+	MinSelections int32       `json:"min_selections" validate:"gte=0"` // 0 - optional
+	MaxSelections int32       `json:"max_selections" validate:"required,gtefield=MinSelections"`
+	Modifiers     []*modifier `json:"modifiers" validate:"required,min=1,max=50,dive"`
+}
+
+type modifier struct {
+	ID    int32  `json:"id"` // read-only
+	Title string `json:"title" validate:"required,max=50"`
+	Price int32  `json:"price" validate:"gte=0"` // added to the product's, 0 - free
+}
+
+type webhookSecretResponse struct {
+	PartnerID int32  `json:"partner_id"`
+	Secret    string `json:"secret"` // shown once, the webhooks are signed with it from now on
+}
+
+type listDeliveriesRequest struct {
+	PartnerID string `json:"partner_id" validate:"omitempty,number"`
+	Status    string `json:"status" validate:"omitempty,oneof=pending delivered dead"`
+	OrderID   string `json:"order_id" validate:"omitempty,number"`
+	Limit     string `json:"limit" validate:"omitempty,number"`  // 20 by default, 100 at most
+	Offset    string `json:"offset" validate:"omitempty,number"` // 0 by default
+}
+
+type webhookDelivery struct {
+	ID             int64             `json:"id"`
+	PartnerID      int32             `json:"partner_id"`
+	Event          string            `json:"event"`
+	OrderID        int64             `json:"order_id"`
+	Payload        string            `json:"payload"`
+	Status         string            `json:"status"` // pending, delivered, dead
+	Attempts       int32             `json:"attempts"`
+	NextAttemptAt  string            `json:"next_attempt_at,omitempty"`
+	LastStatusCode int32             `json:"last_status_code"` // 0 - no response
+	LastError      string            `json:"last_error,omitempty"`
+	CreatedAt      string            `json:"created_at"`
+	DeliveredAt    string            `json:"delivered_at,omitempty"`
+	Log            []*webhookAttempt `json:"log,omitempty"` // the latest first, of a single delivery only
+}
+
+type webhookAttempt struct {
+	Attempt     int32  `json:"attempt"`
+	StatusCode  int32  `json:"status_code"` // 0 - no response, e.g. a timeout
+	Error       string `json:"error,omitempty"`
+	DurationMs  int32  `json:"duration_ms"`
+	AttemptedAt string `json:"attempted_at"`
+}
+
+// stockShortage is a product of the order the partner doesn't have enough of.
+type stockShortage struct {
+	PartnerID int32  `json:"partner_id"`
+	ProductID int32  `json:"product_id"`
+	Title     string `json:"title"` // empty - the product is not on sale by the partner at all
+	Requested int32  `json:"requested"`
+	Available int32  `json:"available"`
+}
+
+type productRequest struct {
+	ID          int32  `json:"id" validate:"omitempty,gt=0"` // used on update only
+	Title       string `json:"title" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=256"`
+	PartnerID   int32  `json:"partner_id" validate:"omitempty,gt=0"` // admins only: the partner the product is added for, a partner adds its own
+}
+
+type productResponse struct {
+	ID          int32    `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	PictureURL  string   `json:"picture_url"`
+	PartnerID   int32    `json:"partner_id"` // 0 - of the admins' catalog
+	CategoryIDs []int32  `json:"category_ids"`
+	Tags        []string `json:"tags"`
+}
+
+// labelsRequest replaces the categories and the tags of a product.
+type labelsRequest struct {
+	ProductID   int32    `json:"product_id" validate:"required,gt=0"`
+	CategoryIDs []int32  `json:"category_ids" validate:"max=10,dive,gt=0"`
+	Tags        []string `json:"tags" validate:"max=20,dive,min=1,max=50"`
+}
+
+type labelsResponse struct {
+	ProductID   int32    `json:"product_id"`
+	CategoryIDs []int32  `json:"category_ids"`
+	Tags        []string `json:"tags"`
+}
+
+type categoryRequest struct {
+	ID       int32  `json:"id" validate:"omitempty,gt=0"` // used on update only
+	Title    string `json:"title" validate:"required,max=100"`
+	ParentID int32  `json:"parent_id" validate:"omitempty,gt=0"` // 0 - a top-level category
+}
+
+type categoryResponse struct {
+	ID            int32               `json:"id"`
+	Title         string              `json:"title"`
+	ParentID      int32               `json:"parent_id"`
+	ProductCount  int32               `json:"product_count"` // of the subcategories too, on the tree only
+	Subcategories []*categoryResponse `json:"subcategories,omitempty"`
+}
+
+type availableRequest struct {
+	ProductID int32  `json:"product_id" validate:"required,gt=0"`
+	PartnerID int32  `json:"partner_id" validate:"omitempty,gt=0"` // admins only, a partner lists its own
+	Price     int32  `json:"price" validate:"required,gt=0"`
+	Active    bool   `json:"active"`
+	Stock     *int32 `json:"stock" validate:"omitempty,gte=0"` // left on sale, the reserved quantities are taken off; null - not tracked
+}
+
+type availableResponse struct {
+	ProductID int32  `json:"product_id"`
+	PartnerID int32  `json:"partner_id"`
+	Title     string `json:"title"`
+	Price     int32  `json:"price"`
+	Active    bool   `json:"active"`
+	Stock     int32  `json:"stock"` // -1 - not tracked
+}
+
+// paymentMethod is an active bank that customers can pay with, its ID is the order paytype.
+type paymentMethod struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Currencies []string `json:"currencies"`
+}
+
+type reportRequest struct {
+	DateFrom string `json:"date_from" validate:"required,dateonly"` // YYYY-MM-DD
+	DateTo   string `json:"date_to" validate:"required,dateonly"`   // YYYY-MM-DD
+}
+
+// discrepancy is a mismatch found by the payment reconciliation.
+type discrepancy struct {
+	OrderID        int64  `json:"order_id"`
+	PaymentID      string `json:"payment_id"`
+	BankID         string `json:"bank_id"`
+	Kind           string `json:"kind"` // overpayment, missing_payment, orphan_payment, amount_mismatch
+	ExpectedAmount int64  `json:"expected_amount"`
+	ActualAmount   int64  `json:"actual_amount"`
+	Details        string `json:"details"`
+	DetectedAt     string `json:"detected_at"`
+}
+
+// address represents a structured delivery or pickup location.
+type address struct {
+	Street       string  `json:"street" validate:"required,min=3,max=100"`
+	City         string  `json:"city" validate:"required,min=2,max=50"`
+	PostalCode   string  `json:"postal_code" validate:"required,postalcode"`
+	Apartment    string  `json:"apartment" validate:"omitempty,max=20"`     // optional: apartment, suite, floor, etc.
+	Instructions string  `json:"instructions" validate:"omitempty,max=256"` // optional: how to find the door, entrance code, etc.
+	Latitude     float64 `json:"latitude" validate:"omitempty,lat"`
+	Longitude    float64 `json:"longitude" validate:"omitempty,lng"`
+}
+
+type promoCodeRequest struct {
+	Code           string  `json:"code" validate:"required,alphanum,max=32"` // case-insensitive
+	Kind           string  `json:"kind" validate:"required,oneof=percent fixed"`
+	Value          int64   `json:"value" validate:"required,gt=0"`                                     // percent: basis points, fixed: minor units of the currency
+	MaxDiscount    int64   `json:"max_discount" validate:"omitempty,gt=0"`                             // caps percent discounts
+	MinOrderAmount int64   `json:"min_order_amount" validate:"omitempty,gt=0"`                         // of the products subtotal
+	PartnerIDs     []int32 `json:"partner_ids" validate:"omitempty,dive,gt=0"`                         // empty - any partner
+	ValidFrom      string  `json:"valid_from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"` // RFC3339, empty - from now on
+	ValidTo        string  `json:"valid_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`   // RFC3339, empty - no end
+	UsageLimit     int32   `json:"usage_limit" validate:"omitempty,gt=0"`                              // empty - unlimited
+	PerUserLimit   int32   `json:"per_user_limit" validate:"omitempty,gt=0"`                           // empty - unlimited
+	Active         bool    `json:"active"`
+}
+
+type promoCodeResponse struct {
+	Code           string  `json:"code"`
+	Kind           string  `json:"kind"`
+	Value          int64   `json:"value"`
+	MaxDiscount    int64   `json:"max_discount"`
+	MinOrderAmount int64   `json:"min_order_amount"`
+	PartnerIDs     []int32 `json:"partner_ids"`
+	ValidFrom      string  `json:"valid_from"`
+	ValidTo        string  `json:"valid_to"`
+	UsageLimit     int32   `json:"usage_limit"`
+	PerUserLimit   int32   `json:"per_user_limit"`
+	Active         bool    `json:"active"`
+}
@@ -24,10 +24,13 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 
 	lifecycle.Append(fx.Hook{
 		OnStart: func(context.Context) error {
-			handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
-				Topic:    "orders.delivering",
-				Callback: handler.delivering,
-			})
+			// the orders service composes the messages, they're only delivered here
+			for _, topic := range []string{"orders.delivering", "orders.refunded", "orders.delivered", "orders.tipped"} {
+				handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
+					Topic:    topic,
+					Callback: handler.notify(topic),
+				})
+			}
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
@@ -39,21 +42,24 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 	})
 }
 
-func (h *handler) delivering(ctx context.Context, span pkg.Span, msg pkg.Message) error {
-	ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.delivering")
-	defer span.End()
-	var req = &Message{}
-	err := json.Unmarshal(msg.Body(), req)
-	if err != nil {
-		err = errors.Wrap(err, "json.Unmarshal")
-		span.RecordError(err)
-		return err
+// notify returns the callback sending the notifications published to the topic.
+func (h *handler) notify(topic string) func(context.Context, pkg.Span, pkg.Message) error {
+	return func(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+		ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.notify."+topic)
+		defer span.End()
+		var req = &Message{}
+		err := json.Unmarshal(msg.Body(), req)
+		if err != nil {
+			err = errors.Wrap(err, "json.Unmarshal")
+			span.RecordError(err)
+			return err
+		}
+		err = h.service.sendNotification(ctx, req)
+		if err != nil {
+			err = errors.Wrap(err, "h.service.sendNotification")
+			span.RecordError(err)
+			return err
+		}
+		return nil
 	}
-	err = h.service.sendNotification(ctx, req)
-	if err != nil {
-		err = errors.Wrap(err, "h.service.sendNotification")
-		span.RecordError(err)
-		return err
-	}
-	return nil
 }
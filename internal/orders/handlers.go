@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shahzodshafizod/gocloud/pkg"
@@ -32,16 +33,45 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 	if err != nil {
 		return errors.Wrap(err, "net.Listen")
 	}
+	interval, err := time.ParseDuration(os.Getenv("RECONCILIATION_INTERVAL"))
+	if err != nil {
+		interval = time.Hour
+	}
+	// pending orders are expired once they stay unpaid for this long
+	expiration, err := time.ParseDuration(os.Getenv("ORDER_EXPIRATION"))
+	if err != nil {
+		expiration = time.Hour
+	}
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
 	lifecycle.Append(fx.Hook{
 		OnStart: func(context.Context) error {
 			handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
 				Topic:    "orders.ready",
 				Callback: handler.pickupOrder,
 			})
+			// each instance passes the events on to the streams it serves, so the broker
+			// must deliver this topic to every instance of the service
+			handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "orders.events",
+				Callback: handler.broadcastEvent,
+			})
+			handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "orders.receipts",
+				Callback: handler.sendReceipt(false),
+			})
+			handler.queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "orders.partner_receipts",
+				Callback: handler.sendReceipt(true),
+			})
+			go handler.repeat(jobsCtx, "handler.reconcile", interval, handler.service.reconcile)
+			go handler.repeat(jobsCtx, "handler.expireOrders", time.Minute, func(ctx context.Context) error {
+				return handler.service.expireOrders(ctx, time.Now().Add(-expiration))
+			})
 			go handler.server.Serve(lis)
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			stopJobs()
 			tracer.Shutdown(ctx)
 			queue.Close()
 			postgres.Close(ctx)
@@ -88,6 +118,289 @@ func (h *handler) AssignOrder(ctx context.Context, req *AssignRequest) (*AssignR
 	return resp, nil
 }
 
+func (h *handler) UpdateOrderAddress(ctx context.Context, req *UpdateAddressRequest) (*UpdateAddressResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.UpdateOrderAddress")
+	defer span.End()
+	resp, err := h.service.updateOrderAddress(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updateOrderAddress")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetBank(ctx context.Context, req *GetBankRequest) (*Bank, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetBank")
+	defer span.End()
+	resp, err := h.service.getBank(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getBank")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListBanks(ctx context.Context, req *ListBanksRequest) (*ListBanksResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListBanks")
+	defer span.End()
+	resp, err := h.service.listBanks(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listBanks")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreateBank(ctx context.Context, req *Bank) (*Bank, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreateBank")
+	defer span.End()
+	resp, err := h.service.createBank(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createBank")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) UpdateBank(ctx context.Context, req *Bank) (*Bank, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.UpdateBank")
+	defer span.End()
+	resp, err := h.service.updateBank(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updateBank")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetBankActive(ctx context.Context, req *SetBankActiveRequest) (*Bank, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetBankActive")
+	defer span.End()
+	resp, err := h.service.setBankActive(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setBankActive")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ReconciliationReport(ctx context.Context, req *ReportRequest) (*ReportResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ReconciliationReport")
+	defer span.End()
+	resp, err := h.service.reconciliationReport(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.reconciliationReport")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) RefundOrder(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.RefundOrder")
+	defer span.End()
+	resp, err := h.service.refundOrder(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.refundOrder")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CancelOrder(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CancelOrder")
+	defer span.End()
+	resp, err := h.service.cancelOrder(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.cancelOrder")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CheckPromoCode(ctx context.Context, req *PromoRequest) (*Pricing, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CheckPromoCode")
+	defer span.End()
+	resp, err := h.service.checkPromoCode(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.checkPromoCode")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreatePromoCode(ctx context.Context, req *PromoCode) (*PromoCode, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreatePromoCode")
+	defer span.End()
+	resp, err := h.service.createPromoCode(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createPromoCode")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CompleteOrder(ctx context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CompleteOrder")
+	defer span.End()
+	resp, err := h.service.completeOrder(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.completeOrder")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) AddTip(ctx context.Context, req *TipRequest) (*CreateResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.AddTip")
+	defer span.End()
+	resp, err := h.service.addTip(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.addTip")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) PayTip(ctx context.Context, req *PayRequest) (*PayResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.PayTip")
+	defer span.End()
+	resp, err := h.service.payTip(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.payTip")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetEarnings(ctx context.Context, req *EarningsRequest) (*EarningsResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetEarnings")
+	defer span.End()
+	resp, err := h.service.getEarnings(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getEarnings")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreateReview(ctx context.Context, req *Review) (*Review, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreateReview")
+	defer span.End()
+	resp, err := h.service.createReview(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createReview")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListReviews")
+	defer span.End()
+	resp, err := h.service.listReviews(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listReviews")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetReviewHidden(ctx context.Context, req *SetReviewHiddenRequest) (*Review, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetReviewHidden")
+	defer span.End()
+	resp, err := h.service.setReviewHidden(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setReviewHidden")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreateCart(ctx context.Context, req *Cart) (*CartResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreateCart")
+	defer span.End()
+	resp, err := h.service.createCart(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createCart")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) PayCart(ctx context.Context, req *PayRequest) (*PayResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.PayCart")
+	defer span.End()
+	resp, err := h.service.payCart(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.payCart")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) WatchOrder(req *WatchRequest, stream Orders_WatchOrderServer) error {
+	ctx, span := h.tracer.StartFromContext(stream.Context(), "handler.WatchOrder")
+	defer span.End()
+	err := h.service.watchOrder(ctx, req, stream.Send)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.watchOrder")
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) TrackLocation(ctx context.Context, req *LocationPing) (*LocationResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.TrackLocation")
+	defer span.End()
+	resp, err := h.service.trackLocation(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.trackLocation")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// repeat runs the job every `interval` until ctx is cancelled.
+func (h *handler) repeat(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			spanCtx, span := h.tracer.StartFromContext(ctx, name)
+			err := job(spanCtx)
+			if err != nil {
+				span.RecordError(errors.Wrap(err, name))
+			}
+			span.End()
+		}
+	}
+}
+
 func (h *handler) pickupOrder(ctx context.Context, span pkg.Span, msg pkg.Message) error {
 	ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.pickupOrder")
 	defer span.End()
@@ -107,4 +420,64 @@ func (h *handler) pickupOrder(ctx context.Context, span pkg.Span, msg pkg.Messag
 	return nil
 }
 
+// sendReceipt returns the callback emailing the receipts to the customers, or their copies to the partners.
+func (h *handler) sendReceipt(partnerCopy bool) func(context.Context, pkg.Span, pkg.Message) error {
+	return func(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+		ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.sendReceipt")
+		defer span.End()
+		var req = &ReceiptRequest{}
+		err := json.Unmarshal(msg.Body(), req)
+		if err != nil {
+			err = errors.Wrap(err, "json.Unmarshal")
+			span.RecordError(err)
+			return err
+		}
+		err = h.service.sendReceipt(ctx, req.OrderID, partnerCopy)
+		if err != nil {
+			err = errors.Wrap(err, "h.service.sendReceipt")
+			span.RecordError(err)
+			return err
+		}
+		return nil
+	}
+}
+
+func (h *handler) GetReceipt(ctx context.Context, req *ReceiptRequest) (*ReceiptResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetReceipt")
+	defer span.End()
+	resp, err := h.service.getReceipt(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getReceipt")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetOrder(ctx context.Context, req *GetOrderRequest) (*Order, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetOrder")
+	defer span.End()
+	order, err := h.service.getOrder(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getOrder")
+		span.RecordError(err)
+		return nil, err
+	}
+	return order, nil
+}
+
+func (h *handler) broadcastEvent(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+	_, span = h.tracer.StartFromSpan(ctx, span, "handler.broadcastEvent")
+	defer span.End()
+	var event = &OrderEvent{}
+	err := json.Unmarshal(msg.Body(), event)
+	if err != nil {
+		err = errors.Wrap(err, "json.Unmarshal")
+		span.RecordError(err)
+		return err
+	}
+	h.service.broadcastEvent(event)
+	return nil
+}
+
 func (h *handler) mustEmbedUnimplementedOrdersServer() {}
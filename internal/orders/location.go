@@ -0,0 +1,28 @@
+package orders
+
+import "math"
+
+const earthRadiusKm = 6371
+
+// DistanceKm is the great-circle (haversine) distance between two points,
+// 0 when any of them has no coordinates.
+func DistanceKm(fromLat, fromLng, toLat, toLng float64) float64 {
+	if (fromLat == 0 && fromLng == 0) || (toLat == 0 && toLng == 0) {
+		return 0
+	}
+	rad := math.Pi / 180
+	dLat := (toLat - fromLat) * rad
+	dLng := (toLng - fromLng) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(fromLat*rad)*math.Cos(toLat*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// etaMinutes estimates the minutes to ride `distanceKm` at `speedKmh`,
+// at least a minute until the deliverer is at the door, 0 - unknown.
+func etaMinutes(distanceKm float64, speedKmh float64) int32 {
+	if distanceKm <= 0 || speedKmh <= 0 {
+		return 0
+	}
+	return int32(math.Max(1, math.Ceil(distanceKm/speedKmh*60)))
+}
@@ -16,6 +16,7 @@ import (
 	orders "github.com/shahzodshafizod/gocloud/internal/orders"
 	gomock "go.uber.org/mock/gomock"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockOrdersClient is a mock of OrdersClient interface.
@@ -42,6 +43,26 @@ func (m *MockOrdersClient) EXPECT() *MockOrdersClientMockRecorder {
 	return m.recorder
 }
 
+// AddTip mocks base method.
+func (m *MockOrdersClient) AddTip(ctx context.Context, in *orders.TipRequest, opts ...grpc.CallOption) (*orders.CreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddTip", varargs...)
+	ret0, _ := ret[0].(*orders.CreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTip indicates an expected call of AddTip.
+func (mr *MockOrdersClientMockRecorder) AddTip(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTip", reflect.TypeOf((*MockOrdersClient)(nil).AddTip), varargs...)
+}
+
 // AssignOrder mocks base method.
 func (m *MockOrdersClient) AssignOrder(ctx context.Context, in *orders.AssignRequest, opts ...grpc.CallOption) (*orders.AssignResponse, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +83,106 @@ func (mr *MockOrdersClientMockRecorder) AssignOrder(ctx, in any, opts ...any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrder", reflect.TypeOf((*MockOrdersClient)(nil).AssignOrder), varargs...)
 }
 
+// CancelOrder mocks base method.
+func (m *MockOrdersClient) CancelOrder(ctx context.Context, in *orders.CancelRequest, opts ...grpc.CallOption) (*orders.CancelResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelOrder", varargs...)
+	ret0, _ := ret[0].(*orders.CancelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrdersClientMockRecorder) CancelOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrdersClient)(nil).CancelOrder), varargs...)
+}
+
+// CheckPromoCode mocks base method.
+func (m *MockOrdersClient) CheckPromoCode(ctx context.Context, in *orders.PromoRequest, opts ...grpc.CallOption) (*orders.Pricing, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CheckPromoCode", varargs...)
+	ret0, _ := ret[0].(*orders.Pricing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPromoCode indicates an expected call of CheckPromoCode.
+func (mr *MockOrdersClientMockRecorder) CheckPromoCode(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPromoCode", reflect.TypeOf((*MockOrdersClient)(nil).CheckPromoCode), varargs...)
+}
+
+// CompleteOrder mocks base method.
+func (m *MockOrdersClient) CompleteOrder(ctx context.Context, in *orders.CompleteRequest, opts ...grpc.CallOption) (*orders.CompleteResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompleteOrder", varargs...)
+	ret0, _ := ret[0].(*orders.CompleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrder indicates an expected call of CompleteOrder.
+func (mr *MockOrdersClientMockRecorder) CompleteOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrder", reflect.TypeOf((*MockOrdersClient)(nil).CompleteOrder), varargs...)
+}
+
+// CreateBank mocks base method.
+func (m *MockOrdersClient) CreateBank(ctx context.Context, in *orders.Bank, opts ...grpc.CallOption) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateBank", varargs...)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBank indicates an expected call of CreateBank.
+func (mr *MockOrdersClientMockRecorder) CreateBank(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBank", reflect.TypeOf((*MockOrdersClient)(nil).CreateBank), varargs...)
+}
+
+// CreateCart mocks base method.
+func (m *MockOrdersClient) CreateCart(ctx context.Context, in *orders.Cart, opts ...grpc.CallOption) (*orders.CartResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCart", varargs...)
+	ret0, _ := ret[0].(*orders.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCart indicates an expected call of CreateCart.
+func (mr *MockOrdersClientMockRecorder) CreateCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCart", reflect.TypeOf((*MockOrdersClient)(nil).CreateCart), varargs...)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrdersClient) CreateOrder(ctx context.Context, in *orders.Order, opts ...grpc.CallOption) (*orders.CreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +203,186 @@ func (mr *MockOrdersClientMockRecorder) CreateOrder(ctx, in any, opts ...any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrdersClient)(nil).CreateOrder), varargs...)
 }
 
+// CreatePromoCode mocks base method.
+func (m *MockOrdersClient) CreatePromoCode(ctx context.Context, in *orders.PromoCode, opts ...grpc.CallOption) (*orders.PromoCode, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePromoCode", varargs...)
+	ret0, _ := ret[0].(*orders.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockOrdersClientMockRecorder) CreatePromoCode(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockOrdersClient)(nil).CreatePromoCode), varargs...)
+}
+
+// CreateReview mocks base method.
+func (m *MockOrdersClient) CreateReview(ctx context.Context, in *orders.Review, opts ...grpc.CallOption) (*orders.Review, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateReview", varargs...)
+	ret0, _ := ret[0].(*orders.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockOrdersClientMockRecorder) CreateReview(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockOrdersClient)(nil).CreateReview), varargs...)
+}
+
+// GetBank mocks base method.
+func (m *MockOrdersClient) GetBank(ctx context.Context, in *orders.GetBankRequest, opts ...grpc.CallOption) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBank", varargs...)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBank indicates an expected call of GetBank.
+func (mr *MockOrdersClientMockRecorder) GetBank(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBank", reflect.TypeOf((*MockOrdersClient)(nil).GetBank), varargs...)
+}
+
+// GetEarnings mocks base method.
+func (m *MockOrdersClient) GetEarnings(ctx context.Context, in *orders.EarningsRequest, opts ...grpc.CallOption) (*orders.EarningsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetEarnings", varargs...)
+	ret0, _ := ret[0].(*orders.EarningsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarnings indicates an expected call of GetEarnings.
+func (mr *MockOrdersClientMockRecorder) GetEarnings(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarnings", reflect.TypeOf((*MockOrdersClient)(nil).GetEarnings), varargs...)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrdersClient) GetOrder(ctx context.Context, in *orders.GetOrderRequest, opts ...grpc.CallOption) (*orders.Order, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOrder", varargs...)
+	ret0, _ := ret[0].(*orders.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrdersClientMockRecorder) GetOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrdersClient)(nil).GetOrder), varargs...)
+}
+
+// GetReceipt mocks base method.
+func (m *MockOrdersClient) GetReceipt(ctx context.Context, in *orders.ReceiptRequest, opts ...grpc.CallOption) (*orders.ReceiptResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReceipt", varargs...)
+	ret0, _ := ret[0].(*orders.ReceiptResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockOrdersClientMockRecorder) GetReceipt(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockOrdersClient)(nil).GetReceipt), varargs...)
+}
+
+// ListBanks mocks base method.
+func (m *MockOrdersClient) ListBanks(ctx context.Context, in *orders.ListBanksRequest, opts ...grpc.CallOption) (*orders.ListBanksResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListBanks", varargs...)
+	ret0, _ := ret[0].(*orders.ListBanksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBanks indicates an expected call of ListBanks.
+func (mr *MockOrdersClientMockRecorder) ListBanks(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBanks", reflect.TypeOf((*MockOrdersClient)(nil).ListBanks), varargs...)
+}
+
+// ListReviews mocks base method.
+func (m *MockOrdersClient) ListReviews(ctx context.Context, in *orders.ListReviewsRequest, opts ...grpc.CallOption) (*orders.ListReviewsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListReviews", varargs...)
+	ret0, _ := ret[0].(*orders.ListReviewsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockOrdersClientMockRecorder) ListReviews(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockOrdersClient)(nil).ListReviews), varargs...)
+}
+
+// PayCart mocks base method.
+func (m *MockOrdersClient) PayCart(ctx context.Context, in *orders.PayRequest, opts ...grpc.CallOption) (*orders.PayResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PayCart", varargs...)
+	ret0, _ := ret[0].(*orders.PayResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayCart indicates an expected call of PayCart.
+func (mr *MockOrdersClientMockRecorder) PayCart(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayCart", reflect.TypeOf((*MockOrdersClient)(nil).PayCart), varargs...)
+}
+
 // PayOrder mocks base method.
 func (m *MockOrdersClient) PayOrder(ctx context.Context, in *orders.PayRequest, opts ...grpc.CallOption) (*orders.PayResponse, error) {
 	m.ctrl.T.Helper()
@@ -95,11 +396,315 @@ func (m *MockOrdersClient) PayOrder(ctx context.Context, in *orders.PayRequest,
 	return ret0, ret1
 }
 
-// PayOrder indicates an expected call of PayOrder.
-func (mr *MockOrdersClientMockRecorder) PayOrder(ctx, in any, opts ...any) *gomock.Call {
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockOrdersClientMockRecorder) PayOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrdersClient)(nil).PayOrder), varargs...)
+}
+
+// PayTip mocks base method.
+func (m *MockOrdersClient) PayTip(ctx context.Context, in *orders.PayRequest, opts ...grpc.CallOption) (*orders.PayResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PayTip", varargs...)
+	ret0, _ := ret[0].(*orders.PayResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayTip indicates an expected call of PayTip.
+func (mr *MockOrdersClientMockRecorder) PayTip(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayTip", reflect.TypeOf((*MockOrdersClient)(nil).PayTip), varargs...)
+}
+
+// ReconciliationReport mocks base method.
+func (m *MockOrdersClient) ReconciliationReport(ctx context.Context, in *orders.ReportRequest, opts ...grpc.CallOption) (*orders.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReconciliationReport", varargs...)
+	ret0, _ := ret[0].(*orders.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconciliationReport indicates an expected call of ReconciliationReport.
+func (mr *MockOrdersClientMockRecorder) ReconciliationReport(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconciliationReport", reflect.TypeOf((*MockOrdersClient)(nil).ReconciliationReport), varargs...)
+}
+
+// RefundOrder mocks base method.
+func (m *MockOrdersClient) RefundOrder(ctx context.Context, in *orders.RefundRequest, opts ...grpc.CallOption) (*orders.RefundResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RefundOrder", varargs...)
+	ret0, _ := ret[0].(*orders.RefundResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockOrdersClientMockRecorder) RefundOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockOrdersClient)(nil).RefundOrder), varargs...)
+}
+
+// SetBankActive mocks base method.
+func (m *MockOrdersClient) SetBankActive(ctx context.Context, in *orders.SetBankActiveRequest, opts ...grpc.CallOption) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetBankActive", varargs...)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBankActive indicates an expected call of SetBankActive.
+func (mr *MockOrdersClientMockRecorder) SetBankActive(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBankActive", reflect.TypeOf((*MockOrdersClient)(nil).SetBankActive), varargs...)
+}
+
+// SetReviewHidden mocks base method.
+func (m *MockOrdersClient) SetReviewHidden(ctx context.Context, in *orders.SetReviewHiddenRequest, opts ...grpc.CallOption) (*orders.Review, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetReviewHidden", varargs...)
+	ret0, _ := ret[0].(*orders.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewHidden indicates an expected call of SetReviewHidden.
+func (mr *MockOrdersClientMockRecorder) SetReviewHidden(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewHidden", reflect.TypeOf((*MockOrdersClient)(nil).SetReviewHidden), varargs...)
+}
+
+// TrackLocation mocks base method.
+func (m *MockOrdersClient) TrackLocation(ctx context.Context, in *orders.LocationPing, opts ...grpc.CallOption) (*orders.LocationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TrackLocation", varargs...)
+	ret0, _ := ret[0].(*orders.LocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackLocation indicates an expected call of TrackLocation.
+func (mr *MockOrdersClientMockRecorder) TrackLocation(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackLocation", reflect.TypeOf((*MockOrdersClient)(nil).TrackLocation), varargs...)
+}
+
+// UpdateBank mocks base method.
+func (m *MockOrdersClient) UpdateBank(ctx context.Context, in *orders.Bank, opts ...grpc.CallOption) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateBank", varargs...)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBank indicates an expected call of UpdateBank.
+func (mr *MockOrdersClientMockRecorder) UpdateBank(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBank", reflect.TypeOf((*MockOrdersClient)(nil).UpdateBank), varargs...)
+}
+
+// UpdateOrderAddress mocks base method.
+func (m *MockOrdersClient) UpdateOrderAddress(ctx context.Context, in *orders.UpdateAddressRequest, opts ...grpc.CallOption) (*orders.UpdateAddressResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateOrderAddress", varargs...)
+	ret0, _ := ret[0].(*orders.UpdateAddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderAddress indicates an expected call of UpdateOrderAddress.
+func (mr *MockOrdersClientMockRecorder) UpdateOrderAddress(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderAddress", reflect.TypeOf((*MockOrdersClient)(nil).UpdateOrderAddress), varargs...)
+}
+
+// WatchOrder mocks base method.
+func (m *MockOrdersClient) WatchOrder(ctx context.Context, in *orders.WatchRequest, opts ...grpc.CallOption) (orders.Orders_WatchOrderClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchOrder", varargs...)
+	ret0, _ := ret[0].(orders.Orders_WatchOrderClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchOrder indicates an expected call of WatchOrder.
+func (mr *MockOrdersClientMockRecorder) WatchOrder(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOrder", reflect.TypeOf((*MockOrdersClient)(nil).WatchOrder), varargs...)
+}
+
+// MockOrders_WatchOrderClient is a mock of Orders_WatchOrderClient interface.
+type MockOrders_WatchOrderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrders_WatchOrderClientMockRecorder
+	isgomock struct{}
+}
+
+// MockOrders_WatchOrderClientMockRecorder is the mock recorder for MockOrders_WatchOrderClient.
+type MockOrders_WatchOrderClientMockRecorder struct {
+	mock *MockOrders_WatchOrderClient
+}
+
+// NewMockOrders_WatchOrderClient creates a new mock instance.
+func NewMockOrders_WatchOrderClient(ctrl *gomock.Controller) *MockOrders_WatchOrderClient {
+	mock := &MockOrders_WatchOrderClient{ctrl: ctrl}
+	mock.recorder = &MockOrders_WatchOrderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrders_WatchOrderClient) EXPECT() *MockOrders_WatchOrderClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockOrders_WatchOrderClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockOrders_WatchOrderClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockOrders_WatchOrderClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockOrders_WatchOrderClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockOrders_WatchOrderClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockOrders_WatchOrderClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockOrders_WatchOrderClient) Recv() (*orders.OrderEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*orders.OrderEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockOrders_WatchOrderClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockOrders_WatchOrderClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockOrders_WatchOrderClientMockRecorder) RecvMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockOrders_WatchOrderClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockOrders_WatchOrderClientMockRecorder) SendMsg(m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrdersClient)(nil).PayOrder), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockOrders_WatchOrderClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockOrders_WatchOrderClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockOrders_WatchOrderClient)(nil).Trailer))
 }
 
 // MockOrdersServer is a mock of OrdersServer interface.
@@ -126,6 +731,21 @@ func (m *MockOrdersServer) EXPECT() *MockOrdersServerMockRecorder {
 	return m.recorder
 }
 
+// AddTip mocks base method.
+func (m *MockOrdersServer) AddTip(arg0 context.Context, arg1 *orders.TipRequest) (*orders.CreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTip", arg0, arg1)
+	ret0, _ := ret[0].(*orders.CreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTip indicates an expected call of AddTip.
+func (mr *MockOrdersServerMockRecorder) AddTip(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTip", reflect.TypeOf((*MockOrdersServer)(nil).AddTip), arg0, arg1)
+}
+
 // AssignOrder mocks base method.
 func (m *MockOrdersServer) AssignOrder(arg0 context.Context, arg1 *orders.AssignRequest) (*orders.AssignResponse, error) {
 	m.ctrl.T.Helper()
@@ -141,6 +761,81 @@ func (mr *MockOrdersServerMockRecorder) AssignOrder(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignOrder", reflect.TypeOf((*MockOrdersServer)(nil).AssignOrder), arg0, arg1)
 }
 
+// CancelOrder mocks base method.
+func (m *MockOrdersServer) CancelOrder(arg0 context.Context, arg1 *orders.CancelRequest) (*orders.CancelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", arg0, arg1)
+	ret0, _ := ret[0].(*orders.CancelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrdersServerMockRecorder) CancelOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrdersServer)(nil).CancelOrder), arg0, arg1)
+}
+
+// CheckPromoCode mocks base method.
+func (m *MockOrdersServer) CheckPromoCode(arg0 context.Context, arg1 *orders.PromoRequest) (*orders.Pricing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPromoCode", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Pricing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckPromoCode indicates an expected call of CheckPromoCode.
+func (mr *MockOrdersServerMockRecorder) CheckPromoCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPromoCode", reflect.TypeOf((*MockOrdersServer)(nil).CheckPromoCode), arg0, arg1)
+}
+
+// CompleteOrder mocks base method.
+func (m *MockOrdersServer) CompleteOrder(arg0 context.Context, arg1 *orders.CompleteRequest) (*orders.CompleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOrder", arg0, arg1)
+	ret0, _ := ret[0].(*orders.CompleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrder indicates an expected call of CompleteOrder.
+func (mr *MockOrdersServerMockRecorder) CompleteOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrder", reflect.TypeOf((*MockOrdersServer)(nil).CompleteOrder), arg0, arg1)
+}
+
+// CreateBank mocks base method.
+func (m *MockOrdersServer) CreateBank(arg0 context.Context, arg1 *orders.Bank) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBank", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBank indicates an expected call of CreateBank.
+func (mr *MockOrdersServerMockRecorder) CreateBank(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBank", reflect.TypeOf((*MockOrdersServer)(nil).CreateBank), arg0, arg1)
+}
+
+// CreateCart mocks base method.
+func (m *MockOrdersServer) CreateCart(arg0 context.Context, arg1 *orders.Cart) (*orders.CartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCart", arg0, arg1)
+	ret0, _ := ret[0].(*orders.CartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCart indicates an expected call of CreateCart.
+func (mr *MockOrdersServerMockRecorder) CreateCart(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCart", reflect.TypeOf((*MockOrdersServer)(nil).CreateCart), arg0, arg1)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrdersServer) CreateOrder(arg0 context.Context, arg1 *orders.Order) (*orders.CreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +851,141 @@ func (mr *MockOrdersServerMockRecorder) CreateOrder(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrdersServer)(nil).CreateOrder), arg0, arg1)
 }
 
+// CreatePromoCode mocks base method.
+func (m *MockOrdersServer) CreatePromoCode(arg0 context.Context, arg1 *orders.PromoCode) (*orders.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromoCode", arg0, arg1)
+	ret0, _ := ret[0].(*orders.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockOrdersServerMockRecorder) CreatePromoCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockOrdersServer)(nil).CreatePromoCode), arg0, arg1)
+}
+
+// CreateReview mocks base method.
+func (m *MockOrdersServer) CreateReview(arg0 context.Context, arg1 *orders.Review) (*orders.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockOrdersServerMockRecorder) CreateReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockOrdersServer)(nil).CreateReview), arg0, arg1)
+}
+
+// GetBank mocks base method.
+func (m *MockOrdersServer) GetBank(arg0 context.Context, arg1 *orders.GetBankRequest) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBank", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBank indicates an expected call of GetBank.
+func (mr *MockOrdersServerMockRecorder) GetBank(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBank", reflect.TypeOf((*MockOrdersServer)(nil).GetBank), arg0, arg1)
+}
+
+// GetEarnings mocks base method.
+func (m *MockOrdersServer) GetEarnings(arg0 context.Context, arg1 *orders.EarningsRequest) (*orders.EarningsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarnings", arg0, arg1)
+	ret0, _ := ret[0].(*orders.EarningsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarnings indicates an expected call of GetEarnings.
+func (mr *MockOrdersServerMockRecorder) GetEarnings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarnings", reflect.TypeOf((*MockOrdersServer)(nil).GetEarnings), arg0, arg1)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrdersServer) GetOrder(arg0 context.Context, arg1 *orders.GetOrderRequest) (*orders.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrdersServerMockRecorder) GetOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrdersServer)(nil).GetOrder), arg0, arg1)
+}
+
+// GetReceipt mocks base method.
+func (m *MockOrdersServer) GetReceipt(arg0 context.Context, arg1 *orders.ReceiptRequest) (*orders.ReceiptResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipt", arg0, arg1)
+	ret0, _ := ret[0].(*orders.ReceiptResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockOrdersServerMockRecorder) GetReceipt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockOrdersServer)(nil).GetReceipt), arg0, arg1)
+}
+
+// ListBanks mocks base method.
+func (m *MockOrdersServer) ListBanks(arg0 context.Context, arg1 *orders.ListBanksRequest) (*orders.ListBanksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBanks", arg0, arg1)
+	ret0, _ := ret[0].(*orders.ListBanksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBanks indicates an expected call of ListBanks.
+func (mr *MockOrdersServerMockRecorder) ListBanks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBanks", reflect.TypeOf((*MockOrdersServer)(nil).ListBanks), arg0, arg1)
+}
+
+// ListReviews mocks base method.
+func (m *MockOrdersServer) ListReviews(arg0 context.Context, arg1 *orders.ListReviewsRequest) (*orders.ListReviewsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", arg0, arg1)
+	ret0, _ := ret[0].(*orders.ListReviewsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockOrdersServerMockRecorder) ListReviews(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockOrdersServer)(nil).ListReviews), arg0, arg1)
+}
+
+// PayCart mocks base method.
+func (m *MockOrdersServer) PayCart(arg0 context.Context, arg1 *orders.PayRequest) (*orders.PayResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayCart", arg0, arg1)
+	ret0, _ := ret[0].(*orders.PayResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayCart indicates an expected call of PayCart.
+func (mr *MockOrdersServerMockRecorder) PayCart(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayCart", reflect.TypeOf((*MockOrdersServer)(nil).PayCart), arg0, arg1)
+}
+
 // PayOrder mocks base method.
 func (m *MockOrdersServer) PayOrder(arg0 context.Context, arg1 *orders.PayRequest) (*orders.PayResponse, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +1001,140 @@ func (mr *MockOrdersServerMockRecorder) PayOrder(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrdersServer)(nil).PayOrder), arg0, arg1)
 }
 
+// PayTip mocks base method.
+func (m *MockOrdersServer) PayTip(arg0 context.Context, arg1 *orders.PayRequest) (*orders.PayResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayTip", arg0, arg1)
+	ret0, _ := ret[0].(*orders.PayResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayTip indicates an expected call of PayTip.
+func (mr *MockOrdersServerMockRecorder) PayTip(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayTip", reflect.TypeOf((*MockOrdersServer)(nil).PayTip), arg0, arg1)
+}
+
+// ReconciliationReport mocks base method.
+func (m *MockOrdersServer) ReconciliationReport(arg0 context.Context, arg1 *orders.ReportRequest) (*orders.ReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconciliationReport", arg0, arg1)
+	ret0, _ := ret[0].(*orders.ReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconciliationReport indicates an expected call of ReconciliationReport.
+func (mr *MockOrdersServerMockRecorder) ReconciliationReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconciliationReport", reflect.TypeOf((*MockOrdersServer)(nil).ReconciliationReport), arg0, arg1)
+}
+
+// RefundOrder mocks base method.
+func (m *MockOrdersServer) RefundOrder(arg0 context.Context, arg1 *orders.RefundRequest) (*orders.RefundResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", arg0, arg1)
+	ret0, _ := ret[0].(*orders.RefundResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockOrdersServerMockRecorder) RefundOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockOrdersServer)(nil).RefundOrder), arg0, arg1)
+}
+
+// SetBankActive mocks base method.
+func (m *MockOrdersServer) SetBankActive(arg0 context.Context, arg1 *orders.SetBankActiveRequest) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBankActive", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBankActive indicates an expected call of SetBankActive.
+func (mr *MockOrdersServerMockRecorder) SetBankActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBankActive", reflect.TypeOf((*MockOrdersServer)(nil).SetBankActive), arg0, arg1)
+}
+
+// SetReviewHidden mocks base method.
+func (m *MockOrdersServer) SetReviewHidden(arg0 context.Context, arg1 *orders.SetReviewHiddenRequest) (*orders.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewHidden", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewHidden indicates an expected call of SetReviewHidden.
+func (mr *MockOrdersServerMockRecorder) SetReviewHidden(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewHidden", reflect.TypeOf((*MockOrdersServer)(nil).SetReviewHidden), arg0, arg1)
+}
+
+// TrackLocation mocks base method.
+func (m *MockOrdersServer) TrackLocation(arg0 context.Context, arg1 *orders.LocationPing) (*orders.LocationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackLocation", arg0, arg1)
+	ret0, _ := ret[0].(*orders.LocationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackLocation indicates an expected call of TrackLocation.
+func (mr *MockOrdersServerMockRecorder) TrackLocation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackLocation", reflect.TypeOf((*MockOrdersServer)(nil).TrackLocation), arg0, arg1)
+}
+
+// UpdateBank mocks base method.
+func (m *MockOrdersServer) UpdateBank(arg0 context.Context, arg1 *orders.Bank) (*orders.Bank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBank", arg0, arg1)
+	ret0, _ := ret[0].(*orders.Bank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBank indicates an expected call of UpdateBank.
+func (mr *MockOrdersServerMockRecorder) UpdateBank(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBank", reflect.TypeOf((*MockOrdersServer)(nil).UpdateBank), arg0, arg1)
+}
+
+// UpdateOrderAddress mocks base method.
+func (m *MockOrdersServer) UpdateOrderAddress(arg0 context.Context, arg1 *orders.UpdateAddressRequest) (*orders.UpdateAddressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderAddress", arg0, arg1)
+	ret0, _ := ret[0].(*orders.UpdateAddressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderAddress indicates an expected call of UpdateOrderAddress.
+func (mr *MockOrdersServerMockRecorder) UpdateOrderAddress(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderAddress", reflect.TypeOf((*MockOrdersServer)(nil).UpdateOrderAddress), arg0, arg1)
+}
+
+// WatchOrder mocks base method.
+func (m *MockOrdersServer) WatchOrder(arg0 *orders.WatchRequest, arg1 orders.Orders_WatchOrderServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchOrder indicates an expected call of WatchOrder.
+func (mr *MockOrdersServerMockRecorder) WatchOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchOrder", reflect.TypeOf((*MockOrdersServer)(nil).WatchOrder), arg0, arg1)
+}
+
 // mustEmbedUnimplementedOrdersServer mocks base method.
 func (m *MockOrdersServer) mustEmbedUnimplementedOrdersServer() {
 	m.ctrl.T.Helper()
@@ -218,3 +1182,123 @@ func (mr *MockUnsafeOrdersServerMockRecorder) mustEmbedUnimplementedOrdersServer
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedOrdersServer", reflect.TypeOf((*MockUnsafeOrdersServer)(nil).mustEmbedUnimplementedOrdersServer))
 }
+
+// MockOrders_WatchOrderServer is a mock of Orders_WatchOrderServer interface.
+type MockOrders_WatchOrderServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrders_WatchOrderServerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrders_WatchOrderServerMockRecorder is the mock recorder for MockOrders_WatchOrderServer.
+type MockOrders_WatchOrderServerMockRecorder struct {
+	mock *MockOrders_WatchOrderServer
+}
+
+// NewMockOrders_WatchOrderServer creates a new mock instance.
+func NewMockOrders_WatchOrderServer(ctrl *gomock.Controller) *MockOrders_WatchOrderServer {
+	mock := &MockOrders_WatchOrderServer{ctrl: ctrl}
+	mock.recorder = &MockOrders_WatchOrderServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrders_WatchOrderServer) EXPECT() *MockOrders_WatchOrderServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockOrders_WatchOrderServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockOrders_WatchOrderServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockOrders_WatchOrderServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockOrders_WatchOrderServerMockRecorder) RecvMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockOrders_WatchOrderServer) Send(arg0 *orders.OrderEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockOrders_WatchOrderServerMockRecorder) Send(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockOrders_WatchOrderServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockOrders_WatchOrderServerMockRecorder) SendHeader(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockOrders_WatchOrderServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockOrders_WatchOrderServerMockRecorder) SendMsg(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockOrders_WatchOrderServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockOrders_WatchOrderServerMockRecorder) SetHeader(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockOrders_WatchOrderServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockOrders_WatchOrderServerMockRecorder) SetTrailer(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockOrders_WatchOrderServer)(nil).SetTrailer), arg0)
+}
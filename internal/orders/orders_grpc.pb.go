@@ -25,6 +25,30 @@ type OrdersClient interface {
 	CreateOrder(ctx context.Context, in *Order, opts ...grpc.CallOption) (*CreateResponse, error)
 	PayOrder(ctx context.Context, in *PayRequest, opts ...grpc.CallOption) (*PayResponse, error)
 	AssignOrder(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
+	UpdateOrderAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*UpdateAddressResponse, error)
+	GetBank(ctx context.Context, in *GetBankRequest, opts ...grpc.CallOption) (*Bank, error)
+	ListBanks(ctx context.Context, in *ListBanksRequest, opts ...grpc.CallOption) (*ListBanksResponse, error)
+	CreateBank(ctx context.Context, in *Bank, opts ...grpc.CallOption) (*Bank, error)
+	UpdateBank(ctx context.Context, in *Bank, opts ...grpc.CallOption) (*Bank, error)
+	SetBankActive(ctx context.Context, in *SetBankActiveRequest, opts ...grpc.CallOption) (*Bank, error)
+	ReconciliationReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error)
+	RefundOrder(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+	CancelOrder(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	CheckPromoCode(ctx context.Context, in *PromoRequest, opts ...grpc.CallOption) (*Pricing, error)
+	CreatePromoCode(ctx context.Context, in *PromoCode, opts ...grpc.CallOption) (*PromoCode, error)
+	CompleteOrder(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error)
+	AddTip(ctx context.Context, in *TipRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	PayTip(ctx context.Context, in *PayRequest, opts ...grpc.CallOption) (*PayResponse, error)
+	GetEarnings(ctx context.Context, in *EarningsRequest, opts ...grpc.CallOption) (*EarningsResponse, error)
+	CreateReview(ctx context.Context, in *Review, opts ...grpc.CallOption) (*Review, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	SetReviewHidden(ctx context.Context, in *SetReviewHiddenRequest, opts ...grpc.CallOption) (*Review, error)
+	CreateCart(ctx context.Context, in *Cart, opts ...grpc.CallOption) (*CartResponse, error)
+	PayCart(ctx context.Context, in *PayRequest, opts ...grpc.CallOption) (*PayResponse, error)
+	WatchOrder(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Orders_WatchOrderClient, error)
+	TrackLocation(ctx context.Context, in *LocationPing, opts ...grpc.CallOption) (*LocationResponse, error)
+	GetReceipt(ctx context.Context, in *ReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
 }
 
 type ordersClient struct {
@@ -62,6 +86,245 @@ func (c *ordersClient) AssignOrder(ctx context.Context, in *AssignRequest, opts
 	return out, nil
 }
 
+func (c *ordersClient) UpdateOrderAddress(ctx context.Context, in *UpdateAddressRequest, opts ...grpc.CallOption) (*UpdateAddressResponse, error) {
+	out := new(UpdateAddressResponse)
+	err := c.cc.Invoke(ctx, "/Orders/UpdateOrderAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetBank(ctx context.Context, in *GetBankRequest, opts ...grpc.CallOption) (*Bank, error) {
+	out := new(Bank)
+	err := c.cc.Invoke(ctx, "/Orders/GetBank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) ListBanks(ctx context.Context, in *ListBanksRequest, opts ...grpc.CallOption) (*ListBanksResponse, error) {
+	out := new(ListBanksResponse)
+	err := c.cc.Invoke(ctx, "/Orders/ListBanks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CreateBank(ctx context.Context, in *Bank, opts ...grpc.CallOption) (*Bank, error) {
+	out := new(Bank)
+	err := c.cc.Invoke(ctx, "/Orders/CreateBank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) UpdateBank(ctx context.Context, in *Bank, opts ...grpc.CallOption) (*Bank, error) {
+	out := new(Bank)
+	err := c.cc.Invoke(ctx, "/Orders/UpdateBank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) SetBankActive(ctx context.Context, in *SetBankActiveRequest, opts ...grpc.CallOption) (*Bank, error) {
+	out := new(Bank)
+	err := c.cc.Invoke(ctx, "/Orders/SetBankActive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) ReconciliationReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*ReportResponse, error) {
+	out := new(ReportResponse)
+	err := c.cc.Invoke(ctx, "/Orders/ReconciliationReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) RefundOrder(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, "/Orders/RefundOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CancelOrder(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, "/Orders/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CheckPromoCode(ctx context.Context, in *PromoRequest, opts ...grpc.CallOption) (*Pricing, error) {
+	out := new(Pricing)
+	err := c.cc.Invoke(ctx, "/Orders/CheckPromoCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CreatePromoCode(ctx context.Context, in *PromoCode, opts ...grpc.CallOption) (*PromoCode, error) {
+	out := new(PromoCode)
+	err := c.cc.Invoke(ctx, "/Orders/CreatePromoCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CompleteOrder(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error) {
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, "/Orders/CompleteOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) AddTip(ctx context.Context, in *TipRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/Orders/AddTip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) PayTip(ctx context.Context, in *PayRequest, opts ...grpc.CallOption) (*PayResponse, error) {
+	out := new(PayResponse)
+	err := c.cc.Invoke(ctx, "/Orders/PayTip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetEarnings(ctx context.Context, in *EarningsRequest, opts ...grpc.CallOption) (*EarningsResponse, error) {
+	out := new(EarningsResponse)
+	err := c.cc.Invoke(ctx, "/Orders/GetEarnings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CreateReview(ctx context.Context, in *Review, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := c.cc.Invoke(ctx, "/Orders/CreateReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, "/Orders/ListReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) SetReviewHidden(ctx context.Context, in *SetReviewHiddenRequest, opts ...grpc.CallOption) (*Review, error) {
+	out := new(Review)
+	err := c.cc.Invoke(ctx, "/Orders/SetReviewHidden", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) CreateCart(ctx context.Context, in *Cart, opts ...grpc.CallOption) (*CartResponse, error) {
+	out := new(CartResponse)
+	err := c.cc.Invoke(ctx, "/Orders/CreateCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) PayCart(ctx context.Context, in *PayRequest, opts ...grpc.CallOption) (*PayResponse, error) {
+	out := new(PayResponse)
+	err := c.cc.Invoke(ctx, "/Orders/PayCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) WatchOrder(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Orders_WatchOrderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Orders_ServiceDesc.Streams[0], "/Orders/WatchOrder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ordersWatchOrderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Orders_WatchOrderClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type ordersWatchOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *ordersWatchOrderClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ordersClient) TrackLocation(ctx context.Context, in *LocationPing, opts ...grpc.CallOption) (*LocationResponse, error) {
+	out := new(LocationResponse)
+	err := c.cc.Invoke(ctx, "/Orders/TrackLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetReceipt(ctx context.Context, in *ReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error) {
+	out := new(ReceiptResponse)
+	err := c.cc.Invoke(ctx, "/Orders/GetReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, "/Orders/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersServer is the server API for Orders service.
 // All implementations must embed UnimplementedOrdersServer
 // for forward compatibility
@@ -69,6 +332,30 @@ type OrdersServer interface {
 	CreateOrder(context.Context, *Order) (*CreateResponse, error)
 	PayOrder(context.Context, *PayRequest) (*PayResponse, error)
 	AssignOrder(context.Context, *AssignRequest) (*AssignResponse, error)
+	UpdateOrderAddress(context.Context, *UpdateAddressRequest) (*UpdateAddressResponse, error)
+	GetBank(context.Context, *GetBankRequest) (*Bank, error)
+	ListBanks(context.Context, *ListBanksRequest) (*ListBanksResponse, error)
+	CreateBank(context.Context, *Bank) (*Bank, error)
+	UpdateBank(context.Context, *Bank) (*Bank, error)
+	SetBankActive(context.Context, *SetBankActiveRequest) (*Bank, error)
+	ReconciliationReport(context.Context, *ReportRequest) (*ReportResponse, error)
+	RefundOrder(context.Context, *RefundRequest) (*RefundResponse, error)
+	CancelOrder(context.Context, *CancelRequest) (*CancelResponse, error)
+	CheckPromoCode(context.Context, *PromoRequest) (*Pricing, error)
+	CreatePromoCode(context.Context, *PromoCode) (*PromoCode, error)
+	CompleteOrder(context.Context, *CompleteRequest) (*CompleteResponse, error)
+	AddTip(context.Context, *TipRequest) (*CreateResponse, error)
+	PayTip(context.Context, *PayRequest) (*PayResponse, error)
+	GetEarnings(context.Context, *EarningsRequest) (*EarningsResponse, error)
+	CreateReview(context.Context, *Review) (*Review, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	SetReviewHidden(context.Context, *SetReviewHiddenRequest) (*Review, error)
+	CreateCart(context.Context, *Cart) (*CartResponse, error)
+	PayCart(context.Context, *PayRequest) (*PayResponse, error)
+	WatchOrder(*WatchRequest, Orders_WatchOrderServer) error
+	TrackLocation(context.Context, *LocationPing) (*LocationResponse, error)
+	GetReceipt(context.Context, *ReceiptRequest) (*ReceiptResponse, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
 	mustEmbedUnimplementedOrdersServer()
 }
 
@@ -85,6 +372,78 @@ func (UnimplementedOrdersServer) PayOrder(context.Context, *PayRequest) (*PayRes
 func (UnimplementedOrdersServer) AssignOrder(context.Context, *AssignRequest) (*AssignResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssignOrder not implemented")
 }
+func (UnimplementedOrdersServer) UpdateOrderAddress(context.Context, *UpdateAddressRequest) (*UpdateAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderAddress not implemented")
+}
+func (UnimplementedOrdersServer) GetBank(context.Context, *GetBankRequest) (*Bank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBank not implemented")
+}
+func (UnimplementedOrdersServer) ListBanks(context.Context, *ListBanksRequest) (*ListBanksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanks not implemented")
+}
+func (UnimplementedOrdersServer) CreateBank(context.Context, *Bank) (*Bank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBank not implemented")
+}
+func (UnimplementedOrdersServer) UpdateBank(context.Context, *Bank) (*Bank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBank not implemented")
+}
+func (UnimplementedOrdersServer) SetBankActive(context.Context, *SetBankActiveRequest) (*Bank, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBankActive not implemented")
+}
+func (UnimplementedOrdersServer) ReconciliationReport(context.Context, *ReportRequest) (*ReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconciliationReport not implemented")
+}
+func (UnimplementedOrdersServer) RefundOrder(context.Context, *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
+}
+func (UnimplementedOrdersServer) CancelOrder(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrdersServer) CheckPromoCode(context.Context, *PromoRequest) (*Pricing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPromoCode not implemented")
+}
+func (UnimplementedOrdersServer) CreatePromoCode(context.Context, *PromoCode) (*PromoCode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromoCode not implemented")
+}
+func (UnimplementedOrdersServer) CompleteOrder(context.Context, *CompleteRequest) (*CompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteOrder not implemented")
+}
+func (UnimplementedOrdersServer) AddTip(context.Context, *TipRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTip not implemented")
+}
+func (UnimplementedOrdersServer) PayTip(context.Context, *PayRequest) (*PayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayTip not implemented")
+}
+func (UnimplementedOrdersServer) GetEarnings(context.Context, *EarningsRequest) (*EarningsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEarnings not implemented")
+}
+func (UnimplementedOrdersServer) CreateReview(context.Context, *Review) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReview not implemented")
+}
+func (UnimplementedOrdersServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedOrdersServer) SetReviewHidden(context.Context, *SetReviewHiddenRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReviewHidden not implemented")
+}
+func (UnimplementedOrdersServer) CreateCart(context.Context, *Cart) (*CartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCart not implemented")
+}
+func (UnimplementedOrdersServer) PayCart(context.Context, *PayRequest) (*PayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayCart not implemented")
+}
+func (UnimplementedOrdersServer) WatchOrder(*WatchRequest, Orders_WatchOrderServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
+func (UnimplementedOrdersServer) TrackLocation(context.Context, *LocationPing) (*LocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrackLocation not implemented")
+}
+func (UnimplementedOrdersServer) GetReceipt(context.Context, *ReceiptRequest) (*ReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedOrdersServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
 func (UnimplementedOrdersServer) mustEmbedUnimplementedOrdersServer() {}
 
 // UnsafeOrdersServer may be embedded to opt out of forward compatibility for this service.
@@ -152,26 +511,559 @@ func _Orders_AssignOrder_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-// Orders_ServiceDesc is the grpc.ServiceDesc for Orders service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Orders_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "Orders",
-	HandlerType: (*OrdersServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateOrder",
-			Handler:    _Orders_CreateOrder_Handler,
-		},
-		{
-			MethodName: "PayOrder",
-			Handler:    _Orders_PayOrder_Handler,
-		},
-		{
-			MethodName: "AssignOrder",
-			Handler:    _Orders_AssignOrder_Handler,
+func _Orders_UpdateOrderAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).UpdateOrderAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/UpdateOrderAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).UpdateOrderAddress(ctx, req.(*UpdateAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetBank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetBank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetBank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetBank(ctx, req.(*GetBankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_ListBanks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBanksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ListBanks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/ListBanks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ListBanks(ctx, req.(*ListBanksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CreateBank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Bank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CreateBank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CreateBank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CreateBank(ctx, req.(*Bank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_UpdateBank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Bank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).UpdateBank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/UpdateBank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).UpdateBank(ctx, req.(*Bank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_SetBankActive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBankActiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).SetBankActive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/SetBankActive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).SetBankActive(ctx, req.(*SetBankActiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_ReconciliationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ReconciliationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/ReconciliationReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ReconciliationReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/RefundOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).RefundOrder(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CancelOrder(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CheckPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CheckPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CheckPromoCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CheckPromoCode(ctx, req.(*PromoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CreatePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoCode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CreatePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CreatePromoCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CreatePromoCode(ctx, req.(*PromoCode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CompleteOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CompleteOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CompleteOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CompleteOrder(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_AddTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).AddTip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/AddTip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).AddTip(ctx, req.(*TipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_PayTip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).PayTip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/PayTip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).PayTip(ctx, req.(*PayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetEarnings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EarningsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetEarnings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetEarnings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetEarnings(ctx, req.(*EarningsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CreateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Review)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CreateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CreateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CreateReview(ctx, req.(*Review))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_SetReviewHidden_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReviewHiddenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).SetReviewHidden(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/SetReviewHidden",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).SetReviewHidden(ctx, req.(*SetReviewHiddenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_CreateCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cart)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).CreateCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/CreateCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).CreateCart(ctx, req.(*Cart))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_PayCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).PayCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/PayCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).PayCart(ctx, req.(*PayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrdersServer).WatchOrder(m, &ordersWatchOrderServer{stream})
+}
+
+type Orders_WatchOrderServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type ordersWatchOrderServer struct {
+	grpc.ServerStream
+}
+
+func (x *ordersWatchOrderServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Orders_TrackLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocationPing)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).TrackLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/TrackLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).TrackLocation(ctx, req.(*LocationPing))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetReceipt(ctx, req.(*ReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orders_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Orders/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Orders_ServiceDesc is the grpc.ServiceDesc for Orders service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Orders_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Orders",
+	HandlerType: (*OrdersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _Orders_CreateOrder_Handler,
+		},
+		{
+			MethodName: "PayOrder",
+			Handler:    _Orders_PayOrder_Handler,
+		},
+		{
+			MethodName: "AssignOrder",
+			Handler:    _Orders_AssignOrder_Handler,
+		},
+		{
+			MethodName: "UpdateOrderAddress",
+			Handler:    _Orders_UpdateOrderAddress_Handler,
+		},
+		{
+			MethodName: "GetBank",
+			Handler:    _Orders_GetBank_Handler,
+		},
+		{
+			MethodName: "ListBanks",
+			Handler:    _Orders_ListBanks_Handler,
+		},
+		{
+			MethodName: "CreateBank",
+			Handler:    _Orders_CreateBank_Handler,
+		},
+		{
+			MethodName: "UpdateBank",
+			Handler:    _Orders_UpdateBank_Handler,
+		},
+		{
+			MethodName: "SetBankActive",
+			Handler:    _Orders_SetBankActive_Handler,
+		},
+		{
+			MethodName: "ReconciliationReport",
+			Handler:    _Orders_ReconciliationReport_Handler,
+		},
+		{
+			MethodName: "RefundOrder",
+			Handler:    _Orders_RefundOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _Orders_CancelOrder_Handler,
+		},
+		{
+			MethodName: "CheckPromoCode",
+			Handler:    _Orders_CheckPromoCode_Handler,
+		},
+		{
+			MethodName: "CreatePromoCode",
+			Handler:    _Orders_CreatePromoCode_Handler,
+		},
+		{
+			MethodName: "CompleteOrder",
+			Handler:    _Orders_CompleteOrder_Handler,
+		},
+		{
+			MethodName: "AddTip",
+			Handler:    _Orders_AddTip_Handler,
+		},
+		{
+			MethodName: "PayTip",
+			Handler:    _Orders_PayTip_Handler,
+		},
+		{
+			MethodName: "GetEarnings",
+			Handler:    _Orders_GetEarnings_Handler,
+		},
+		{
+			MethodName: "CreateReview",
+			Handler:    _Orders_CreateReview_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _Orders_ListReviews_Handler,
+		},
+		{
+			MethodName: "SetReviewHidden",
+			Handler:    _Orders_SetReviewHidden_Handler,
+		},
+		{
+			MethodName: "CreateCart",
+			Handler:    _Orders_CreateCart_Handler,
+		},
+		{
+			MethodName: "PayCart",
+			Handler:    _Orders_PayCart_Handler,
+		},
+		{
+			MethodName: "TrackLocation",
+			Handler:    _Orders_TrackLocation_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _Orders_GetReceipt_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Orders_GetOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _Orders_WatchOrder_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/protos/orders.proto",
 }
@@ -0,0 +1,166 @@
+package orders
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pdfText is a piece of text at `x` points from the left edge of the page.
+type pdfText struct {
+	x    float64
+	text string
+}
+
+// pdfLine is a line of the document, an empty one makes a gap of its size.
+type pdfLine struct {
+	bold  bool
+	size  float64
+	texts []pdfText
+}
+
+// renderReceipt lays the receipt out as a PDF document.
+func renderReceipt(rec *receipt, issuedAt time.Time) []byte {
+	var lines = []pdfLine{
+		{bold: true, size: 18, texts: []pdfText{{50, "Receipt"}}},
+		{size: 10},
+		receiptRow(false, "Order number", rec.OrderNumber),
+		receiptRow(false, "Order ID", strconv.FormatInt(rec.OrderID, 10)),
+		receiptRow(false, "Partner", rec.PartnerTitle+" ("+rec.PartnerBrand+")"),
+		receiptRow(false, "Issued at", issuedAt.Format("2006-01-02 15:04 MST")),
+		{size: 10},
+		{bold: true, size: 10, texts: []pdfText{{50, "Product"}, {330, "Quantity"}, {400, "Price"}, {480, "Amount"}}},
+	}
+	for _, product := range rec.Products {
+		title := product.Title
+		if title == "" {
+			title = "Product #" + strconv.Itoa(int(product.ID))
+		}
+		lines = append(lines, pdfLine{size: 10, texts: []pdfText{
+			{50, title},
+			{330, strconv.Itoa(int(product.Quantity))},
+			{400, formatAmount(int64(product.Price))},
+			{480, formatAmount(int64(product.Price) * int64(product.Quantity))},
+		}})
+	}
+	lines = append(lines, pdfLine{size: 10})
+
+	if p := rec.Pricing; p != nil && p.Subtotal > 0 {
+		lines = append(lines, receiptRow(false, "Subtotal", formatAmount(p.Subtotal)))
+		for _, fee := range []struct {
+			title  string
+			amount int64
+		}{
+			{"Discount", -p.Discount},
+			{"Delivery fee", p.DeliveryFee},
+			{"Small order fee", p.SmallOrderFee},
+			{"Service fee", p.ServiceFee},
+			{"Tax", p.Tax},
+			{"Tip", p.Tip},
+		} {
+			if fee.amount != 0 {
+				lines = append(lines, receiptRow(false, fee.title, formatAmount(fee.amount)))
+			}
+		}
+	}
+	lines = append(lines,
+		receiptRow(true, "Total", formatAmount(rec.TotalAmount)),
+		pdfLine{size: 10},
+		receiptRow(false, "Paid amount", formatAmount(rec.PaidAmount)),
+		receiptRow(false, "Paytype", rec.Paytype),
+		receiptRow(false, "Payment ID", rec.PaymentID),
+	)
+
+	return renderPDF(lines)
+}
+
+func receiptRow(bold bool, title string, value string) pdfLine {
+	return pdfLine{bold: bold, size: 10, texts: []pdfText{{50, title}, {200, value}}}
+}
+
+// formatAmount shows the minor units of the currency as a decimal: 12345 -> 123.45
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// renderPDF writes the lines on as many A4 pages as they need. It uses the standard Helvetica
+// fonts, which every PDF reader has, so no font is embedded and the text is latin only.
+func renderPDF(lines []pdfLine) []byte {
+	const top, bottom = 792, 50
+	var pages []string
+	var page strings.Builder
+	var y float64 = top
+	for _, line := range lines {
+		height := line.size * 1.5
+		if y-height < bottom {
+			pages = append(pages, page.String())
+			page.Reset()
+			y = top
+		}
+		y -= height
+		font := "F1"
+		if line.bold {
+			font = "F2"
+		}
+		for _, text := range line.texts {
+			fmt.Fprintf(&page, "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, line.size, text.x, y, pdfEscape(text.text))
+		}
+	}
+	pages = append(pages, page.String())
+
+	// 1: catalog, 2: page tree, 3 and 4: fonts, then each page is followed by its content
+	var kids = make([]string, len(pages))
+	for idx := range pages {
+		kids[idx] = strconv.Itoa(5+idx*2) + " 0 R"
+	}
+	var objects = []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+	for idx, content := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", 6+idx*2),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n")
+	var offsets = make([]int, len(objects))
+	for idx, object := range objects {
+		offsets[idx] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", idx+1, object)
+	}
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buffer.Bytes()
+}
+
+// pdfEscape makes a PDF literal string of the text, replacing what the standard fonts can't show.
+func pdfEscape(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r < ' ' || r > '~':
+			builder.WriteByte('?')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -2,6 +2,7 @@ package orders
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/pkg/errors"
@@ -12,10 +13,53 @@ import (
 
 type Repository interface {
 	getBank(context.Context, string) (*bank, error)
+	listBanks(ctx context.Context, activeOnly bool) ([]*bank, error)
+	createBank(context.Context, *bank) (*bank, error)
+	updateBank(context.Context, *bank) (*bank, error)
+	setBankActive(ctx context.Context, id string, active bool) (*bank, error)
 	createOrder(context.Context, *Order) (int64, error)
+	setCheckoutSession(ctx context.Context, orderID int64, sessionID string) error
 	payOrder(context.Context, *PayRequest) (string, *PaidOrder, error)
-	pickupOrder(ctx context.Context, orderID int64, pickupAddress string) error
-	assignOrder(context.Context, *AssignRequest) (string, error)
+	pickupOrder(ctx context.Context, orderID int64, pickupAddress *Address) error
+	assignOrder(context.Context, *AssignRequest) (string, *AssignResponse, error)
+	getOrderPricing(ctx context.Context, orderID int64, customerID string) (*Pricing, error)
+	updateOrderAddress(context.Context, *UpdateAddressRequest) (*updatedAddress, error)
+	saveHistory(ctx context.Context, orderID int64, status string, event string, details pkg.Map) error
+	getUnreconciledPayments(context.Context) ([]*paymentRecord, error)
+	getUnreconciledOrders(ctx context.Context, orderIDs []int64, cutoff time.Time) ([]*reconciledOrder, error)
+	saveReconciliation(ctx context.Context, discrepancies []*Discrepancy, orderIDs []int64, paymentIDs []string) error
+	getDiscrepancies(ctx context.Context, from time.Time, to time.Time) ([]*Discrepancy, error)
+	getRefundableOrder(ctx context.Context, orderID int64) (*refundableOrder, error)
+	getPayment(ctx context.Context, orderID int64) (*paymentRecord, error)
+	reserveRefund(ctx context.Context, orderID int64, refundedAmount int64, amount int64) (string, error)
+	releaseRefund(ctx context.Context, orderID int64, amount int64) error
+	refundOrder(ctx context.Context, orderID int64, paymentID string, status string, refund *refundRecord) error
+	cancelOrder(ctx context.Context, orderID int64, customerID string) ([]int64, error)
+	expireOrders(ctx context.Context, createdBefore time.Time) ([]int64, error)
+	getPromoCode(ctx context.Context, code string) (*promoCode, error)
+	countRedemptions(ctx context.Context, code string, customerID string) (int32, error)
+	createPromoCode(context.Context, *promoCode) error
+	completeOrder(ctx context.Context, orderID int64, delivererID string, deliveryFeeShare int64) (*completedOrder, error)
+	getTippableOrder(ctx context.Context, orderID int64, customerID string) (*tippableOrder, error)
+	createTip(ctx context.Context, orderID int64, amount int64) (int64, error)
+	setTipCheckoutSession(ctx context.Context, tipID int64, sessionID string) error
+	payTip(context.Context, *PayRequest) (*paidTip, error)
+	getEarnings(ctx context.Context, delivererID string, from time.Time, to time.Time) ([]*Earnings, error)
+	getPendingPayout(ctx context.Context, delivererID string) (int64, error)
+	createReview(context.Context, *Review) (*Review, error)
+	listReviews(context.Context, *ListReviewsRequest) ([]*Review, error)
+	setReviewHidden(ctx context.Context, orderID int64, hidden bool) (*Review, error)
+	getPartnerRating(ctx context.Context, partnerID int32) (*PartnerRating, error)
+	getDelivererRating(ctx context.Context, delivererID string) (float64, int32, error)
+	createCart(ctx context.Context, cart *Cart, totalAmount int64) (int64, []int64, error)
+	setCartCheckoutSession(ctx context.Context, checkoutID int64, sessionID string) error
+	payCart(context.Context, *PayRequest) ([]*PaidOrder, error)
+	getOrderEvent(ctx context.Context, orderID int64, customerID string) (*OrderEvent, error)
+	getDeliveringOrder(ctx context.Context, orderID int64, delivererID string) (*deliveringOrder, error)
+	saveLocation(ctx context.Context, ping *LocationPing, etaMinutes int32) error
+	getReceipt(ctx context.Context, orderID int64) (*receipt, error)
+	getCustomerOrder(ctx context.Context, orderID int64, customerID string) (*Order, error)
+	setReceiptURL(ctx context.Context, orderID int64, receiptURL string) (string, error)
 }
 
 type repository struct {
@@ -30,17 +74,187 @@ func NewRepository(postgres pkg.Postgres, nosql pkg.NoSQL) Repository {
 	}
 }
 
-func (r *repository) getBank(ctx context.Context, id string) (*bank, error) {
-	query := `SELECT id, webcheckout_url FROM banks WHERE id = $1 AND active`
+const bankColumns = `id, title, driver, webcheckout_url, callback_secret, allowed_ips, currencies, active`
+
+func scanBank(row pkg.Row) (*bank, error) {
 	var bank = &bank{}
-	err := r.postgres.QueryRow(ctx, query, id).Scan(&bank.ID, &bank.WebcheckoutURL)
+	err := row.Scan(
+		&bank.ID,
+		&bank.Title,
+		&bank.Driver,
+		&bank.WebcheckoutURL,
+		&bank.CallbackSecret,
+		&bank.AllowedIPs,
+		&bank.Currencies,
+		&bank.Active,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return bank, nil
+}
+
+func (r *repository) getBank(ctx context.Context, id string) (*bank, error) {
+	query := `SELECT ` + bankColumns + ` FROM banks WHERE id = $1 AND active`
+	bank, err := scanBank(r.postgres.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return bank, nil
+}
+
+func (r *repository) listBanks(ctx context.Context, activeOnly bool) ([]*bank, error) {
+	query := `SELECT ` + bankColumns + ` FROM banks WHERE active OR NOT $1 ORDER BY id`
+	rows, err := r.postgres.Query(ctx, query, activeOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var banks = make([]*bank, 0)
+	for rows.Next() {
+		bank, err := scanBank(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		banks = append(banks, bank)
+	}
+	return banks, nil
+}
+
+func (r *repository) createBank(ctx context.Context, b *bank) (*bank, error) {
+	query := `INSERT INTO banks (
+		id
+		, title
+		, driver
+		, webcheckout_url
+		, callback_secret
+		, allowed_ips
+		, currencies
+		, active
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING ` + bankColumns
+	bank, err := scanBank(r.postgres.QueryRow(ctx, query,
+		b.ID,
+		b.Title,
+		b.Driver,
+		b.WebcheckoutURL,
+		b.CallbackSecret,
+		b.AllowedIPs,
+		b.Currencies,
+		b.Active,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return bank, nil
+}
+
+func (r *repository) updateBank(ctx context.Context, b *bank) (*bank, error) {
+	query := `UPDATE banks
+	SET
+		title = $2
+		, driver = $3
+		, webcheckout_url = $4
+		, callback_secret = COALESCE(NULLIF($5, ''), callback_secret)
+		, allowed_ips = $6
+		, currencies = $7
+	WHERE id = $1
+	RETURNING ` + bankColumns
+	bank, err := scanBank(r.postgres.QueryRow(ctx, query,
+		b.ID,
+		b.Title,
+		b.Driver,
+		b.WebcheckoutURL,
+		b.CallbackSecret,
+		b.AllowedIPs,
+		b.Currencies,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return bank, nil
+}
+
+func (r *repository) setBankActive(ctx context.Context, id string, active bool) (*bank, error) {
+	query := `UPDATE banks SET active = $2 WHERE id = $1 RETURNING ` + bankColumns
+	bank, err := scanBank(r.postgres.QueryRow(ctx, query, id, active))
 	if err != nil {
 		return nil, errors.Wrap(err, "r.postgres.QueryRow")
 	}
 	return bank, nil
 }
 
+// querier is implemented by both pkg.Postgres and pkg.Tx.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pkg.Row
+}
+
 func (r *repository) createOrder(ctx context.Context, order *Order) (int64, error) {
+	if order.PromoCode == "" {
+		id, err := insertOrder(ctx, r.postgres, order, 0)
+		if err != nil {
+			return 0, errors.Wrap(err, "insertOrder")
+		}
+		return id, nil
+	}
+
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	id, err := insertOrder(ctx, tx, order, 0)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, errors.Wrap(err, "insertOrder")
+	}
+
+	// the limits are checked again, under the row lock, as they might have run out since checkOrder
+	query := `UPDATE promo_codes
+	SET used_count = used_count + 1
+	WHERE
+		code = $1
+		AND active
+		AND valid_from <= now()
+		AND (valid_to IS NULL OR valid_to > now())
+		AND (usage_limit = 0 OR used_count < usage_limit)
+		AND (cardinality(partner_ids) = 0 OR $2 = ANY(partner_ids))
+		AND min_order_amount <= $3
+		AND (per_user_limit = 0 OR per_user_limit > (
+			SELECT count(*) FROM promo_redemptions WHERE code = $1 AND customer_id = $4
+		))
+	RETURNING code`
+	var code string
+	err = tx.QueryRow(ctx, query,
+		order.PromoCode,
+		order.PartnerID,
+		order.Pricing.GetSubtotal(),
+		order.CustomerID,
+	).Scan(&code)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, errors.Wrap(err, "UPDATE promo_codes")
+	}
+
+	query = `INSERT INTO promo_redemptions (order_id, code, customer_id, discount) VALUES ($1, $2, $3, $4)`
+	err = tx.Exec(ctx, query, id, code, order.CustomerID, order.Pricing.GetDiscount())
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, errors.Wrap(err, "INSERT INTO promo_redemptions")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, errors.Wrap(err, "tx.Commit")
+	}
+
+	return id, nil
+}
+
+// insertOrder saves the order, checkoutID links it to its cart, 0 - a single partner order.
+func insertOrder(ctx context.Context, q querier, order *Order, checkoutID int64) (int64, error) {
 	query := `INSERT INTO orders (
 		order_id
 		, customer_id
@@ -54,10 +268,16 @@ func (r *repository) createOrder(ctx context.Context, order *Order) (int64, erro
 		, total_amount
 		, paytype
 		, products
-	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		, pricing
+		, promo_code
+		, scheduled_at
+		, checkout_id
+		, customer_email
+		, partner_email
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, '')::TIMESTAMPTZ, NULLIF($16::BIGINT, 0), $17, $18)
 	RETURNING id`
 	var id int64
-	err := r.postgres.QueryRow(ctx, query,
+	err := q.QueryRow(ctx, query,
 		order.OrderID,
 		order.CustomerID,
 		order.CustomerName,
@@ -70,14 +290,32 @@ func (r *repository) createOrder(ctx context.Context, order *Order) (int64, erro
 		order.TotalAmount,
 		order.Paytype,
 		order.Products,
+		order.Pricing,
+		order.PromoCode,
+		order.ScheduledAt,
+		checkoutID,
+		order.CustomerEmail,
+		order.PartnerEmail,
 	).Scan(&id)
 	if err != nil {
-		return 0, errors.Wrap(err, "r.postgres.QueryRow")
+		return 0, errors.Wrap(err, "q.QueryRow")
 	}
-
 	return id, nil
 }
 
+func (r *repository) setCheckoutSession(ctx context.Context, orderID int64, sessionID string) error {
+	query := `UPDATE orders
+	SET
+		checkout_session_id = $1
+		, updated_at = now()
+	WHERE id = $2`
+	err := r.postgres.Exec(ctx, query, sessionID, orderID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
 func (r *repository) payOrder(ctx context.Context, req *PayRequest) (string, *PaidOrder, error) {
 	tx, err := r.postgres.Begin(ctx)
 	if err != nil {
@@ -92,8 +330,9 @@ func (r *repository) payOrder(ctx context.Context, req *PayRequest) (string, *Pa
 	WHERE
 		id = $2
 		AND status = 'pending'
+		AND paytype = $3
 		AND total_amount <= $1
-	RETURNING products, partner_id`
+	RETURNING products, partner_id, scheduled_at`
 
 	var order = &PaidOrder{
 		OrderID: req.OrderID,
@@ -101,9 +340,11 @@ func (r *repository) payOrder(ctx context.Context, req *PayRequest) (string, *Pa
 	err = tx.QueryRow(ctx, query,
 		req.PaidAmount,
 		req.OrderID,
+		req.BankID,
 	).Scan(
 		&order.Products,
 		&order.PartnerID,
+		&order.ScheduledAt,
 	)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -114,7 +355,9 @@ func (r *repository) payOrder(ctx context.Context, req *PayRequest) (string, *Pa
 		"payment_id": req.PaymentID,
 		"order_id":   req.OrderID,
 		"amount":     req.PaidAmount,
+		"bank_id":    req.BankID,
 		"status":     "paid",
+		"reconciled": false,
 		"created_at": time.Now(),
 	}
 	paymentID, err := r.nosql.Insert(ctx, "payments", payItems)
@@ -133,7 +376,7 @@ func (r *repository) payOrder(ctx context.Context, req *PayRequest) (string, *Pa
 	return paymentID, order, nil
 }
 
-func (r *repository) pickupOrder(ctx context.Context, orderID int64, pickupAddress string) error {
+func (r *repository) pickupOrder(ctx context.Context, orderID int64, pickupAddress *Address) error {
 	query := `UPDATE orders
 	SET
 		pickup_address = $1
@@ -147,21 +390,1079 @@ func (r *repository) pickupOrder(ctx context.Context, orderID int64, pickupAddre
 	return nil
 }
 
-func (r *repository) assignOrder(ctx context.Context, req *AssignRequest) (string, error) {
+func (r *repository) assignOrder(ctx context.Context, req *AssignRequest) (string, *AssignResponse, error) {
 	query := `UPDATE orders
 	SET
 		deliverer_id = $1
+		, deliverer_notif_token = $2
 		, updated_at = now()
 		, status = 'delivering'
-	WHERE id = $2 AND status = 'ready'
-	RETURNING customer_notif_token`
+	WHERE id = $3 AND status = 'ready'
+	RETURNING customer_notif_token, pickup_address, delivery_address`
 	var customerNotifToken string
+	var resp = &AssignResponse{
+		PickupAddress:   &Address{},
+		DeliveryAddress: &Address{},
+	}
+	err := r.postgres.QueryRow(ctx, query,
+		req.DelivererID,
+		req.DelivererNotifToken,
+		req.OrderID,
+	).Scan(
+		&customerNotifToken,
+		resp.PickupAddress,
+		resp.DeliveryAddress,
+	)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return customerNotifToken, resp, nil
+}
+
+func (r *repository) getOrderPricing(ctx context.Context, orderID int64, customerID string) (*Pricing, error) {
+	query := `SELECT pricing FROM orders WHERE id = $1 AND customer_id = $2`
+	var pricing = &Pricing{}
+	err := r.postgres.QueryRow(ctx, query, orderID, customerID).Scan(pricing)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return pricing, nil
+}
+
+func (r *repository) updateOrderAddress(ctx context.Context, req *UpdateAddressRequest) (*updatedAddress, error) {
+	query := `UPDATE orders ord
+	SET
+		delivery_address = $1
+		, updated_at = now()
+	FROM (
+		SELECT id, delivery_address FROM orders WHERE id = $2 FOR UPDATE
+	) old
+	WHERE
+		ord.id = old.id
+		AND ord.customer_id = $3
+		AND ord.status IN ('pending', 'paid', 'ready')
+	RETURNING ord.status, old.delivery_address`
+	var updated = &updatedAddress{OldAddress: &Address{}}
+	err := r.postgres.QueryRow(ctx, query,
+		req.DeliveryAddress,
+		req.OrderID,
+		req.CustomerID,
+	).Scan(
+		&updated.Status,
+		updated.OldAddress,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return updated, nil
+}
+
+func (r *repository) saveHistory(ctx context.Context, orderID int64, status string, event string, details pkg.Map) error {
+	var items = pkg.Map{
+		"order_id":   orderID,
+		"status":     status,
+		"event":      event,
+		"details":    details,
+		"created_at": time.Now(),
+	}
+	_, err := r.nosql.Insert(ctx, "orders_history", items)
+	if err != nil {
+		return errors.Wrap(err, "r.nosql.Insert")
+	}
+	return nil
+}
+
+func (r *repository) getUnreconciledPayments(ctx context.Context) ([]*paymentRecord, error) {
+	items, err := r.nosql.GetItems(ctx, "payments", pkg.Map{"reconciled": false})
+	if err != nil {
+		return nil, errors.Wrap(err, "r.nosql.GetItems")
+	}
+	var payments = make([]*paymentRecord, 0, len(items))
+	for _, item := range items {
+		var payment = &paymentRecord{}
+		err = decodeItem(item, payment)
+		if err != nil {
+			return nil, errors.Wrap(err, "decodeItem")
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
+
+// decodeItem round-trips a NoSQL item through json to not depend on the value types of the NoSQL driver.
+func decodeItem(item pkg.Map, v any) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+	return nil
+}
+
+// getUnreconciledOrders skips the orders changed after the cutoff,
+// their payments may have been stored after the payments were read.
+func (r *repository) getUnreconciledOrders(ctx context.Context, orderIDs []int64, cutoff time.Time) ([]*reconciledOrder, error) {
+	query := `SELECT
+		id
+		, status
+		, total_amount
+		, paid_amount
+		, paytype
+	FROM orders
+	WHERE
+		(reconciled_at IS NULL AND status NOT IN ('pending', 'expired', 'cancelled') AND updated_at < $2)
+		OR id = ANY($1)
+	ORDER BY id`
+	rows, err := r.postgres.Query(ctx, query, orderIDs, cutoff)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var orders = make([]*reconciledOrder, 0)
+	for rows.Next() {
+		var order = &reconciledOrder{}
+		err = rows.Scan(
+			&order.ID,
+			&order.Status,
+			&order.TotalAmount,
+			&order.PaidAmount,
+			&order.Paytype,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *repository) saveReconciliation(ctx context.Context, discrepancies []*Discrepancy, orderIDs []int64, paymentIDs []string) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	query := `INSERT INTO discrepancies (
+		order_id
+		, payment_id
+		, bank_id
+		, kind
+		, expected_amount
+		, actual_amount
+		, details
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for _, d := range discrepancies {
+		err = tx.Exec(ctx, query,
+			d.OrderID,
+			d.PaymentID,
+			d.BankID,
+			d.Kind,
+			d.ExpectedAmount,
+			d.ActualAmount,
+			d.Details,
+		)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO discrepancies")
+		}
+	}
+
+	query = `UPDATE orders SET reconciled_at = now() WHERE id = ANY($1)`
+	err = tx.Exec(ctx, query, orderIDs)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "UPDATE orders")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "tx.Commit")
+	}
+
+	// a payment that fails to be marked is checked again on the next run
+	for _, paymentID := range paymentIDs {
+		_, err = r.nosql.Update(ctx, "payments", pkg.Map{"payment_id": paymentID}, pkg.Map{"reconciled": true})
+		if err != nil {
+			return errors.Wrap(err, "r.nosql.Update")
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) getDiscrepancies(ctx context.Context, from time.Time, to time.Time) ([]*Discrepancy, error) {
+	query := `SELECT
+		order_id
+		, payment_id
+		, bank_id
+		, kind
+		, expected_amount
+		, actual_amount
+		, details
+		, detected_at
+	FROM discrepancies
+	WHERE detected_at >= $1 AND detected_at < $2
+	ORDER BY detected_at, id`
+	rows, err := r.postgres.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var discrepancies = make([]*Discrepancy, 0)
+	for rows.Next() {
+		var d = &Discrepancy{}
+		var detectedAt time.Time
+		err = rows.Scan(
+			&d.OrderID,
+			&d.PaymentID,
+			&d.BankID,
+			&d.Kind,
+			&d.ExpectedAmount,
+			&d.ActualAmount,
+			&d.Details,
+			&detectedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		d.DetectedAt = detectedAt.Format(time.RFC3339)
+		discrepancies = append(discrepancies, d)
+	}
+	return discrepancies, nil
+}
+
+func (r *repository) getRefundableOrder(ctx context.Context, orderID int64) (*refundableOrder, error) {
+	query := `SELECT
+		status
+		, paid_amount
+		, refunded_amount
+		, customer_notif_token
+		, products
+		, pricing
+	FROM orders
+	WHERE id = $1`
+	var order = &refundableOrder{Pricing: &Pricing{}}
+	err := r.postgres.QueryRow(ctx, query, orderID).Scan(
+		&order.Status,
+		&order.PaidAmount,
+		&order.RefundedAmount,
+		&order.CustomerNotifToken,
+		&order.Products,
+		order.Pricing,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return order, nil
+}
+
+func (r *repository) getPayment(ctx context.Context, orderID int64) (*paymentRecord, error) {
+	item, err := r.nosql.GetItem(ctx, "payments", pkg.Map{"order_id": orderID})
+	if err != nil {
+		return nil, errors.Wrap(err, "r.nosql.GetItem")
+	}
+	var payment = &paymentRecord{}
+	err = decodeItem(item, payment)
+	if err != nil {
+		return nil, errors.Wrap(err, "decodeItem")
+	}
+	return payment, nil
+}
+
+// reserveRefund adds the amount to the refunded one of the order, if no other refund has been
+// made since it was read and none is in progress, and returns the status the refund leads to.
+func (r *repository) reserveRefund(ctx context.Context, orderID int64, refundedAmount int64, amount int64) (string, error) {
+	// a refund left in progress by a crash stops blocking the others after a while
+	query := `UPDATE orders
+	SET
+		refunded_amount = refunded_amount + $1
+		, refunding_at = now()
+	WHERE
+		id = $2
+		AND refunded_amount = $3
+		AND refunded_amount + $1 <= paid_amount
+		AND (refunding_at IS NULL OR refunding_at < now() - interval '5 minutes')
+	RETURNING CASE
+		WHEN refunded_amount >= paid_amount THEN 'refunded'
+		ELSE 'partially_refunded'
+	END`
+	var status string
+	err := r.postgres.QueryRow(ctx, query, amount, orderID, refundedAmount).Scan(&status)
+	if err != nil {
+		return "", errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return status, nil
+}
+
+// releaseRefund gives back the amount reserved by a refund that failed.
+func (r *repository) releaseRefund(ctx context.Context, orderID int64, amount int64) error {
+	query := `UPDATE orders
+	SET
+		refunded_amount = refunded_amount - $1
+		, refunding_at = NULL
+	WHERE id = $2`
+	err := r.postgres.Exec(ctx, query, amount, orderID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// refundOrder records the refund made on the reserved amount and sets the order's status.
+func (r *repository) refundOrder(ctx context.Context, orderID int64, paymentID string, status string, refund *refundRecord) error {
+	// the orders of a cart share the payment ID
+	_, err := r.nosql.Append(ctx, "payments", pkg.Map{"payment_id": paymentID, "order_id": orderID}, "refunds", refund, pkg.Map{
+		"status": status,
+	})
+	if err != nil {
+		return errors.Wrap(err, "r.nosql.Append")
+	}
+
+	query := `UPDATE orders
+	SET
+		status = $1
+		, refunding_at = NULL
+		, updated_at = now()
+	WHERE id = $2`
+	err = r.postgres.Exec(ctx, query, status, orderID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+func (r *repository) cancelOrder(ctx context.Context, orderID int64, customerID string) ([]int64, error) {
+	// the promo code redemption of the order is released in the same statement,
+	// the other orders of its cart are cancelled too, as they share the payment
+	query := `WITH cancelled AS (
+		UPDATE orders
+		SET
+			status = 'cancelled'
+			, updated_at = now()
+		WHERE
+			(id = $1 OR checkout_id = (SELECT checkout_id FROM orders WHERE id = $1))
+			AND customer_id = $2
+			AND status = 'pending'
+		RETURNING id
+	), released AS (
+		DELETE FROM promo_redemptions WHERE order_id IN (SELECT id FROM cancelled)
+		RETURNING code
+	), unused AS (
+		UPDATE promo_codes SET used_count = used_count - 1 WHERE code IN (SELECT code FROM released)
+	)
+	SELECT id FROM cancelled`
+	rows, err := r.postgres.Query(ctx, query, orderID, customerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var ids = make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, pkg.ErrNoRows
+	}
+	return ids, nil
+}
+
+func (r *repository) expireOrders(ctx context.Context, createdBefore time.Time) ([]int64, error) {
+	query := `WITH expired AS (
+		UPDATE orders
+		SET
+			status = 'expired'
+			, updated_at = now()
+		WHERE status = 'pending' AND created_at < $1
+		RETURNING id
+	), released AS (
+		DELETE FROM promo_redemptions WHERE order_id IN (SELECT id FROM expired)
+		RETURNING code
+	), unused AS (
+		UPDATE promo_codes pc
+		SET used_count = pc.used_count - rel.count
+		FROM (SELECT code, count(*) AS count FROM released GROUP BY code) rel
+		WHERE pc.code = rel.code
+	)
+	SELECT id FROM expired`
+	rows, err := r.postgres.Query(ctx, query, createdBefore)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var ids = make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *repository) getPromoCode(ctx context.Context, code string) (*promoCode, error) {
+	query := `SELECT
+		code
+		, kind
+		, value
+		, max_discount
+		, min_order_amount
+		, partner_ids
+		, valid_from
+		, valid_to
+		, usage_limit
+		, per_user_limit
+		, used_count
+		, active
+	FROM promo_codes
+	WHERE code = $1`
+	var promo = &promoCode{}
+	err := r.postgres.QueryRow(ctx, query, code).Scan(
+		&promo.Code,
+		&promo.Kind,
+		&promo.Value,
+		&promo.MaxDiscount,
+		&promo.MinOrderAmount,
+		&promo.PartnerIDs,
+		&promo.ValidFrom,
+		&promo.ValidTo,
+		&promo.UsageLimit,
+		&promo.PerUserLimit,
+		&promo.UsedCount,
+		&promo.Active,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return promo, nil
+}
+
+func (r *repository) countRedemptions(ctx context.Context, code string, customerID string) (int32, error) {
+	query := `SELECT count(*) FROM promo_redemptions WHERE code = $1 AND customer_id = $2`
+	var count int32
+	err := r.postgres.QueryRow(ctx, query, code, customerID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return count, nil
+}
+
+func (r *repository) createPromoCode(ctx context.Context, promo *promoCode) error {
+	query := `INSERT INTO promo_codes (
+		code
+		, kind
+		, value
+		, max_discount
+		, min_order_amount
+		, partner_ids
+		, valid_from
+		, valid_to
+		, usage_limit
+		, per_user_limit
+		, active
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	err := r.postgres.Exec(ctx, query,
+		promo.Code,
+		promo.Kind,
+		promo.Value,
+		promo.MaxDiscount,
+		promo.MinOrderAmount,
+		promo.PartnerIDs,
+		promo.ValidFrom,
+		promo.ValidTo,
+		promo.UsageLimit,
+		promo.PerUserLimit,
+		promo.Active,
+	)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// completeOrder marks the order delivered and credits the deliverer with
+// `deliveryFeeShare` (basis points) of the delivery fee and the checkout tip.
+func (r *repository) completeOrder(ctx context.Context, orderID int64, delivererID string, deliveryFeeShare int64) (*completedOrder, error) {
+	query := `WITH delivered AS (
+		UPDATE orders
+		SET
+			status = 'delivered'
+			, delivered_at = now()
+			, updated_at = now()
+		WHERE id = $1 AND deliverer_id = $2 AND status = 'delivering'
+		RETURNING
+			id
+			, deliverer_id
+			, customer_notif_token
+			, (COALESCE((pricing->>'deliveryFee')::BIGINT, 0) * $3 + 5000) / 10000 AS fee_share
+			, COALESCE((pricing->>'tip')::BIGINT, 0) AS tip
+	), credited AS (
+		INSERT INTO earnings (deliverer_id, order_id, kind, amount)
+		SELECT deliverer_id, id, 'delivery_fee', fee_share FROM delivered
+		UNION ALL
+		SELECT deliverer_id, id, 'tip', tip FROM delivered WHERE tip > 0
+	)
+	SELECT customer_notif_token, fee_share + tip FROM delivered`
+	var order = &completedOrder{}
+	err := r.postgres.QueryRow(ctx, query, orderID, delivererID, deliveryFeeShare).Scan(
+		&order.CustomerNotifToken,
+		&order.Earned,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return order, nil
+}
+
+func (r *repository) getTippableOrder(ctx context.Context, orderID int64, customerID string) (*tippableOrder, error) {
+	query := `SELECT paytype, partner_brand FROM orders WHERE id = $1 AND customer_id = $2 AND status = 'delivered'`
+	var order = &tippableOrder{}
+	err := r.postgres.QueryRow(ctx, query, orderID, customerID).Scan(
+		&order.Paytype,
+		&order.PartnerBrand,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return order, nil
+}
+
+func (r *repository) createTip(ctx context.Context, orderID int64, amount int64) (int64, error) {
+	query := `INSERT INTO tips (order_id, amount) VALUES ($1, $2) RETURNING id`
+	var id int64
+	err := r.postgres.QueryRow(ctx, query, orderID, amount).Scan(&id)
+	if err != nil {
+		return 0, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return id, nil
+}
+
+func (r *repository) setTipCheckoutSession(ctx context.Context, tipID int64, sessionID string) error {
+	query := `UPDATE tips SET checkout_session_id = $1 WHERE id = $2`
+	err := r.postgres.Exec(ctx, query, sessionID, tipID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// payTip marks the oldest pending tip of the order covered by the paid amount
+// as paid and credits it to the deliverer.
+func (r *repository) payTip(ctx context.Context, req *PayRequest) (*paidTip, error) {
+	query := `WITH paid AS (
+		UPDATE tips
+		SET
+			status = 'paid'
+			, payment_id = $1
+			, paid_at = now()
+		WHERE id = (
+			SELECT t.id
+			FROM tips t
+			JOIN orders o ON o.id = t.order_id
+			WHERE
+				t.order_id = $2
+				AND t.status = 'pending'
+				AND t.amount <= $3
+				AND o.paytype = $4
+			ORDER BY t.id
+			LIMIT 1
+			FOR UPDATE OF t
+		)
+		RETURNING order_id, amount
+	), credited AS (
+		INSERT INTO earnings (deliverer_id, order_id, kind, amount)
+		SELECT o.deliverer_id, o.id, 'tip', paid.amount
+		FROM paid JOIN orders o ON o.id = paid.order_id
+	)
+	SELECT paid.amount, o.deliverer_notif_token
+	FROM paid JOIN orders o ON o.id = paid.order_id`
+	var tip = &paidTip{}
 	err := r.postgres.QueryRow(ctx, query,
+		req.PaymentID,
+		req.OrderID,
+		req.PaidAmount,
+		req.BankID,
+	).Scan(
+		&tip.Amount,
+		&tip.DelivererNotifToken,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return tip, nil
+}
+
+// getEarnings sums up the deliverer's earnings by day, the days without earnings are skipped.
+func (r *repository) getEarnings(ctx context.Context, delivererID string, from time.Time, to time.Time) ([]*Earnings, error) {
+	query := `SELECT
+		to_char(created_at, 'YYYY-MM-DD') AS day
+		, count(*) FILTER (WHERE kind = 'delivery_fee')
+		, COALESCE(sum(amount) FILTER (WHERE kind = 'delivery_fee'), 0)
+		, COALESCE(sum(amount) FILTER (WHERE kind = 'tip'), 0)
+	FROM earnings
+	WHERE deliverer_id = $1 AND created_at >= $2 AND created_at < $3
+	GROUP BY day
+	ORDER BY day`
+	rows, err := r.postgres.Query(ctx, query, delivererID, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var earnings = make([]*Earnings, 0)
+	for rows.Next() {
+		var day = &Earnings{}
+		err = rows.Scan(
+			&day.Date,
+			&day.Deliveries,
+			&day.DeliveryFees,
+			&day.Tips,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		day.Total = day.DeliveryFees + day.Tips
+		earnings = append(earnings, day)
+	}
+	return earnings, nil
+}
+
+func (r *repository) getPendingPayout(ctx context.Context, delivererID string) (int64, error) {
+	query := `SELECT COALESCE(sum(amount), 0) FROM earnings WHERE deliverer_id = $1 AND paid_out_at IS NULL`
+	var amount int64
+	err := r.postgres.QueryRow(ctx, query, delivererID).Scan(&amount)
+	if err != nil {
+		return 0, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return amount, nil
+}
+
+const reviewColumns = `order_id
+	, customer_id
+	, partner_id
+	, deliverer_id
+	, partner_rating
+	, deliverer_rating
+	, comment
+	, hidden
+	, created_at`
+
+func scanReview(row pkg.Row) (*Review, error) {
+	var review = &Review{}
+	var createdAt time.Time
+	err := row.Scan(
+		&review.OrderID,
+		&review.CustomerID,
+		&review.PartnerID,
+		&review.DelivererID,
+		&review.PartnerRating,
+		&review.DelivererRating,
+		&review.Comment,
+		&review.Hidden,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	review.CreatedAt = createdAt.Format(time.RFC3339)
+	return review, nil
+}
+
+// createReview saves the review of the customer's delivered order, unless it is already reviewed.
+func (r *repository) createReview(ctx context.Context, req *Review) (*Review, error) {
+	query := `INSERT INTO reviews (
+		order_id
+		, customer_id
+		, partner_id
+		, deliverer_id
+		, partner_rating
+		, deliverer_rating
+		, comment
+	)
+	SELECT id, customer_id, partner_id, deliverer_id, $3, $4, $5
+	FROM orders
+	WHERE id = $1 AND customer_id = $2 AND status = 'delivered'
+	ON CONFLICT (order_id) DO NOTHING
+	RETURNING ` + reviewColumns
+	review, err := scanReview(r.postgres.QueryRow(ctx, query,
+		req.OrderID,
+		req.CustomerID,
+		req.PartnerRating,
+		req.DelivererRating,
+		req.Comment,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return review, nil
+}
+
+func (r *repository) listReviews(ctx context.Context, req *ListReviewsRequest) ([]*Review, error) {
+	query := `SELECT ` + reviewColumns + `
+	FROM reviews
+	WHERE
+		($1 = 0 OR partner_id = $1)
+		AND ($2 = '' OR deliverer_id = $2)
+		AND (NOT hidden OR $3)
+	ORDER BY created_at DESC
+	LIMIT $4 OFFSET $5`
+	rows, err := r.postgres.Query(ctx, query,
+		req.PartnerID,
 		req.DelivererID,
+		req.IncludeHidden,
+		req.Limit,
+		req.Offset,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var reviews = make([]*Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+func (r *repository) setReviewHidden(ctx context.Context, orderID int64, hidden bool) (*Review, error) {
+	query := `UPDATE reviews
+	SET
+		hidden = $1
+		, updated_at = now()
+	WHERE order_id = $2
+	RETURNING ` + reviewColumns
+	review, err := scanReview(r.postgres.QueryRow(ctx, query, hidden, orderID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return review, nil
+}
+
+func (r *repository) getPartnerRating(ctx context.Context, partnerID int32) (*PartnerRating, error) {
+	query := `SELECT COALESCE(sum(partner_rating), 0), count(*)
+	FROM reviews
+	WHERE partner_id = $1 AND NOT hidden`
+	var rating = &PartnerRating{PartnerID: partnerID}
+	err := r.postgres.QueryRow(ctx, query, partnerID).Scan(
+		&rating.RatingSum,
+		&rating.RatingCount,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return rating, nil
+}
+
+func (r *repository) getDelivererRating(ctx context.Context, delivererID string) (float64, int32, error) {
+	query := `SELECT COALESCE(avg(deliverer_rating), 0)::DOUBLE PRECISION, count(*)
+	FROM reviews
+	WHERE deliverer_id = $1 AND NOT hidden`
+	var rating float64
+	var count int32
+	err := r.postgres.QueryRow(ctx, query, delivererID).Scan(&rating, &count)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return rating, count, nil
+}
+
+func (r *repository) createCart(ctx context.Context, cart *Cart, totalAmount int64) (int64, []int64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	query := `INSERT INTO checkouts (cart_id, customer_id, paytype, total_amount)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id`
+	var checkoutID int64
+	err = tx.QueryRow(ctx, query,
+		cart.CartID,
+		cart.CustomerID,
+		cart.Paytype,
+		totalAmount,
+	).Scan(&checkoutID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, nil, errors.Wrap(err, "INSERT INTO checkouts")
+	}
+
+	var orderIDs = make([]int64, 0, len(cart.Orders))
+	for _, order := range cart.Orders {
+		id, err := insertOrder(ctx, tx, order, checkoutID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, nil, errors.Wrap(err, "insertOrder")
+		}
+		orderIDs = append(orderIDs, id)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, nil, errors.Wrap(err, "tx.Commit")
+	}
+
+	return checkoutID, orderIDs, nil
+}
+
+func (r *repository) setCartCheckoutSession(ctx context.Context, checkoutID int64, sessionID string) error {
+	query := `UPDATE checkouts
+	SET
+		checkout_session_id = $1
+		, updated_at = now()
+	WHERE id = $2`
+	err := r.postgres.Exec(ctx, query, sessionID, checkoutID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// payCart marks the cart and its child orders paid, each child gets a payment record
+// of its own amount, so it is refunded and reconciled like a single order.
+func (r *repository) payCart(ctx context.Context, req *PayRequest) ([]*PaidOrder, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	// a cart with a cancelled or expired child can't be paid anymore
+	query := `WITH paid AS (
+		UPDATE checkouts
+		SET
+			paid_amount = $1
+			, updated_at = now()
+			, status = 'paid'
+		WHERE
+			id = $2
+			AND status = 'pending'
+			AND paytype = $3
+			AND total_amount <= $1
+			AND NOT EXISTS (SELECT 1 FROM orders WHERE checkout_id = $2 AND status <> 'pending')
+		RETURNING id
+	)
+	UPDATE orders
+	SET
+		paid_amount = total_amount
+		, updated_at = now()
+		, status = 'paid'
+	WHERE checkout_id IN (SELECT id FROM paid) AND status = 'pending'
+	RETURNING id, total_amount, products, partner_id, scheduled_at`
+	rows, err := tx.Query(ctx, query,
+		req.PaidAmount,
 		req.OrderID,
-	).Scan(&customerNotifToken)
+		req.BankID,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "UPDATE checkouts")
+	}
+
+	var paidOrders = make([]*PaidOrder, 0)
+	var amounts = make([]int64, 0)
+	for rows.Next() {
+		var order = &PaidOrder{}
+		var amount int64
+		err = rows.Scan(
+			&order.OrderID,
+			&amount,
+			&order.Products,
+			&order.PartnerID,
+			&order.ScheduledAt,
+		)
+		if err != nil {
+			rows.Close()
+			tx.Rollback(ctx)
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		paidOrders = append(paidOrders, order)
+		amounts = append(amounts, amount)
+	}
+	rows.Close()
+	if len(paidOrders) == 0 {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(pkg.ErrNoRows, "UPDATE checkouts")
+	}
+
+	for idx, order := range paidOrders {
+		_, err = r.nosql.Insert(ctx, "payments", pkg.Map{
+			"payment_id":      req.PaymentID,
+			"order_id":        order.OrderID,
+			"amount":          amounts[idx],
+			"checkout_amount": req.PaidAmount,
+			"bank_id":         req.BankID,
+			"status":          "paid",
+			"reconciled":      false,
+			"created_at":      time.Now(),
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, errors.Wrap(err, "r.nosql.Insert")
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "tx.Commit")
+	}
+
+	return paidOrders, nil
+}
+
+// getOrderEvent returns the current state of the customer's order as an event.
+func (r *repository) getOrderEvent(ctx context.Context, orderID int64, customerID string) (*OrderEvent, error) {
+	query := `SELECT status, deliverer_id, updated_at FROM orders WHERE id = $1 AND customer_id = $2`
+	var event = &OrderEvent{OrderID: orderID, Event: "current"}
+	var updatedAt time.Time
+	err := r.postgres.QueryRow(ctx, query, orderID, customerID).Scan(
+		&event.Status,
+		&event.DelivererID,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	event.CreatedAt = updatedAt.Format(time.RFC3339)
+	return event, nil
+}
+
+func (r *repository) getDeliveringOrder(ctx context.Context, orderID int64, delivererID string) (*deliveringOrder, error) {
+	query := `SELECT customer_id, delivery_address
+	FROM orders
+	WHERE id = $1 AND deliverer_id = $2 AND status = 'delivering'`
+	var order = &deliveringOrder{DeliveryAddress: &Address{}}
+	err := r.postgres.QueryRow(ctx, query, orderID, delivererID).Scan(
+		&order.CustomerID,
+		order.DeliveryAddress,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return order, nil
+}
+
+// saveLocation appends the ping to the trail of the order's delivery.
+func (r *repository) saveLocation(ctx context.Context, ping *LocationPing, etaMinutes int32) error {
+	_, err := r.nosql.Insert(ctx, "deliverer_locations", pkg.Map{
+		"order_id":     ping.OrderID,
+		"deliverer_id": ping.DelivererID,
+		"latitude":     ping.Latitude,
+		"longitude":    ping.Longitude,
+		"eta_minutes":  etaMinutes,
+		"created_at":   time.Now(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "r.nosql.Insert")
+	}
+	return nil
+}
+
+func (r *repository) getCustomerOrder(ctx context.Context, orderID int64, customerID string) (*Order, error) {
+	query := `SELECT
+		order_id
+		, customer_phone
+		, delivery_address
+		, partner_id
+		, partner_title
+		, partner_brand
+		, products
+		, total_amount
+		, paytype
+	FROM orders
+	WHERE id = $1 AND customer_id = $2`
+	var order = &Order{
+		CustomerID:      customerID,
+		DeliveryAddress: &Address{},
+	}
+	err := r.postgres.QueryRow(ctx, query, orderID, customerID).Scan(
+		&order.OrderID,
+		&order.CustomerPhone,
+		order.DeliveryAddress,
+		&order.PartnerID,
+		&order.PartnerTitle,
+		&order.PartnerBrand,
+		&order.Products,
+		&order.TotalAmount,
+		&order.Paytype,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return order, nil
+}
+
+// getReceipt returns the receipt details of a paid order, pkg.ErrNoRows if it isn't paid.
+func (r *repository) getReceipt(ctx context.Context, orderID int64) (*receipt, error) {
+	query := `SELECT
+		order_id
+		, customer_id
+		, customer_email
+		, partner_title
+		, partner_brand
+		, partner_email
+		, products
+		, pricing
+		, total_amount
+		, paid_amount
+		, paytype
+		, receipt_url
+	FROM orders
+	WHERE id = $1 AND paid_amount > 0`
+	var rec = &receipt{
+		OrderID: orderID,
+		Pricing: &Pricing{},
+	}
+	err := r.postgres.QueryRow(ctx, query, orderID).Scan(
+		&rec.OrderNumber,
+		&rec.CustomerID,
+		&rec.CustomerEmail,
+		&rec.PartnerTitle,
+		&rec.PartnerBrand,
+		&rec.PartnerEmail,
+		&rec.Products,
+		rec.Pricing,
+		&rec.TotalAmount,
+		&rec.PaidAmount,
+		&rec.Paytype,
+		&rec.ReceiptURL,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return rec, nil
+}
+
+// setReceiptURL keeps the receipt stored first and returns its URL.
+func (r *repository) setReceiptURL(ctx context.Context, orderID int64, receiptURL string) (string, error) {
+	query := `UPDATE orders
+	SET receipt_url = CASE WHEN receipt_url = '' THEN $1 ELSE receipt_url END
+	WHERE id = $2
+	RETURNING receipt_url`
+	var storedURL string
+	err := r.postgres.QueryRow(ctx, query, receiptURL, orderID).Scan(&storedURL)
 	if err != nil {
 		return "", errors.Wrap(err, "r.postgres.QueryRow")
 	}
-	return customerNotifToken, nil
+	return storedURL, nil
 }
@@ -1,9 +1,18 @@
 package orders
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shahzodshafizod/gocloud/internal/notifications"
@@ -15,41 +24,125 @@ type Service interface {
 	payOrder(context.Context, *PayRequest) (*PayResponse, error)
 	pickupOrder(context.Context, *pickupRequest) error
 	assignOrder(ctx context.Context, req *AssignRequest) (*AssignResponse, error)
-	// updateOrderAddress(orderID int64, updatedAddress *UpdatedAddress) error
+	updateOrderAddress(context.Context, *UpdateAddressRequest) (*UpdateAddressResponse, error)
+	getBank(context.Context, *GetBankRequest) (*Bank, error)
+	listBanks(context.Context, *ListBanksRequest) (*ListBanksResponse, error)
+	createBank(context.Context, *Bank) (*Bank, error)
+	updateBank(context.Context, *Bank) (*Bank, error)
+	setBankActive(context.Context, *SetBankActiveRequest) (*Bank, error)
+	reconcile(context.Context) error
+	reconciliationReport(context.Context, *ReportRequest) (*ReportResponse, error)
+	refundOrder(context.Context, *RefundRequest) (*RefundResponse, error)
+	cancelOrder(context.Context, *CancelRequest) (*CancelResponse, error)
+	expireOrders(ctx context.Context, createdBefore time.Time) error
+	checkPromoCode(context.Context, *PromoRequest) (*Pricing, error)
+	createPromoCode(context.Context, *PromoCode) (*PromoCode, error)
+	completeOrder(context.Context, *CompleteRequest) (*CompleteResponse, error)
+	addTip(context.Context, *TipRequest) (*CreateResponse, error)
+	payTip(context.Context, *PayRequest) (*PayResponse, error)
+	getEarnings(context.Context, *EarningsRequest) (*EarningsResponse, error)
+	createReview(context.Context, *Review) (*Review, error)
+	listReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	setReviewHidden(context.Context, *SetReviewHiddenRequest) (*Review, error)
+	createCart(context.Context, *Cart) (*CartResponse, error)
+	payCart(context.Context, *PayRequest) (*PayResponse, error)
+	watchOrder(ctx context.Context, req *WatchRequest, send func(*OrderEvent) error) error
+	broadcastEvent(*OrderEvent)
+	trackLocation(context.Context, *LocationPing) (*LocationResponse, error)
+	getReceipt(context.Context, *ReceiptRequest) (*ReceiptResponse, error)
+	getOrder(context.Context, *GetOrderRequest) (*Order, error)
+	sendReceipt(ctx context.Context, orderID int64, partnerCopy bool) error
 }
 
 type service struct {
 	repository          Repository
 	queue               pkg.Queue
+	storage             pkg.Storage
+	email               pkg.Email
+	payments            map[string]pkg.Payment // by bank driver
 	paymentCallbackURL  string
+	tipCallbackURL      string
+	cartCallbackURL     string
 	readyCallbackURL    string
 	notificationAgentID string
+	deliveryFeeShare    int64 // basis points of the delivery fee earned by the deliverer
+	watchers            *watchers
+	delivererSpeedKmh   float64 // average speed of the deliverers for the ETA
 }
 
-func NewService(repository Repository, queue pkg.Queue) Service {
+func NewService(repository Repository, queue pkg.Queue, storage pkg.Storage, email pkg.Email, payments map[string]pkg.Payment) Service {
+	deliveryFeeShare, err := strconv.ParseInt(os.Getenv("DELIVERY_FEE_SHARE"), 10, 64)
+	if err != nil || deliveryFeeShare < 0 || deliveryFeeShare > 10000 {
+		deliveryFeeShare = 8000
+	}
+	delivererSpeedKmh, err := strconv.ParseFloat(os.Getenv("DELIVERER_SPEED_KMH"), 64)
+	if err != nil || delivererSpeedKmh <= 0 {
+		delivererSpeedKmh = 20
+	}
 	return &service{
 		repository:          repository,
 		queue:               queue,
+		storage:             storage,
+		email:               email,
+		payments:            payments,
 		paymentCallbackURL:  os.Getenv("PAYMENT_CALLBACK_URL"),
+		tipCallbackURL:      os.Getenv("TIP_CALLBACK_URL"),
+		cartCallbackURL:     os.Getenv("CART_CALLBACK_URL"),
 		readyCallbackURL:    os.Getenv("READY_CALLBACK_URL"),
 		notificationAgentID: os.Getenv("NOTIFICATION_AGENT_ID"),
+		deliveryFeeShare:    deliveryFeeShare,
+		watchers:            newWatchers(),
+		delivererSpeedKmh:   delivererSpeedKmh,
 	}
 }
 
 func (s *service) createOrder(ctx context.Context, order *Order) (*CreateResponse, error) {
 	bank, err := s.repository.getBank(ctx, order.Paytype)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("unknown or disabled paytype: " + order.Paytype)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "s.repository.getBank")
 	}
 
+	payment, found := s.payments[bank.Driver]
+	if !found {
+		return nil, errors.New("no payment driver for the bank: " + bank.ID)
+	}
+
 	id, err := s.repository.createOrder(ctx, order)
+	if order.PromoCode != "" && errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("promo code can no longer be applied: " + order.PromoCode)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "s.repository.createOrder")
 	}
 
+	err = s.holdStock(ctx, id, order.ReservationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.holdStock")
+	}
+
+	session, err := payment.CreateCheckout(ctx, &pkg.Checkout{
+		OrderID:        id,
+		Amount:         order.TotalAmount,
+		Description:    order.PartnerBrand + ": " + order.OrderID,
+		CallbackURL:    s.paymentCallbackURL,
+		BankID:         bank.ID,
+		CallbackSecret: bank.CallbackSecret,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "payment.CreateCheckout")
+	}
+
+	err = s.repository.setCheckoutSession(ctx, id, session.SessionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setCheckoutSession")
+	}
+
 	resp := &CreateResponse{
 		OrderID:        id,
-		WebcheckoutURL: bank.WebcheckoutURL,
+		WebcheckoutURL: session.WebcheckoutURL,
 		CallbackURL:    s.paymentCallbackURL,
 	}
 	return resp, nil
@@ -70,6 +163,8 @@ func (s *service) payOrder(ctx context.Context, req *PayRequest) (*PayResponse,
 	if err != nil {
 		return nil, errors.Wrap(err, "s.queue.Publish")
 	}
+	s.publishEvent(ctx, &OrderEvent{OrderID: req.OrderID, Event: "paid", Status: "paid"})
+	s.requestReceipt(ctx, req.OrderID)
 
 	return &PayResponse{PaymentID: id}, nil
 }
@@ -79,11 +174,12 @@ func (s *service) pickupOrder(ctx context.Context, req *pickupRequest) error {
 	if err != nil {
 		return errors.Wrap(err, "s.repository.pickupOrder")
 	}
+	s.publishEvent(ctx, &OrderEvent{OrderID: req.OrderID, Event: "ready", Status: "ready"})
 	return nil
 }
 
 func (s *service) assignOrder(ctx context.Context, req *AssignRequest) (*AssignResponse, error) {
-	customerNotifToken, err := s.repository.assignOrder(ctx, req)
+	customerNotifToken, resp, err := s.repository.assignOrder(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "s.repository.assignOrder")
 	}
@@ -102,6 +198,1301 @@ func (s *service) assignOrder(ctx context.Context, req *AssignRequest) (*AssignR
 	if err != nil {
 		return nil, errors.Wrap(err, "s.queue.Publish")
 	}
+	s.publishEvent(ctx, &OrderEvent{
+		OrderID:     req.OrderID,
+		Event:       "delivering",
+		Status:      "delivering",
+		DelivererID: req.DelivererID,
+	})
+
+	return resp, nil
+}
+
+// updateOrderAddress changes the delivery address until the order is picked up, within the distance
+// the delivery fee is priced for. No deliverer is assigned to the order by then, the one assigned
+// at the pickup gets the new address.
+func (s *service) updateOrderAddress(ctx context.Context, req *UpdateAddressRequest) (*UpdateAddressResponse, error) {
+	pricing, err := s.repository.getOrderPricing(ctx, req.OrderID, req.CustomerID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("order not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getOrderPricing")
+	}
+	// the delivery fee is charged for the distance priced, the new address mustn't be farther
+	if pricing.OriginLatitude == 0 && pricing.OriginLongitude == 0 {
+		return nil, errors.New("the order isn't priced by the distance, its address can't be changed")
+	}
+	if req.DeliveryAddress.GetLatitude() == 0 && req.DeliveryAddress.GetLongitude() == 0 {
+		return nil, errors.New("the new address has no coordinates")
+	}
+	distance := DistanceKm(pricing.OriginLatitude, pricing.OriginLongitude,
+		req.DeliveryAddress.GetLatitude(), req.DeliveryAddress.GetLongitude())
+	if distance > pricing.DistanceKm {
+		return nil, errors.New("the new address is farther from the partner than the order is priced for")
+	}
+
+	updated, err := s.repository.updateOrderAddress(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.updateOrderAddress")
+	}
+
+	err = s.repository.saveHistory(ctx, req.OrderID, updated.Status, "address_updated", pkg.Map{
+		"old_address": updated.OldAddress,
+		"new_address": req.DeliveryAddress,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.saveHistory")
+	}
+
+	s.publishEvent(ctx, &OrderEvent{OrderID: req.OrderID, Event: "address_updated", Status: updated.Status})
+
+	return &UpdateAddressResponse{}, nil
+}
+
+func (s *service) getBank(ctx context.Context, req *GetBankRequest) (*Bank, error) {
+	bank, err := s.repository.getBank(ctx, req.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getBank")
+	}
+	return toBank(bank), nil
+}
+
+func (s *service) listBanks(ctx context.Context, req *ListBanksRequest) (*ListBanksResponse, error) {
+	banks, err := s.repository.listBanks(ctx, req.ActiveOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listBanks")
+	}
+	var resp = &ListBanksResponse{Banks: make([]*Bank, 0, len(banks))}
+	for _, bank := range banks {
+		resp.Banks = append(resp.Banks, toBank(bank))
+	}
+	return resp, nil
+}
+
+func (s *service) createBank(ctx context.Context, req *Bank) (*Bank, error) {
+	if _, found := s.payments[req.Driver]; !found {
+		return nil, errors.New("unknown payment driver: " + req.Driver)
+	}
+	bank := fromBank(req)
+	if bank.CallbackSecret == "" {
+		var secret = make([]byte, 32)
+		_, err := rand.Read(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "rand.Read")
+		}
+		bank.CallbackSecret = hex.EncodeToString(secret)
+	}
+	bank, err := s.repository.createBank(ctx, bank)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createBank")
+	}
+	return toBank(bank), nil
+}
+
+func (s *service) updateBank(ctx context.Context, req *Bank) (*Bank, error) {
+	if _, found := s.payments[req.Driver]; !found {
+		return nil, errors.New("unknown payment driver: " + req.Driver)
+	}
+	// an empty callback secret keeps the current one
+	bank, err := s.repository.updateBank(ctx, fromBank(req))
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.updateBank")
+	}
+	return toBank(bank), nil
+}
+
+func (s *service) setBankActive(ctx context.Context, req *SetBankActiveRequest) (*Bank, error) {
+	bank, err := s.repository.setBankActive(ctx, req.ID, req.Active)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setBankActive")
+	}
+	return toBank(bank), nil
+}
+
+func toBank(bank *bank) *Bank {
+	return &Bank{
+		ID:             bank.ID,
+		CallbackSecret: bank.CallbackSecret,
+		AllowedIPs:     bank.AllowedIPs,
+		Title:          bank.Title,
+		Driver:         bank.Driver,
+		WebcheckoutURL: bank.WebcheckoutURL,
+		Currencies:     bank.Currencies,
+		Active:         bank.Active,
+	}
+}
+
+func fromBank(req *Bank) *bank {
+	return &bank{
+		ID:             req.ID,
+		Title:          req.Title,
+		Driver:         req.Driver,
+		WebcheckoutURL: req.WebcheckoutURL,
+		CallbackSecret: req.CallbackSecret,
+		AllowedIPs:     req.AllowedIPs,
+		Currencies:     req.Currencies,
+		Active:         req.Active,
+	}
+}
+
+// reconcile cross-checks the orders paid since the last run against
+// the payments collection and the statuses reported by the payment providers.
+func (s *service) reconcile(ctx context.Context) error {
+	// an order paid after the payments are read is left to the next run
+	cutoff := time.Now()
+	payments, err := s.repository.getUnreconciledPayments(ctx)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.getUnreconciledPayments")
+	}
+
+	var paymentsByOrder = make(map[int64][]*paymentRecord)
+	var orderIDs = make([]int64, 0, len(payments))
+	var paymentIDs = make([]string, 0, len(payments))
+	for _, payment := range payments {
+		if _, found := paymentsByOrder[payment.OrderID]; !found {
+			orderIDs = append(orderIDs, payment.OrderID)
+		}
+		paymentsByOrder[payment.OrderID] = append(paymentsByOrder[payment.OrderID], payment)
+		paymentIDs = append(paymentIDs, payment.PaymentID)
+	}
+
+	orders, err := s.repository.getUnreconciledOrders(ctx, orderIDs, cutoff)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.getUnreconciledOrders")
+	}
+
+	var discrepancies = make([]*Discrepancy, 0)
+	var reconciledIDs = make([]int64, 0, len(orders))
+	for _, order := range orders {
+		records := paymentsByOrder[order.ID]
+		delete(paymentsByOrder, order.ID)
+		reconciledIDs = append(reconciledIDs, order.ID)
+
+		if order.PaidAmount > order.TotalAmount {
+			discrepancies = append(discrepancies, &Discrepancy{
+				OrderID:        order.ID,
+				BankID:         order.Paytype,
+				Kind:           "overpayment",
+				ExpectedAmount: order.TotalAmount,
+				ActualAmount:   order.PaidAmount,
+				Details:        "paid amount exceeds the order total",
+			})
+		}
+
+		if order.Status == "pending" || order.Status == "expired" || order.Status == "cancelled" {
+			for _, record := range records {
+				discrepancies = append(discrepancies, orphanPayment(record, "payment is recorded for an unpaid order"))
+			}
+			continue
+		}
+
+		if len(records) == 0 {
+			discrepancies = append(discrepancies, &Discrepancy{
+				OrderID:        order.ID,
+				BankID:         order.Paytype,
+				Kind:           "missing_payment",
+				ExpectedAmount: order.TotalAmount,
+				Details:        "order is " + order.Status + " but has no payment record",
+			})
+			continue
+		}
+
+		for _, record := range records {
+			discrepancy, err := s.checkProvider(ctx, record)
+			if err != nil {
+				return errors.Wrap(err, "s.checkProvider")
+			}
+			if discrepancy != nil {
+				discrepancies = append(discrepancies, discrepancy)
+			}
+		}
+	}
+
+	// the left payments point to orders that don't exist
+	for _, records := range paymentsByOrder {
+		for _, record := range records {
+			discrepancies = append(discrepancies, orphanPayment(record, "payment is recorded for an unknown order"))
+		}
+	}
+
+	err = s.repository.saveReconciliation(ctx, discrepancies, reconciledIDs, paymentIDs)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.saveReconciliation")
+	}
+	return nil
+}
+
+// checkProvider compares a payment record with the provider's side of the payment.
+func (s *service) checkProvider(ctx context.Context, record *paymentRecord) (*Discrepancy, error) {
+	bank, err := s.repository.getBank(ctx, record.BankID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getBank")
+	}
+	payment, found := s.payments[bank.Driver]
+	if !found {
+		return nil, errors.New("no payment driver for the bank: " + bank.ID)
+	}
+
+	status, err := payment.GetPaymentStatus(ctx, record.PaymentID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return &Discrepancy{
+			OrderID:        record.OrderID,
+			PaymentID:      record.PaymentID,
+			BankID:         record.BankID,
+			Kind:           "missing_payment",
+			ExpectedAmount: record.Amount,
+			Details:        "provider has no such payment",
+		}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "payment.GetPaymentStatus")
+	}
+
+	switch status.Status {
+	case "paid", "refunded", "partially_refunded":
+	default:
+		return &Discrepancy{
+			OrderID:        record.OrderID,
+			PaymentID:      record.PaymentID,
+			BankID:         record.BankID,
+			Kind:           "missing_payment",
+			ExpectedAmount: record.Amount,
+			Details:        "provider reports the payment as " + status.Status,
+		}, nil
+	}
+
+	// the orders of a cart share a payment, the provider knows only its whole amount
+	expected := record.Amount
+	if record.CheckoutAmount > 0 {
+		expected = record.CheckoutAmount
+	}
+	if status.Amount != expected {
+		return &Discrepancy{
+			OrderID:        record.OrderID,
+			PaymentID:      record.PaymentID,
+			BankID:         record.BankID,
+			Kind:           "amount_mismatch",
+			ExpectedAmount: expected,
+			ActualAmount:   status.Amount,
+			Details:        fmt.Sprintf("provider reports %d instead of the recorded %d", status.Amount, expected),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func orphanPayment(record *paymentRecord, details string) *Discrepancy {
+	return &Discrepancy{
+		OrderID:      record.OrderID,
+		PaymentID:    record.PaymentID,
+		BankID:       record.BankID,
+		Kind:         "orphan_payment",
+		ActualAmount: record.Amount,
+		Details:      details,
+	}
+}
+
+func (s *service) reconciliationReport(ctx context.Context, req *ReportRequest) (*ReportResponse, error) {
+	from, err := time.Parse(time.DateOnly, req.DateFrom)
+	if err != nil {
+		return nil, errors.Wrap(err, "time.Parse")
+	}
+	to, err := time.Parse(time.DateOnly, req.DateTo)
+	if err != nil {
+		return nil, errors.Wrap(err, "time.Parse")
+	}
+
+	discrepancies, err := s.repository.getDiscrepancies(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getDiscrepancies")
+	}
+	return &ReportResponse{Discrepancies: discrepancies}, nil
+}
+
+func (s *service) refundOrder(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	order, err := s.repository.getRefundableOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getRefundableOrder")
+	}
+	switch order.Status {
+	case "pending", "expired", "cancelled", "refunded":
+		return nil, errors.New("order can't be refunded in status: " + order.Status)
+	}
+
+	payment, err := s.repository.getPayment(ctx, req.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getPayment")
+	}
+
+	amount, err := refundAmount(order, payment.Refunds, req.Products)
+	if err != nil {
+		return nil, errors.Wrap(err, "refundAmount")
+	}
+
+	bank, err := s.repository.getBank(ctx, payment.BankID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getBank")
+	}
+	provider, found := s.payments[bank.Driver]
+	if !found {
+		return nil, errors.New("no payment driver for the bank: " + bank.ID)
+	}
+
+	// the refunds of the order run one at a time, each priced by the ones made before
+	status, err := s.repository.reserveRefund(ctx, req.OrderID, order.RefundedAmount, amount)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the order has been refunded meanwhile, try again")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.reserveRefund")
+	}
+
+	refundID, refundErr := provider.Refund(ctx, payment.PaymentID, amount)
+	if refundErr != nil {
+		err = s.repository.releaseRefund(ctx, req.OrderID, amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.releaseRefund")
+		}
+		return nil, errors.Wrap(refundErr, "provider.Refund")
+	}
+
+	refund := &refundRecord{
+		RefundID:  refundID,
+		Amount:    amount,
+		Products:  req.Products,
+		Reason:    req.Reason,
+		CreatedAt: time.Now(),
+	}
+	err = s.repository.refundOrder(ctx, req.OrderID, payment.PaymentID, status, refund)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.refundOrder")
+	}
+
+	err = s.repository.saveHistory(ctx, req.OrderID, status, "refunded", pkg.Map{
+		"refund_id": refundID,
+		"amount":    amount,
+		"products":  req.Products,
+		"reason":    req.Reason,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.saveHistory")
+	}
+
+	data, err := json.Marshal(notifications.Message{
+		AgentID: s.notificationAgentID,
+		Token:   order.CustomerNotifToken,
+		Title:   "Your Refund Is on Its Way",
+		Body:    fmt.Sprintf("We have refunded %d to your payment method. It may take a few days to appear on your statement.", amount),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	err = s.queue.Publish(ctx, "orders.refunded", data)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.queue.Publish")
+	}
+	s.publishEvent(ctx, &OrderEvent{OrderID: req.OrderID, Event: "refunded", Status: status})
+
+	return &RefundResponse{
+		RefundID: refundID,
+		Amount:   amount,
+		Status:   status,
+	}, nil
+}
+
+// refundAmount prices the refunded lines of the order with their tax,
+// or the whole remaining paid amount when no products are given.
+func refundAmount(order *refundableOrder, refunds []*refundRecord, products []*Product) (int64, error) {
+	remaining := order.PaidAmount - order.RefundedAmount
+	if len(products) == 0 {
+		if remaining <= 0 {
+			return 0, errors.New("nothing left to refund")
+		}
+		return remaining, nil
+	}
+
+	// the same product is on several lines with the variants and the modifiers, each priced on its own
+	var ordered = make(map[string]*Product, len(order.Products)) // by line
+	for _, p := range order.Products {
+		line := ProductLine(p)
+		if same, found := ordered[line]; found {
+			same.Quantity += p.Quantity
+			continue
+		}
+		ordered[line] = &Product{ID: p.ID, Quantity: p.Quantity, Price: p.Price}
+	}
+	var refunded = make(map[string]int32) // quantity by line
+	for _, refund := range refunds {
+		for _, product := range refund.Products {
+			refunded[ProductLine(product)] += product.Quantity
+		}
+	}
+
+	var amount int64
+	for _, product := range products {
+		line := ProductLine(product)
+		same, found := ordered[line]
+		if !found {
+			return 0, fmt.Errorf("product %d with the options given is not in the order", product.ID)
+		}
+		if product.Quantity <= 0 || refunded[line]+product.Quantity > same.Quantity {
+			return 0, fmt.Errorf("product %d: refunded quantity exceeds the ordered %d", product.ID, same.Quantity)
+		}
+		refunded[line] += product.Quantity
+		amount += int64(same.Price) * int64(product.Quantity)
+	}
+	// the tax paid for the products goes back too, the fees are refunded with the whole order only
+	if order.Pricing != nil {
+		// a promo discount is spread over the products in proportion to their price
+		if order.Pricing.Discount > 0 && order.Pricing.Subtotal > 0 {
+			amount -= (amount*order.Pricing.Discount + order.Pricing.Subtotal/2) / order.Pricing.Subtotal
+		}
+		amount += (amount*order.Pricing.TaxRate + 5000) / 10000
+	}
+
+	if amount > remaining {
+		return 0, errors.New("refund amount exceeds the remaining paid amount")
+	}
+	return amount, nil
+}
+
+// ProductLine tells the lines of an order apart by the product and the options it's ordered with,
+// the modifiers chosen in any order.
+func ProductLine(product *Product) string {
+	modifiers := slices.Clone(product.ModifierIDs)
+	slices.Sort(modifiers)
+	line := strconv.Itoa(int(product.ID)) + ":" + strconv.Itoa(int(product.VariantID))
+	for _, id := range modifiers {
+		line += "," + strconv.Itoa(int(id))
+	}
+	return line
+}
+
+func (s *service) cancelOrder(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	ids, err := s.repository.cancelOrder(ctx, req.OrderID, req.CustomerID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("only the customer's pending orders can be cancelled")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.cancelOrder")
+	}
+
+	err = s.releaseStock(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.releaseStock")
+	}
+	for _, id := range ids {
+		err = s.repository.saveHistory(ctx, id, "cancelled", "cancelled", nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.saveHistory")
+		}
+		s.publishEvent(ctx, &OrderEvent{OrderID: id, Event: "cancelled", Status: "cancelled"})
+	}
+	return &CancelResponse{}, nil
+}
+
+// expireOrders expires the orders left unpaid since `createdBefore`, releasing their promo codes.
+func (s *service) expireOrders(ctx context.Context, createdBefore time.Time) error {
+	ids, err := s.repository.expireOrders(ctx, createdBefore)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.expireOrders")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = s.releaseStock(ctx, ids)
+	if err != nil {
+		return errors.Wrap(err, "s.releaseStock")
+	}
+	for _, id := range ids {
+		err = s.repository.saveHistory(ctx, id, "expired", "expired", nil)
+		if err != nil {
+			return errors.Wrap(err, "s.repository.saveHistory")
+		}
+		s.publishEvent(ctx, &OrderEvent{OrderID: id, Event: "expired", Status: "expired"})
+	}
+	return nil
+}
+
+func (s *service) checkPromoCode(ctx context.Context, req *PromoRequest) (*Pricing, error) {
+	if req.GetPricing() == nil {
+		return nil, errors.New("order pricing is required")
+	}
+	code := strings.ToUpper(req.Code)
+	promo, err := s.repository.getPromoCode(ctx, code)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("unknown promo code: " + code)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getPromoCode")
+	}
+
+	used, err := s.repository.countRedemptions(ctx, code, req.CustomerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.countRedemptions")
+	}
+
+	discount, err := promoDiscount(promo, time.Now(), req.PartnerID, req.Pricing.Subtotal, used)
+	if err != nil {
+		return nil, errors.Wrap(err, "promoDiscount")
+	}
+	return applyDiscount(req.Pricing, discount), nil
+}
+
+// promoDiscount checks the promo code against the order and returns the discount it gives.
+// `used` is the number of times the customer has already redeemed the code.
+func promoDiscount(promo *promoCode, now time.Time, partnerID int32, subtotal int64, used int32) (int64, error) {
+	if !promo.Active || now.Before(promo.ValidFrom) || (promo.ValidTo != nil && !now.Before(*promo.ValidTo)) {
+		return 0, errors.New("promo code is not active")
+	}
+	if promo.UsageLimit > 0 && promo.UsedCount >= promo.UsageLimit {
+		return 0, errors.New("promo code usage limit is reached")
+	}
+	if promo.PerUserLimit > 0 && used >= promo.PerUserLimit {
+		return 0, errors.New("promo code has already been used")
+	}
+	if subtotal < promo.MinOrderAmount {
+		return 0, fmt.Errorf("promo code requires an order of at least %d", promo.MinOrderAmount)
+	}
+	if len(promo.PartnerIDs) > 0 && !slices.Contains(promo.PartnerIDs, partnerID) {
+		return 0, errors.New("promo code is not valid for this partner")
+	}
 
-	return &AssignResponse{}, nil
+	var discount int64
+	switch promo.Kind {
+	case "percent":
+		discount = (subtotal*promo.Value + 5000) / 10000
+		if promo.MaxDiscount > 0 {
+			discount = min(discount, promo.MaxDiscount)
+		}
+	case "fixed":
+		discount = promo.Value
+	default:
+		return 0, errors.New("unknown promo code kind: " + promo.Kind)
+	}
+	return min(discount, subtotal), nil
+}
+
+// applyDiscount returns a copy of the pricing with the discount taken off the taxed amount.
+func applyDiscount(pricing *Pricing, discount int64) *Pricing {
+	var p = &Pricing{
+		Subtotal:        pricing.Subtotal,
+		DeliveryFee:     pricing.DeliveryFee,
+		SmallOrderFee:   pricing.SmallOrderFee,
+		ServiceFee:      pricing.ServiceFee,
+		DistanceKm:      pricing.DistanceKm,
+		OriginLatitude:  pricing.OriginLatitude,
+		OriginLongitude: pricing.OriginLongitude,
+		TaxRate:         pricing.TaxRate,
+		Discount:        discount,
+	}
+	taxable := p.Subtotal + p.DeliveryFee + p.SmallOrderFee + p.ServiceFee - p.Discount
+	p.Tax = (taxable*p.TaxRate + 5000) / 10000
+	p.Total = taxable + p.Tax
+	return p
+}
+
+func (s *service) createPromoCode(ctx context.Context, req *PromoCode) (*PromoCode, error) {
+	promo := &promoCode{
+		Code:           strings.ToUpper(req.Code),
+		Kind:           req.Kind,
+		Value:          req.Value,
+		MaxDiscount:    req.MaxDiscount,
+		MinOrderAmount: req.MinOrderAmount,
+		PartnerIDs:     req.PartnerIDs,
+		ValidFrom:      time.Now(),
+		UsageLimit:     req.UsageLimit,
+		PerUserLimit:   req.PerUserLimit,
+		Active:         req.Active,
+	}
+	if promo.PartnerIDs == nil {
+		promo.PartnerIDs = []int32{}
+	}
+	switch {
+	case promo.Kind == "percent" && (promo.Value <= 0 || promo.Value > 10000):
+		return nil, errors.New("percent value must be between 1 and 10000 basis points")
+	case promo.Kind == "fixed" && promo.Value <= 0:
+		return nil, errors.New("fixed value must be positive")
+	case promo.Kind != "percent" && promo.Kind != "fixed":
+		return nil, errors.New("unknown promo code kind: " + promo.Kind)
+	}
+
+	if req.ValidFrom != "" {
+		validFrom, err := time.Parse(time.RFC3339, req.ValidFrom)
+		if err != nil {
+			return nil, errors.Wrap(err, "time.Parse validFrom")
+		}
+		promo.ValidFrom = validFrom
+	}
+	if req.ValidTo != "" {
+		validTo, err := time.Parse(time.RFC3339, req.ValidTo)
+		if err != nil {
+			return nil, errors.Wrap(err, "time.Parse validTo")
+		}
+		if !validTo.After(promo.ValidFrom) {
+			return nil, errors.New("validTo must be after validFrom")
+		}
+		promo.ValidTo = &validTo
+	}
+
+	err := s.repository.createPromoCode(ctx, promo)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createPromoCode")
+	}
+
+	resp := &PromoCode{
+		Code:           promo.Code,
+		Kind:           promo.Kind,
+		Value:          promo.Value,
+		MaxDiscount:    promo.MaxDiscount,
+		MinOrderAmount: promo.MinOrderAmount,
+		PartnerIDs:     promo.PartnerIDs,
+		ValidFrom:      promo.ValidFrom.Format(time.RFC3339),
+		UsageLimit:     promo.UsageLimit,
+		PerUserLimit:   promo.PerUserLimit,
+		Active:         promo.Active,
+	}
+	if promo.ValidTo != nil {
+		resp.ValidTo = promo.ValidTo.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+func (s *service) completeOrder(ctx context.Context, req *CompleteRequest) (*CompleteResponse, error) {
+	order, err := s.repository.completeOrder(ctx, req.OrderID, req.DelivererID, s.deliveryFeeShare)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("only the deliverer's orders on the way can be completed")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.completeOrder")
+	}
+
+	err = s.repository.saveHistory(ctx, req.OrderID, "delivered", "delivered", pkg.Map{
+		"deliverer_id": req.DelivererID,
+		"earned":       order.Earned,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.saveHistory")
+	}
+
+	data, err := json.Marshal(notifications.Message{
+		AgentID: s.notificationAgentID,
+		Token:   order.CustomerNotifToken,
+		Title:   "Your Order Is Delivered",
+		Body:    "Enjoy your order! You can thank your deliverer with a tip in the app.",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	err = s.queue.Publish(ctx, "orders.delivered", data)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.queue.Publish")
+	}
+	s.publishEvent(ctx, &OrderEvent{
+		OrderID:     req.OrderID,
+		Event:       "delivered",
+		Status:      "delivered",
+		DelivererID: req.DelivererID,
+	})
+
+	return &CompleteResponse{Earned: order.Earned}, nil
+}
+
+// addTip opens a checkout for a tip to the deliverer of a delivered order, with the bank the order was paid with.
+func (s *service) addTip(ctx context.Context, req *TipRequest) (*CreateResponse, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("tip amount must be positive")
+	}
+	order, err := s.repository.getTippableOrder(ctx, req.OrderID, req.CustomerID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("only the customer's delivered orders can be tipped")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getTippableOrder")
+	}
+
+	bank, err := s.repository.getBank(ctx, order.Paytype)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getBank")
+	}
+	payment, found := s.payments[bank.Driver]
+	if !found {
+		return nil, errors.New("no payment driver for the bank: " + bank.ID)
+	}
+
+	tipID, err := s.repository.createTip(ctx, req.OrderID, req.Amount)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createTip")
+	}
+
+	session, err := payment.CreateCheckout(ctx, &pkg.Checkout{
+		OrderID:        req.OrderID,
+		Amount:         req.Amount,
+		Description:    order.PartnerBrand + ": tip for the delivery",
+		CallbackURL:    s.tipCallbackURL,
+		BankID:         bank.ID,
+		CallbackSecret: bank.CallbackSecret,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "payment.CreateCheckout")
+	}
+
+	err = s.repository.setTipCheckoutSession(ctx, tipID, session.SessionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setTipCheckoutSession")
+	}
+
+	return &CreateResponse{
+		OrderID:        req.OrderID,
+		WebcheckoutURL: session.WebcheckoutURL,
+		CallbackURL:    s.tipCallbackURL,
+	}, nil
+}
+
+func (s *service) payTip(ctx context.Context, req *PayRequest) (*PayResponse, error) {
+	tip, err := s.repository.payTip(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.payTip")
+	}
+
+	err = s.repository.saveHistory(ctx, req.OrderID, "delivered", "tipped", pkg.Map{
+		"payment_id": req.PaymentID,
+		"amount":     tip.Amount,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.saveHistory")
+	}
+
+	data, err := json.Marshal(notifications.Message{
+		AgentID: s.notificationAgentID,
+		Token:   tip.DelivererNotifToken,
+		Title:   "You Got a Tip",
+		Body:    fmt.Sprintf("A customer has tipped you %d for the delivery #%d.", tip.Amount, req.OrderID),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	err = s.queue.Publish(ctx, "orders.tipped", data)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.queue.Publish")
+	}
+
+	return &PayResponse{PaymentID: req.PaymentID}, nil
+}
+
+// createCart saves the orders of a multi-partner cart as linked child orders
+// and opens a single checkout session for all of them.
+func (s *service) createCart(ctx context.Context, cart *Cart) (*CartResponse, error) {
+	if len(cart.Orders) == 0 {
+		return nil, errors.New("cart has no orders")
+	}
+	var totalAmount int64
+	for _, order := range cart.Orders {
+		if order.PromoCode != "" {
+			return nil, errors.New("promo codes are not supported in a multi-partner cart")
+		}
+		// the children are paid and refunded with the cart's payment
+		order.CustomerID = cart.CustomerID
+		order.Paytype = cart.Paytype
+		totalAmount += order.TotalAmount
+	}
+
+	bank, err := s.repository.getBank(ctx, cart.Paytype)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("unknown or disabled paytype: " + cart.Paytype)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getBank")
+	}
+
+	payment, found := s.payments[bank.Driver]
+	if !found {
+		return nil, errors.New("no payment driver for the bank: " + bank.ID)
+	}
+
+	checkoutID, orderIDs, err := s.repository.createCart(ctx, cart, totalAmount)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createCart")
+	}
+
+	for idx, id := range orderIDs {
+		err = s.holdStock(ctx, id, cart.Orders[idx].ReservationID)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.holdStock")
+		}
+	}
+
+	session, err := payment.CreateCheckout(ctx, &pkg.Checkout{
+		OrderID:        checkoutID,
+		Amount:         totalAmount,
+		Description:    fmt.Sprintf("%d partners: %s", len(cart.Orders), cart.CartID),
+		CallbackURL:    s.cartCallbackURL,
+		BankID:         bank.ID,
+		CallbackSecret: bank.CallbackSecret,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "payment.CreateCheckout")
+	}
+
+	err = s.repository.setCartCheckoutSession(ctx, checkoutID, session.SessionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setCartCheckoutSession")
+	}
+
+	return &CartResponse{
+		CheckoutID:     checkoutID,
+		OrderIDs:       orderIDs,
+		TotalAmount:    totalAmount,
+		WebcheckoutURL: session.WebcheckoutURL,
+		CallbackURL:    s.cartCallbackURL,
+	}, nil
+}
+
+// payCart marks the cart and all its child orders paid and sends each child
+// to its partner, from there on every child follows its own lifecycle.
+func (s *service) payCart(ctx context.Context, req *PayRequest) (*PayResponse, error) {
+	paidOrders, err := s.repository.payCart(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.payCart")
+	}
+
+	for _, order := range paidOrders {
+		order.CallbackURL = s.readyCallbackURL
+		data, err := json.Marshal(order)
+		if err != nil {
+			return nil, errors.Wrap(err, "json.Marshal")
+		}
+		err = s.queue.Publish(ctx, "orders.paid", data)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.queue.Publish")
+		}
+		s.publishEvent(ctx, &OrderEvent{OrderID: order.OrderID, Event: "paid", Status: "paid"})
+		s.requestReceipt(ctx, order.OrderID)
+	}
+
+	return &PayResponse{PaymentID: req.PaymentID}, nil
+}
+
+// getEarnings returns the deliverer's earnings for each day of the week ending at req.Date.
+func (s *service) getEarnings(ctx context.Context, req *EarningsRequest) (*EarningsResponse, error) {
+	var to = time.Now()
+	if req.Date != "" {
+		date, err := time.ParseInLocation(time.DateOnly, req.Date, time.Local)
+		if err != nil {
+			return nil, errors.Wrap(err, "time.Parse")
+		}
+		to = date
+	}
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	from := to.AddDate(0, 0, -7)
+
+	earnings, err := s.repository.getEarnings(ctx, req.DelivererID, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getEarnings")
+	}
+
+	pendingPayout, err := s.repository.getPendingPayout(ctx, req.DelivererID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getPendingPayout")
+	}
+
+	var resp = &EarningsResponse{
+		Days:          make([]*Earnings, 0, 7),
+		Week:          &Earnings{},
+		PendingPayout: pendingPayout,
+	}
+	for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+		var day = &Earnings{Date: date.Format(time.DateOnly)}
+		for _, earned := range earnings {
+			if earned.Date == day.Date {
+				day = earned
+				break
+			}
+		}
+		resp.Days = append(resp.Days, day)
+		resp.Week.Deliveries += day.Deliveries
+		resp.Week.DeliveryFees += day.DeliveryFees
+		resp.Week.Tips += day.Tips
+		resp.Week.Total += day.Total
+	}
+	return resp, nil
+}
+
+func (s *service) createReview(ctx context.Context, req *Review) (*Review, error) {
+	if req.PartnerRating < 1 || req.PartnerRating > 5 || req.DelivererRating < 1 || req.DelivererRating > 5 {
+		return nil, errors.New("ratings must be from 1 to 5 stars")
+	}
+	if len(req.Comment) > 1000 {
+		return nil, errors.New("comment is too long")
+	}
+
+	review, err := s.repository.createReview(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("only the customer's delivered orders can be reviewed, once")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createReview")
+	}
+
+	err = s.publishPartnerRating(ctx, review.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.publishPartnerRating")
+	}
+	return review, nil
+}
+
+func (s *service) listReviews(ctx context.Context, req *ListReviewsRequest) (*ListReviewsResponse, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	reviews, err := s.repository.listReviews(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listReviews")
+	}
+
+	var resp = &ListReviewsResponse{Reviews: reviews}
+	switch {
+	case req.PartnerID != 0:
+		rating, err := s.repository.getPartnerRating(ctx, req.PartnerID)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.getPartnerRating")
+		}
+		if rating.RatingCount > 0 {
+			resp.Rating = float64(rating.RatingSum) / float64(rating.RatingCount)
+		}
+		resp.RatingCount = rating.RatingCount
+	case req.DelivererID != "":
+		resp.Rating, resp.RatingCount, err = s.repository.getDelivererRating(ctx, req.DelivererID)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.getDelivererRating")
+		}
+	}
+	return resp, nil
+}
+
+// setReviewHidden hides an abusive review, or shows it back, and recounts the partner's rating.
+func (s *service) setReviewHidden(ctx context.Context, req *SetReviewHiddenRequest) (*Review, error) {
+	review, err := s.repository.setReviewHidden(ctx, req.OrderID, req.Hidden)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setReviewHidden")
+	}
+
+	err = s.publishPartnerRating(ctx, review.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.publishPartnerRating")
+	}
+	return review, nil
+}
+
+// publishPartnerRating sends the partner's recounted rating to the partners service.
+// The totals are sent instead of the change, so a redelivered message does no harm.
+func (s *service) publishPartnerRating(ctx context.Context, partnerID int32) error {
+	rating, err := s.repository.getPartnerRating(ctx, partnerID)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.getPartnerRating")
+	}
+
+	data, err := json.Marshal(rating)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	err = s.queue.Publish(ctx, "reviews.partner_rated", data)
+	if err != nil {
+		return errors.Wrap(err, "s.queue.Publish")
+	}
+	return nil
+}
+
+// trackLocation saves a GPS ping of the deliverer of an order on the way and estimates
+// the time left to the delivery address, the customer sees both on the order's tracking stream.
+func (s *service) trackLocation(ctx context.Context, req *LocationPing) (*LocationResponse, error) {
+	order, err := s.repository.getDeliveringOrder(ctx, req.OrderID, req.DelivererID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("only the deliverer's orders on the way can be tracked")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getDeliveringOrder")
+	}
+
+	address := order.DeliveryAddress
+	distance := DistanceKm(req.Latitude, req.Longitude, address.Latitude, address.Longitude)
+	resp := &LocationResponse{
+		CustomerID: order.CustomerID,
+		DistanceKm: distance,
+		EtaMinutes: etaMinutes(distance, s.delivererSpeedKmh),
+	}
+
+	err = s.repository.saveLocation(ctx, req, resp.EtaMinutes)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.saveLocation")
+	}
+
+	s.publishEvent(ctx, &OrderEvent{
+		OrderID:     req.OrderID,
+		Event:       "location",
+		Status:      "delivering",
+		DelivererID: req.DelivererID,
+		EtaMinutes:  resp.EtaMinutes,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	})
+
+	return resp, nil
+}
+
+// getOrder returns the customer's order as it was placed, to be reordered.
+func (s *service) getOrder(ctx context.Context, req *GetOrderRequest) (*Order, error) {
+	order, err := s.repository.getCustomerOrder(ctx, req.OrderID, req.CustomerID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the customer has no such order")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getCustomerOrder")
+	}
+	return order, nil
+}
+
+// holdStock asks the partners service to hold the stock reserved when the order was checked
+// until the order is paid or released, otherwise it's released when the reservation expires.
+func (s *service) holdStock(ctx context.Context, orderID int64, reservationID string) error {
+	if reservationID == "" {
+		return nil
+	}
+	data, err := json.Marshal(&StockReservation{OrderID: orderID, ReservationID: reservationID})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	err = s.queue.Publish(ctx, "orders.created", data)
+	if err != nil {
+		return errors.Wrap(err, "s.queue.Publish")
+	}
+	return nil
+}
+
+// releaseStock puts the stock held for the cancelled or expired orders back on sale.
+func (s *service) releaseStock(ctx context.Context, orderIDs []int64) error {
+	data, err := json.Marshal(&ReleasedOrders{OrderIDs: orderIDs})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	err = s.queue.Publish(ctx, "orders.released", data)
+	if err != nil {
+		return errors.Wrap(err, "s.queue.Publish")
+	}
+	return nil
+}
+
+// requestReceipt queues the receipt of a paid order to be emailed. It's best-effort,
+// the customer can download the receipt anyway, it's rendered on the first request.
+// The partner's copy is queued apart, so a failure of one isn't retried by emailing the other again.
+func (s *service) requestReceipt(ctx context.Context, orderID int64) {
+	data, err := json.Marshal(&ReceiptRequest{OrderID: orderID})
+	if err != nil {
+		return
+	}
+	s.queue.Publish(ctx, "orders.receipts", data)
+	s.queue.Publish(ctx, "orders.partner_receipts", data)
+}
+
+// sendReceipt emails the receipt of a paid order to the customer, or a copy of it to the partner.
+func (s *service) sendReceipt(ctx context.Context, orderID int64, partnerCopy bool) error {
+	rec, err := s.repository.getReceipt(ctx, orderID)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.getReceipt")
+	}
+
+	receiptURL, err := s.issueReceipt(ctx, rec)
+	if err != nil {
+		return errors.Wrap(err, "s.issueReceipt")
+	}
+
+	subject := "Receipt for Order " + rec.OrderNumber
+	body := fmt.Sprintf("Order %s from %s is paid: %s via %s.\nThe receipt is available at %s",
+		rec.OrderNumber, rec.PartnerTitle, formatAmount(rec.PaidAmount), rec.Paytype, receiptURL)
+	to := rec.CustomerEmail
+	if partnerCopy {
+		to = rec.PartnerEmail
+	}
+	if to == "" {
+		return nil
+	}
+	err = s.email.Send(ctx, to, subject, body)
+	if err != nil {
+		return errors.Wrap(err, "s.email.Send")
+	}
+	return nil
+}
+
+// getReceipt returns the link to the receipt of the customer's paid order.
+func (s *service) getReceipt(ctx context.Context, req *ReceiptRequest) (*ReceiptResponse, error) {
+	rec, err := s.repository.getReceipt(ctx, req.OrderID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the order isn't paid")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getReceipt")
+	}
+	if rec.CustomerID != req.CustomerID {
+		return nil, errors.New("only the customer's receipts can be downloaded")
+	}
+
+	receiptURL, err := s.issueReceipt(ctx, rec)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.issueReceipt")
+	}
+	return &ReceiptResponse{ReceiptURL: receiptURL}, nil
+}
+
+// issueReceipt renders the receipt and puts it in the storage once, then returns the stored one.
+func (s *service) issueReceipt(ctx context.Context, rec *receipt) (string, error) {
+	if rec.ReceiptURL != "" {
+		return rec.ReceiptURL, nil
+	}
+
+	payment, err := s.repository.getPayment(ctx, rec.OrderID)
+	if err != nil {
+		return "", errors.Wrap(err, "s.repository.getPayment")
+	}
+	rec.PaymentID = payment.PaymentID
+
+	// the link is sent by email, so the name must not be guessable
+	var name = make([]byte, 16)
+	_, err = rand.Read(name)
+	if err != nil {
+		return "", errors.Wrap(err, "rand.Read")
+	}
+
+	data := renderReceipt(rec, time.Now())
+	receiptURL, err := s.storage.Upload(ctx, pkg.UploadInput{
+		File:        bytes.NewReader(data),
+		Name:        hex.EncodeToString(name) + ".pdf",
+		Size:        int64(len(data)),
+		ContentType: "application/pdf",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "s.storage.Upload")
+	}
+
+	storedURL, err := s.repository.setReceiptURL(ctx, rec.OrderID, receiptURL)
+	if err != nil {
+		err = errors.Wrap(err, "s.repository.setReceiptURL")
+		err2 := s.storage.Delete(ctx, receiptURL)
+		if err2 != nil {
+			err2 = errors.Wrap(err2, "s.storage.Delete")
+			err = errors.Wrap(err, err2.Error())
+		}
+		return "", err
+	}
+	// another request stored its receipt meanwhile
+	if storedURL != receiptURL {
+		err = s.storage.Delete(ctx, receiptURL)
+		if err != nil {
+			return "", errors.Wrap(err, "s.storage.Delete")
+		}
+	}
+	rec.ReceiptURL = storedURL
+	return storedURL, nil
+}
+
+// publishEvent feeds the order trackers of every instance (see watchOrder). A lost event
+// only delays the customer's view until the next one, so it doesn't fail the change itself.
+func (s *service) publishEvent(ctx context.Context, event *OrderEvent) {
+	if event.CreatedAt == "" {
+		event.CreatedAt = time.Now().Format(time.RFC3339)
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	s.queue.Publish(ctx, "orders.events", data)
+}
+
+// broadcastEvent hands an event received from orders.events to the streams watching its order on this instance.
+func (s *service) broadcastEvent(event *OrderEvent) {
+	s.watchers.broadcast(event)
+}
+
+// watchOrder sends the current state of the customer's order and then every change of it,
+// until the order is finished or ctx is cancelled.
+func (s *service) watchOrder(ctx context.Context, req *WatchRequest, send func(*OrderEvent) error) error {
+	// subscribe before reading the state, so no change is missed in between
+	events := s.watchers.add(req.OrderID)
+	defer s.watchers.remove(req.OrderID, events)
+
+	current, err := s.repository.getOrderEvent(ctx, req.OrderID, req.CustomerID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return errors.New("only the customer's orders can be watched")
+	}
+	if err != nil {
+		return errors.Wrap(err, "s.repository.getOrderEvent")
+	}
+
+	event := current
+	for {
+		err = send(event)
+		if err != nil {
+			return errors.Wrap(err, "send")
+		}
+		if slices.Contains(finalStatuses, event.Status) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case event = <-events:
+		}
+	}
+}
+
+// finalStatuses end the watch of an order.
+var finalStatuses = []string{"delivered", "cancelled", "expired", "refunded"}
+
+// watchers fans the order events out to the streams watching them.
+type watchers struct {
+	mu      sync.Mutex
+	byOrder map[int64]map[chan *OrderEvent]struct{}
+}
+
+func newWatchers() *watchers {
+	return &watchers{byOrder: make(map[int64]map[chan *OrderEvent]struct{})}
+}
+
+func (w *watchers) add(orderID int64) chan *OrderEvent {
+	ch := make(chan *OrderEvent, 16)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.byOrder[orderID] == nil {
+		w.byOrder[orderID] = make(map[chan *OrderEvent]struct{})
+	}
+	w.byOrder[orderID][ch] = struct{}{}
+	return ch
+}
+
+func (w *watchers) remove(orderID int64, ch chan *OrderEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.byOrder[orderID], ch)
+	if len(w.byOrder[orderID]) == 0 {
+		delete(w.byOrder, orderID)
+	}
+}
+
+func (w *watchers) broadcast(event *OrderEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.byOrder[event.OrderID] {
+		select {
+		case ch <- event:
+		default: // a stuck stream skips the event rather than blocking the queue
+		}
+	}
 }
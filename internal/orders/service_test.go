@@ -1,10 +1,15 @@
 package orders
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
+	"github.com/shahzodshafizod/gocloud/pkg"
 	"github.com/shahzodshafizod/gocloud/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -16,6 +21,9 @@ type testConfig struct {
 	postgres *mocks.MockPostgres
 	nosql    *mocks.MockNoSQL
 	queue    *mocks.MockQueue
+	storage  *mocks.MockStorage
+	email    *mocks.MockEmail
+	payment  *mocks.MockPayment
 	service  Service
 }
 
@@ -27,9 +35,16 @@ func __SetupTestConfig(t *testing.T) *testConfig {
 		postgres: mocks.NewMockPostgres(ctrl),
 		nosql:    mocks.NewMockNoSQL(ctrl),
 		queue:    mocks.NewMockQueue(ctrl),
+		storage:  mocks.NewMockStorage(ctrl),
+		email:    mocks.NewMockEmail(ctrl),
+		payment:  mocks.NewMockPayment(ctrl),
 	}
+	// the order trackers' feed and the receipts are best-effort, they never fail a test case
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.events", gomock.Any()).Return(nil).AnyTimes()
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.receipts", gomock.Any()).Return(nil).AnyTimes()
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.partner_receipts", gomock.Any()).Return(nil).AnyTimes()
 	repository := NewRepository(cfg.postgres, cfg.nosql)
-	cfg.service = NewService(repository, cfg.queue)
+	cfg.service = NewService(repository, cfg.queue, cfg.storage, cfg.email, map[string]pkg.Payment{"simulator": cfg.payment})
 	return cfg
 }
 
@@ -39,7 +54,14 @@ func TestCreateOrder(t *testing.T) {
 	defer cfg.ctrl.Finish()
 
 	ctx := context.Background()
-	order := &Order{}
+	order := &Order{Paytype: "visa", TotalAmount: 300}
+	scanBank := func(driver string) func(dest ...any) error {
+		return func(dest ...any) error {
+			*dest[0].(*string) = "visa"
+			*dest[2].(*string) = driver
+			return nil
+		}
+	}
 
 	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
 
@@ -49,19 +71,47 @@ func TestCreateOrder(t *testing.T) {
 	_, err := cfg.service.createOrder(ctx, order)
 	assert.True(t, errors.Is(err, targetError))
 
-	// Test case #2
+	// Test case #2: No driver for the bank
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBank("unknown"))
+	_, err = cfg.service.createOrder(ctx, order)
+	assert.Error(t, err)
+
+	// Test case #3
 	targetError = errors.New("createOrder error")
-	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBank("simulator"))
 	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
 	_, err = cfg.service.createOrder(ctx, order)
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil).AnyTimes()
+	// Test case #4
+	targetError = errors.New("CreateCheckout error")
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBank("simulator"))
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.payment.EXPECT().CreateCheckout(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err = cfg.service.createOrder(ctx, order)
+	assert.True(t, errors.Is(err, targetError))
 
-	// Test case #3: Success
+	cfg.payment.EXPECT().CreateCheckout(gomock.Any(), gomock.Any()).Return(&pkg.CheckoutSession{
+		SessionID:      "test session id",
+		WebcheckoutURL: "http://localhost:4405/checkout/test-session-id",
+	}, nil).AnyTimes()
+
+	// Test case #5
+	targetError = errors.New("setCheckoutSession error")
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBank("simulator"))
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.postgres.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(targetError)
+	_, err = cfg.service.createOrder(ctx, order)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.postgres.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// Test case #6: Success
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBank("simulator"))
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
 	resp, err := cfg.service.createOrder(ctx, order)
 	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+	assert.Equal(t, "http://localhost:4405/checkout/test-session-id", resp.WebcheckoutURL)
 }
 
 // go test -v -count=1 ./internal/orders/ -run ^TestPayOrder$
@@ -126,6 +176,70 @@ func TestPayOrder(t *testing.T) {
 	assert.NotNil(t, resp)
 }
 
+// go test -v -count=1 ./internal/orders/ -run ^TestPayCart$
+func TestPayCart(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &PayRequest{
+		OrderID:    7,
+		PaymentID:  "8baec4ff-e08a-4d4a-bf5e-1e8dc4dc9f55",
+		PaidAmount: 800,
+		BankID:     "visa",
+	}
+
+	tx := mocks.NewMockTx(cfg.ctrl)
+	rows := mocks.NewMockRows(cfg.ctrl)
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil).AnyTimes()
+	tx.EXPECT().Rollback(gomock.Any()).AnyTimes()
+
+	// Test case #1
+	targetError := errors.New("payCart Query error")
+	tx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.payCart(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	tx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil).AnyTimes()
+	rows.EXPECT().Close().AnyTimes()
+
+	// Test case #2: Cart is not pending or one of its orders is cancelled
+	rows.EXPECT().Next().Return(false)
+	_, err = cfg.service.payCart(ctx, req)
+	assert.True(t, errors.Is(err, pkg.ErrNoRows))
+
+	// Test case #3: Success, every child order gets its payment record and is sent to its partner
+	children := []struct {
+		id        int64
+		amount    int64
+		partnerID int
+	}{{id: 11, amount: 500, partnerID: 1}, {id: 12, amount: 300, partnerID: 2}}
+	for _, child := range children {
+		rows.EXPECT().Next().Return(true)
+		rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = child.id
+			*dest[1].(*int64) = child.amount
+			*dest[3].(*int) = child.partnerID
+			return nil
+		})
+	}
+	rows.EXPECT().Next().Return(false)
+	for _, child := range children {
+		cfg.nosql.EXPECT().Insert(gomock.Any(), "payments", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, item pkg.Map) (string, error) {
+				assert.Equal(t, child.id, item["order_id"])
+				assert.Equal(t, child.amount, item["amount"])
+				assert.Equal(t, req.PaidAmount, item["checkout_amount"])
+				return "", nil
+			})
+	}
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.paid", gomock.Any()).Return(nil).Times(len(children))
+	resp, err := cfg.service.payCart(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, req.PaymentID, resp.PaymentID)
+}
+
 // go test -v -count=1 ./internal/orders/ -run ^TestPickupOrder$
 func TestPickupOrder(t *testing.T) {
 	cfg := __SetupTestConfig(t)
@@ -133,8 +247,12 @@ func TestPickupOrder(t *testing.T) {
 
 	ctx := context.Background()
 	req := &pickupRequest{
-		OrderID:       1,
-		PickupAddress: "London, Big Ben",
+		OrderID: 1,
+		PickupAddress: &Address{
+			Street:     "Westminster",
+			City:       "London",
+			PostalCode: "SW1A 0AA",
+		},
 	}
 
 	// Test case #1
@@ -184,3 +302,777 @@ func TestAssignOrder(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 }
+
+// go test -v -count=1 ./internal/orders/ -run ^TestUpdateOrderAddress$
+func TestUpdateOrderAddress(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &UpdateAddressRequest{
+		OrderID:    1,
+		CustomerID: "0258dc6d-cc4f-418d-a9a1-62a474d86bb2",
+		DeliveryAddress: &Address{
+			Street:     "Rudaki Ave 1",
+			City:       "Dushanbe",
+			PostalCode: "734025",
+			Latitude:   38.5737,
+			Longitude:  68.7738,
+		},
+	}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// the order is priced for `distanceKm` from the partner, the new address is ~1.9 km away
+	scanPricing := func(distanceKm float64) {
+		cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			pricing := dest[0].(*Pricing)
+			pricing.OriginLatitude, pricing.OriginLongitude = 38.5598, 68.7870
+			pricing.DistanceKm = distanceKm
+			return nil
+		})
+	}
+
+	// Test case #1
+	targetError := errors.New("getOrderPricing error")
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	_, err := cfg.service.updateOrderAddress(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #2: The order isn't priced from the partner's location
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	_, err = cfg.service.updateOrderAddress(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #3: The new address is farther than priced, it'd be delivered for the nearer one's fee
+	scanPricing(1.5)
+	_, err = cfg.service.updateOrderAddress(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #4: The new address has no coordinates, its distance can't be checked
+	scanPricing(2)
+	_, err = cfg.service.updateOrderAddress(ctx, &UpdateAddressRequest{
+		OrderID:         req.OrderID,
+		CustomerID:      req.CustomerID,
+		DeliveryAddress: &Address{Street: "Rudaki Ave 1", City: "Dushanbe", PostalCode: "734025"},
+	})
+	assert.EqualError(t, err, "the new address has no coordinates")
+
+	// Test case #5: Not the owner or already picked up
+	targetError = errors.New("updateOrderAddress error")
+	scanPricing(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	_, err = cfg.service.updateOrderAddress(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #6
+	targetError = errors.New("saveHistory error")
+	scanPricing(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("", targetError)
+	_, err = cfg.service.updateOrderAddress(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #7: Success
+	scanPricing(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("", nil)
+	resp, err := cfg.service.updateOrderAddress(ctx, req)
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestReconcile$
+func TestReconcile(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	rows := mocks.NewMockRows(cfg.ctrl)
+	tx := mocks.NewMockTx(cfg.ctrl)
+
+	// Test case #1
+	targetError := errors.New("GetItems error")
+	cfg.nosql.EXPECT().GetItems(gomock.Any(), "payments", gomock.Any()).Return(nil, targetError)
+	err := cfg.service.reconcile(ctx)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #2: Every kind of discrepancy is detected
+	cfg.nosql.EXPECT().GetItems(gomock.Any(), "payments", gomock.Any()).Return([]pkg.Map{
+		{"payment_id": "p1", "order_id": 1, "amount": 350, "bank_id": "visa"},
+		{"payment_id": "p2", "order_id": 2, "amount": 500, "bank_id": "visa"},
+		{"payment_id": "p99", "order_id": 99, "amount": 100, "bank_id": "visa"},
+	}, nil)
+
+	orders := []reconciledOrder{
+		{ID: 1, Status: "paid", TotalAmount: 300, PaidAmount: 350, Paytype: "visa"},
+		{ID: 2, Status: "ready", TotalAmount: 500, PaidAmount: 500, Paytype: "visa"},
+		{ID: 3, Status: "delivering", TotalAmount: 200, PaidAmount: 200, Paytype: "visa"},
+	}
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil)
+	rows.EXPECT().Close()
+	for _, order := range orders {
+		rows.EXPECT().Next().Return(true)
+		rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = order.ID
+			*dest[1].(*string) = order.Status
+			*dest[2].(*int64) = order.TotalAmount
+			*dest[3].(*int64) = order.PaidAmount
+			*dest[4].(*string) = order.Paytype
+			return nil
+		})
+	}
+	rows.EXPECT().Next().Return(false)
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "visa"
+		*dest[2].(*string) = "simulator"
+		return nil
+	}).AnyTimes()
+	cfg.payment.EXPECT().GetPaymentStatus(gomock.Any(), "p1").Return(&pkg.PaymentStatus{Status: "paid", Amount: 350}, nil)
+	cfg.payment.EXPECT().GetPaymentStatus(gomock.Any(), "p2").Return(&pkg.PaymentStatus{Status: "paid", Amount: 450}, nil)
+
+	var kinds = make(map[int64]string)
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil)
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, sql string, args ...any) error {
+		if len(args) == 7 {
+			kinds[args[0].(int64)] = args[3].(string)
+		} else {
+			assert.ElementsMatch(t, []int64{1, 2, 3}, args[0])
+		}
+		return nil
+	}).Times(5)
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	cfg.nosql.EXPECT().Update(gomock.Any(), "payments", gomock.Any(), pkg.Map{"reconciled": true}).Return(nil, nil).Times(3)
+
+	err = cfg.service.reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]string{
+		1:  "overpayment",
+		2:  "amount_mismatch",
+		3:  "missing_payment",
+		99: "orphan_payment",
+	}, kinds)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestRefundOrder$
+func TestRefundOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	scanOrder := func(status string) func(dest ...any) error {
+		return func(dest ...any) error {
+			*dest[0].(*string) = status
+			*dest[1].(*int64) = 500
+			*dest[2].(*int64) = 0
+			*dest[4].(*[]*Product) = []*Product{
+				{ID: 1, Quantity: 2, Price: 100},
+				{ID: 2, Quantity: 1, Price: 300},
+			}
+			dest[5].(*Pricing).TaxRate = 1000 // 10%
+			return nil
+		}
+	}
+	payment := pkg.Map{"payment_id": "p1", "order_id": 1, "amount": 500, "bank_id": "visa"}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Unpaid order
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanOrder("pending"))
+	_, err := cfg.service.refundOrder(ctx, &RefundRequest{OrderID: 1})
+	assert.Error(t, err)
+
+	// Test case #2: More than ordered
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanOrder("paid"))
+	cfg.nosql.EXPECT().GetItem(gomock.Any(), "payments", gomock.Any()).Return(payment, nil)
+	_, err = cfg.service.refundOrder(ctx, &RefundRequest{OrderID: 1, Products: []*Product{{ID: 1, Quantity: 3}}})
+	assert.Error(t, err)
+
+	// Test case #3
+	targetError := errors.New("Refund error")
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanOrder("paid"))
+	cfg.nosql.EXPECT().GetItem(gomock.Any(), "payments", gomock.Any()).Return(payment, nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[2].(*string) = "simulator"
+		return nil
+	})
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "refunded"
+		return nil
+	})
+	cfg.payment.EXPECT().Refund(gomock.Any(), "p1", int64(500)).Return("", targetError)
+	cfg.postgres.EXPECT().Exec(gomock.Any(), gomock.Any(), int64(500), int64(1)).Return(nil) // the reservation is released
+	_, err = cfg.service.refundOrder(ctx, &RefundRequest{OrderID: 1})
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Another refund made or in progress since the order was read
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanOrder("paid"))
+	cfg.nosql.EXPECT().GetItem(gomock.Any(), "payments", gomock.Any()).Return(payment, nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[2].(*string) = "simulator"
+		return nil
+	})
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err = cfg.service.refundOrder(ctx, &RefundRequest{OrderID: 1})
+	assert.Error(t, err)
+
+	// Test case #5: Partial refund success, with the products' tax
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanOrder("delivering"))
+	cfg.nosql.EXPECT().GetItem(gomock.Any(), "payments", gomock.Any()).Return(payment, nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[2].(*string) = "simulator"
+		return nil
+	})
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "partially_refunded"
+		return nil
+	})
+	cfg.payment.EXPECT().Refund(gomock.Any(), "p1", int64(220)).Return("r1", nil)
+	cfg.nosql.EXPECT().Append(gomock.Any(), "payments", pkg.Map{"payment_id": "p1", "order_id": int64(1)}, "refunds", gomock.Any(), pkg.Map{"status": "partially_refunded"}).Return(nil, nil)
+	cfg.postgres.EXPECT().Exec(gomock.Any(), gomock.Any(), "partially_refunded", int64(1)).Return(nil)
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("", nil)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.refunded", gomock.Any()).Return(nil)
+	resp, err := cfg.service.refundOrder(ctx, &RefundRequest{OrderID: 1, Products: []*Product{{ID: 1, Quantity: 2}}})
+	assert.NoError(t, err)
+	assert.Equal(t, &RefundResponse{RefundID: "r1", Amount: 220, Status: "partially_refunded"}, resp)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestRefundAmount$
+func TestRefundAmount(t *testing.T) {
+	order := &refundableOrder{
+		PaidAmount: 1000,
+		Products: []*Product{
+			{ID: 7, Quantity: 1, Price: 100, VariantID: 10},                               // small
+			{ID: 7, Quantity: 2, Price: 180, VariantID: 11, ModifierIDs: []int32{21, 22}}, // large with extras
+			{ID: 7, Quantity: 1, Price: 150, VariantID: 11},                               // large
+		},
+	}
+
+	// Test case #1: A line is priced by its own price, not the first of the product's
+	amount, err := refundAmount(order, nil, []*Product{{ID: 7, Quantity: 2, VariantID: 11, ModifierIDs: []int32{22, 21}}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(360), amount)
+
+	// Test case #2: The quantities of the other lines of the product don't count
+	_, err = refundAmount(order, nil, []*Product{{ID: 7, Quantity: 2, VariantID: 11}})
+	assert.Error(t, err)
+
+	// Test case #3: The line refunded before
+	refunds := []*refundRecord{{Products: []*Product{{ID: 7, Quantity: 1, VariantID: 10}}}}
+	_, err = refundAmount(order, refunds, []*Product{{ID: 7, Quantity: 1, VariantID: 10}})
+	assert.Error(t, err)
+	amount, err = refundAmount(order, refunds, []*Product{{ID: 7, Quantity: 1, VariantID: 11}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150), amount)
+
+	// Test case #4: No such options ordered
+	_, err = refundAmount(order, nil, []*Product{{ID: 7, Quantity: 1, VariantID: 12}})
+	assert.Error(t, err)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestCreateBank$
+func TestCreateBank(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &Bank{ID: "alif", Title: "Alif Bank", Driver: "simulator", Currencies: []string{"TJS"}}
+
+	// Test case #1: Unknown driver
+	_, err := cfg.service.createBank(ctx, &Bank{ID: "alif", Driver: "unknown"})
+	assert.Error(t, err)
+
+	// Test case #2
+	targetError := errors.New("createBank error")
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	_, err = cfg.service.createBank(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #3: Success with a generated callback secret
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, sql string, args ...any) pkg.Row {
+			assert.Len(t, args[4], 64)
+			return cfg.row
+		})
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "alif"
+		*dest[4].(*string) = "generated secret"
+		return nil
+	})
+	resp, err := cfg.service.createBank(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "alif", resp.ID)
+	assert.Equal(t, "generated secret", resp.CallbackSecret)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestCheckPromoCode$
+func TestCheckPromoCode(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &PromoRequest{
+		Code:       "welcome10",
+		CustomerID: "customer",
+		PartnerID:  1,
+		Pricing:    &Pricing{Subtotal: 2000, DeliveryFee: 500, TaxRate: 1000},
+	}
+	scanPromo := func(dest ...any) error {
+		*dest[0].(*string) = "WELCOME10"
+		*dest[1].(*string) = "percent"
+		*dest[2].(*int64) = 1000
+		*dest[6].(*time.Time) = time.Now().Add(-time.Hour)
+		*dest[9].(*int32) = 1
+		*dest[11].(*bool) = true
+		return nil
+	}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Unknown code
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err := cfg.service.checkPromoCode(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #2: Already used by the customer
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanPromo)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int32) = 1
+		return nil
+	})
+	_, err = cfg.service.checkPromoCode(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #3: Success, 10% off the subtotal, the tax is taken on the discounted amount
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanPromo)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	pricing, err := cfg.service.checkPromoCode(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), pricing.Discount)
+	assert.Equal(t, int64(230), pricing.Tax)
+	assert.Equal(t, int64(2530), pricing.Total)
+
+	// promoDiscount rules
+	now := time.Now()
+	validTo := now.Add(-time.Minute)
+	fixed := &promoCode{Kind: "fixed", Value: 5000, Active: true, ValidFrom: now.Add(-time.Hour)}
+	discount, err := promoDiscount(fixed, now, 1, 2000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), discount) // never more than the subtotal
+
+	capped := &promoCode{Kind: "percent", Value: 5000, MaxDiscount: 300, Active: true, ValidFrom: now.Add(-time.Hour)}
+	discount, err = promoDiscount(capped, now, 1, 2000, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), discount)
+
+	_, err = promoDiscount(&promoCode{Kind: "fixed", Value: 100, Active: true, ValidFrom: now.Add(-time.Hour), ValidTo: &validTo}, now, 1, 2000, 0)
+	assert.Error(t, err) // expired
+	_, err = promoDiscount(&promoCode{Kind: "fixed", Value: 100, Active: true, ValidFrom: now.Add(-time.Hour), UsageLimit: 5, UsedCount: 5}, now, 1, 2000, 0)
+	assert.Error(t, err) // usage limit
+	_, err = promoDiscount(&promoCode{Kind: "fixed", Value: 100, Active: true, ValidFrom: now.Add(-time.Hour), MinOrderAmount: 3000}, now, 1, 2000, 0)
+	assert.Error(t, err) // minimum order
+	_, err = promoDiscount(&promoCode{Kind: "fixed", Value: 100, Active: true, ValidFrom: now.Add(-time.Hour), PartnerIDs: []int32{2}}, now, 1, 2000, 0)
+	assert.Error(t, err) // other partner
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestCancelOrder$
+func TestCancelOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &CancelRequest{OrderID: 1, CustomerID: "customer"}
+
+	rows := mocks.NewMockRows(cfg.ctrl)
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil).AnyTimes()
+	rows.EXPECT().Close().AnyTimes()
+
+	// Test case #1: Not pending or not the customer's order
+	rows.EXPECT().Next().Return(false)
+	_, err := cfg.service.cancelOrder(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #2
+	targetError := errors.New("orders.released publish error")
+	rows.EXPECT().Next().Return(true)
+	rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 1
+		return nil
+	})
+	rows.EXPECT().Next().Return(false)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.released", gomock.Any()).Return(targetError)
+	_, err = cfg.service.cancelOrder(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// the other order of the cart is cancelled too
+	rows.EXPECT().Next().Return(true).Times(2)
+	rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 1
+		return nil
+	})
+	rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 2
+		return nil
+	})
+	rows.EXPECT().Next().Return(false)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.released", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, data []byte) error {
+		var released ReleasedOrders
+		assert.NoError(t, json.Unmarshal(data, &released))
+		assert.Equal(t, []int64{1, 2}, released.OrderIDs)
+		return nil
+	})
+
+	// Test case #3
+	targetError = errors.New("saveHistory error")
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("", targetError)
+	_, err = cfg.service.cancelOrder(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success
+	rows.EXPECT().Next().Return(true)
+	rows.EXPECT().Scan(gomock.Any()).Return(nil)
+	rows.EXPECT().Next().Return(false)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.released", gomock.Any()).Return(nil)
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("id", nil)
+	_, err = cfg.service.cancelOrder(ctx, req)
+	assert.NoError(t, err)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestCompleteOrder$
+func TestCompleteOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &CompleteRequest{OrderID: 1, DelivererID: "deliverer"}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Not the deliverer's order or not on the way
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err := cfg.service.completeOrder(ctx, req)
+	assert.Error(t, err)
+
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "customer token"
+		*dest[1].(*int64) = 600
+		return nil
+	}).AnyTimes()
+
+	// Test case #2
+	targetError := errors.New("saveHistory error")
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("", targetError)
+	_, err = cfg.service.completeOrder(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "orders_history", gomock.Any()).Return("id", nil).AnyTimes()
+
+	// Test case #3
+	targetError = errors.New("Publish error")
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.delivered", gomock.Any()).Return(targetError)
+	_, err = cfg.service.completeOrder(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success
+	cfg.queue.EXPECT().Publish(gomock.Any(), "orders.delivered", gomock.Any()).Return(nil)
+	resp, err := cfg.service.completeOrder(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(600), resp.Earned)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestGetEarnings$
+func TestGetEarnings(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	rows := mocks.NewMockRows(cfg.ctrl)
+	req := &EarningsRequest{DelivererID: "deliverer", Date: "2024-05-12"}
+
+	// Test case #1
+	_, err := cfg.service.getEarnings(ctx, &EarningsRequest{Date: "12.05.2024"})
+	assert.Error(t, err)
+
+	// Test case #2
+	targetError := errors.New("Query error")
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err = cfg.service.getEarnings(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #3: Success, the days without earnings are zeros
+	days := []*Earnings{
+		{Date: "2024-05-07", Deliveries: 2, DeliveryFees: 800, Tips: 0},
+		{Date: "2024-05-12", Deliveries: 1, DeliveryFees: 400, Tips: 300},
+	}
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil)
+	rows.EXPECT().Close()
+	for _, day := range days {
+		rows.EXPECT().Next().Return(true)
+		rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*string) = day.Date
+			*dest[1].(*int32) = day.Deliveries
+			*dest[2].(*int64) = day.DeliveryFees
+			*dest[3].(*int64) = day.Tips
+			return nil
+		})
+	}
+	rows.EXPECT().Next().Return(false)
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 5000
+		return nil
+	})
+	resp, err := cfg.service.getEarnings(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Days, 7)
+	assert.Equal(t, "2024-05-06", resp.Days[0].Date)
+	assert.Equal(t, int64(800), resp.Days[1].Total)
+	assert.Equal(t, int64(0), resp.Days[2].Total)
+	assert.Equal(t, int64(700), resp.Days[6].Total)
+	assert.Equal(t, &Earnings{Deliveries: 3, DeliveryFees: 1200, Tips: 300, Total: 1500}, resp.Week)
+	assert.Equal(t, int64(5000), resp.PendingPayout)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestCreateReview$
+func TestCreateReview(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &Review{OrderID: 1, CustomerID: "customer", PartnerRating: 5, DelivererRating: 4, Comment: "fast and warm"}
+
+	// Test case #1: Invalid rating
+	_, err := cfg.service.createReview(ctx, &Review{OrderID: 1, PartnerRating: 6, DelivererRating: 4})
+	assert.Error(t, err)
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #2: Not delivered, not the customer's or already reviewed
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err = cfg.service.createReview(ctx, req)
+	assert.Error(t, err)
+
+	scanReview := func(dest ...any) error {
+		*dest[0].(*int64) = 1
+		*dest[2].(*int32) = 7
+		*dest[4].(*int32) = 5
+		*dest[5].(*int32) = 4
+		*dest[8].(*time.Time) = time.Now()
+		return nil
+	}
+
+	// Test case #3
+	targetError := errors.New("Publish error")
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanReview)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.queue.EXPECT().Publish(gomock.Any(), "reviews.partner_rated", gomock.Any()).Return(targetError)
+	_, err = cfg.service.createReview(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success, the partner's totals are published
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanReview)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 14
+		*dest[1].(*int32) = 3
+		return nil
+	})
+	cfg.queue.EXPECT().Publish(gomock.Any(), "reviews.partner_rated", gomock.Any()).DoAndReturn(func(ctx context.Context, topic string, data []byte) error {
+		assert.JSONEq(t, `{"partner_id":7,"rating_sum":14,"rating_count":3}`, string(data))
+		return nil
+	})
+	review, err := cfg.service.createReview(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), review.PartnerID)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestWatchOrder$
+func TestWatchOrder(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &WatchRequest{OrderID: 1, CustomerID: "customer"}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Not the customer's order
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	err := cfg.service.watchOrder(ctx, req, func(*OrderEvent) error { return nil })
+	assert.Error(t, err)
+
+	// Test case #2: Success, the current state and then the changes until the order is delivered
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "ready"
+		*dest[2].(*time.Time) = time.Now()
+		return nil
+	})
+	received := make(chan *OrderEvent)
+	done := make(chan error)
+	go func() {
+		done <- cfg.service.watchOrder(ctx, req, func(event *OrderEvent) error {
+			received <- event
+			return nil
+		})
+	}()
+	assert.Equal(t, "current", (<-received).Event)
+
+	cfg.service.broadcastEvent(&OrderEvent{OrderID: 2, Event: "paid", Status: "paid"}) // another order
+	cfg.service.broadcastEvent(&OrderEvent{OrderID: 1, Event: "delivering", Status: "delivering", DelivererID: "deliverer"})
+	event := <-received
+	assert.Equal(t, "delivering", event.Event)
+	assert.Equal(t, "deliverer", event.DelivererID)
+
+	cfg.service.broadcastEvent(&OrderEvent{OrderID: 1, Event: "delivered", Status: "delivered"})
+	assert.Equal(t, "delivered", (<-received).Event)
+	assert.NoError(t, <-done)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestTrackLocation$
+func TestTrackLocation(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	// about 2.2 km to the south of the delivery address
+	req := &LocationPing{OrderID: 1, DelivererID: "deliverer", Latitude: 41.28, Longitude: 69.24}
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Not the deliverer's order or not on the way
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err := cfg.service.trackLocation(ctx, req)
+	assert.Error(t, err)
+
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "customer"
+		*dest[1].(*Address) = Address{Latitude: 41.30, Longitude: 69.24}
+		return nil
+	}).AnyTimes()
+
+	// Test case #2
+	targetError := errors.New("saveLocation error")
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "deliverer_locations", gomock.Any()).Return("", targetError)
+	_, err = cfg.service.trackLocation(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #3: Success, 2.2 km at 20 km/h
+	cfg.nosql.EXPECT().Insert(gomock.Any(), "deliverer_locations", gomock.Any()).Return("id", nil)
+	resp, err := cfg.service.trackLocation(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "customer", resp.CustomerID)
+	assert.InDelta(t, 2.22, resp.DistanceKm, 0.01)
+	assert.Equal(t, int32(7), resp.EtaMinutes)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestSendReceipt$
+func TestSendReceipt(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #1: Not paid
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	err := cfg.service.sendReceipt(ctx, 1, false)
+	assert.Error(t, err)
+
+	scanReceipt := func() {
+		cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*string) = "order-1"
+			*dest[1].(*string) = "customer"
+			*dest[2].(*string) = "customer@mail.com"
+			*dest[3].(*string) = "Partner (Title)"
+			*dest[5].(*string) = "partner@mail.com"
+			*dest[6].(*[]*Product) = []*Product{{ID: 1, Quantity: 2, Price: 150, Title: "Plov"}, {ID: 2, Quantity: 1, Price: 200}}
+			*dest[7].(*Pricing) = Pricing{Subtotal: 500, DeliveryFee: 100, Total: 600}
+			*dest[8].(*int64) = 600
+			*dest[9].(*int64) = 600
+			*dest[10].(*string) = "visa"
+			return nil
+		})
+	}
+	scanStoredURL := func(storedURL string) {
+		cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*string) = storedURL
+			return nil
+		})
+	}
+	cfg.nosql.EXPECT().GetItem(gomock.Any(), "payments", pkg.Map{"order_id": int64(1)}).Return(pkg.Map{"payment_id": "pay-1"}, nil).AnyTimes()
+
+	// Test case #2
+	targetError := errors.New("Upload error")
+	scanReceipt()
+	cfg.storage.EXPECT().Upload(gomock.Any(), gomock.Any()).Return("", targetError)
+	err = cfg.service.sendReceipt(ctx, 1, false)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #3: The stored receipt is removed if it isn't saved in the order
+	targetError = errors.New("QueryRow error")
+	scanReceipt()
+	cfg.storage.EXPECT().Upload(gomock.Any(), gomock.Any()).Return("receipt url", nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	cfg.storage.EXPECT().Delete(gomock.Any(), "receipt url").Return(nil)
+	err = cfg.service.sendReceipt(ctx, 1, false)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success, the customer gets the receipt
+	var content []byte
+	scanReceipt()
+	cfg.storage.EXPECT().Upload(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input pkg.UploadInput) (string, error) {
+		content, _ = io.ReadAll(input.File)
+		assert.Equal(t, "application/pdf", input.ContentType)
+		return "receipt url", nil
+	})
+	scanStoredURL("receipt url")
+	cfg.email.EXPECT().Send(gomock.Any(), "customer@mail.com", "Receipt for Order order-1", gomock.Any()).Return(nil)
+	err = cfg.service.sendReceipt(ctx, 1, false)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+	for _, text := range []string{"(order-1)", "(Partner \\(Title\\) \\(\\))", "(Plov)", "(Product #2)", "(3.00)", "(6.00)", "(visa)", "(pay-1)"} {
+		assert.True(t, bytes.Contains(content, []byte(text)), text)
+	}
+
+	// Test case #5: The partner's copy was stored first, the other one is removed and it's sent
+	scanReceipt()
+	cfg.storage.EXPECT().Upload(gomock.Any(), gomock.Any()).Return("receipt url", nil)
+	scanStoredURL("first receipt url")
+	cfg.storage.EXPECT().Delete(gomock.Any(), "receipt url").Return(nil)
+	cfg.email.EXPECT().Send(gomock.Any(), "partner@mail.com", "Receipt for Order order-1", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, to string, subject string, body string) error {
+			assert.Contains(t, body, "first receipt url")
+			return nil
+		})
+	err = cfg.service.sendReceipt(ctx, 1, true)
+	assert.NoError(t, err)
+}
+
+// go test -v -count=1 ./internal/orders/ -run ^TestGetReceipt$
+func TestGetReceipt(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[1].(*string) = "customer"
+		*dest[11].(*string) = "receipt url"
+		return nil
+	}).AnyTimes()
+
+	// Test case #1: Someone else's order
+	_, err := cfg.service.getReceipt(ctx, &ReceiptRequest{OrderID: 1, CustomerID: "stranger"})
+	assert.Error(t, err)
+
+	// Test case #2: Success, the receipt is rendered once
+	resp, err := cfg.service.getReceipt(ctx, &ReceiptRequest{OrderID: 1, CustomerID: "customer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt url", resp.ReceiptURL)
+}
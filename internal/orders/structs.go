@@ -1,8 +1,16 @@
 package orders
 
+import "time"
+
 type bank struct {
-	ID             string `json:"id"`
-	WebcheckoutURL string `json:"webcheckout_url"`
+	ID             string   `json:"id"`
+	Title          string   `json:"title"`
+	Driver         string   `json:"driver"` // payment provider driver that serves the bank
+	WebcheckoutURL string   `json:"webcheckout_url"`
+	CallbackSecret string   `json:"-"`
+	AllowedIPs     []string `json:"allowed_ips"`
+	Currencies     []string `json:"currencies"`
+	Active         bool     `json:"active"`
 }
 
 type PaidOrder struct {
@@ -10,18 +18,126 @@ type PaidOrder struct {
 	PartnerID   int        `json:"partner_id"`
 	CallbackURL string     `json:"callback_url"`
 	Products    []*Product `json:"products"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // nil - as soon as possible
 }
 
 type pickupRequest struct {
+	OrderID       int64    `json:"order_id"`
+	PickupAddress *Address `json:"pickup_address"` // Address from which the package will be picked up.
+}
+
+type updatedAddress struct {
+	Status     string
+	OldAddress *Address
+}
+
+// paymentRecord is an item of the payments NoSQL collection.
+type paymentRecord struct {
+	PaymentID string          `json:"payment_id"`
+	OrderID   int64           `json:"order_id"`
+	Amount    int64           `json:"amount"`
+	BankID    string          `json:"bank_id"`
+	Refunds   []*refundRecord `json:"refunds"`
+	// CheckoutAmount is the whole payment of the cart the order was paid with, 0 - a single order payment.
+	CheckoutAmount int64 `json:"checkout_amount"`
+}
+
+// refundRecord is an entry of the payment record's refunds.
+type refundRecord struct {
+	RefundID  string     `json:"refund_id"`
+	Amount    int64      `json:"amount"`
+	Products  []*Product `json:"products"` // empty when the whole order is refunded
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type refundableOrder struct {
+	Status             string
+	PaidAmount         int64
+	RefundedAmount     int64
+	CustomerNotifToken string
+	Products           []*Product
+	Pricing            *Pricing
+}
+
+type reconciledOrder struct {
+	ID          int64
+	Status      string
+	TotalAmount int64
+	PaidAmount  int64
+	Paytype     string
+}
+
+type promoCode struct {
+	Code           string
+	Kind           string // percent, fixed
+	Value          int64  // percent: basis points, fixed: minor units of the currency
+	MaxDiscount    int64  // caps percent discounts, 0 - no cap
+	MinOrderAmount int64
+	PartnerIDs     []int32 // empty - any partner
+	ValidFrom      time.Time
+	ValidTo        *time.Time // nil - no end
+	UsageLimit     int32      // 0 - unlimited
+	PerUserLimit   int32      // 0 - unlimited
+	UsedCount      int32
+	Active         bool
+}
+
+type completedOrder struct {
+	CustomerNotifToken string
+	Earned             int64
+}
+
+// receipt is what the receipt of a paid order shows.
+type receipt struct {
+	OrderID       int64
+	OrderNumber   string // the customer's order id
+	CustomerID    string
+	CustomerEmail string
+	PartnerTitle  string
+	PartnerBrand  string
+	PartnerEmail  string
+	Products      []*Product
+	Pricing       *Pricing
+	TotalAmount   int64
+	PaidAmount    int64
+	Paytype       string
+	PaymentID     string
+	ReceiptURL    string // empty - not rendered yet
+}
+
+type deliveringOrder struct {
+	CustomerID      string
+	DeliveryAddress *Address
+}
+
+type tippableOrder struct {
+	Paytype      string
+	PartnerBrand string
+}
+
+type paidTip struct {
+	Amount              int64
+	DelivererNotifToken string
+}
+
+// StockReservation is the message sent to the partners service when an order is created,
+// the stock reserved for it when it was checked is held from then on.
+type StockReservation struct {
 	OrderID       int64  `json:"order_id"`
-	PickupAddress string `json:"pickup_address"` // Address from which the package will be picked up.
+	ReservationID string `json:"reservation_id"`
 }
 
-// type orderAddress struct {
-// 	OrderID     int64  `json:"order_id"`
-// 	Street      string `json:"street"`
-// 	City        string `json:"city"`
-// 	State       string `json:"state"`
-// 	PostalCode  string `json:"postal_code"`
-// 	AddressType string `json:"address_type"` // Billing or Shipping
-// }
+// ReleasedOrders is the message sent to the partners service when unpaid orders
+// are cancelled or expire, the stock held for them is put back on sale.
+type ReleasedOrders struct {
+	OrderIDs []int64 `json:"order_ids"`
+}
+
+// PartnerRating is the message sent to the partners service whenever
+// the visible reviews of a partner change.
+type PartnerRating struct {
+	PartnerID   int32 `json:"partner_id"`
+	RatingSum   int64 `json:"rating_sum"`
+	RatingCount int32 `json:"rating_count"`
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shahzodshafizod/gocloud/internal/orders"
@@ -34,6 +35,7 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 	if err != nil {
 		return errors.Wrap(err, "net.Listen")
 	}
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
 	lifecycle.Append(fx.Hook{
 		OnStart: func(context.Context) error {
 			err := queue.Subscribe(context.Background(), &pkg.Subscribe{
@@ -43,10 +45,35 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 			if err != nil {
 				return nil
 			}
+			err = queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "reviews.partner_rated",
+				Callback: handler.updateRating,
+			})
+			if err != nil {
+				return nil
+			}
+			err = queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "orders.created",
+				Callback: handler.holdStock,
+			})
+			if err != nil {
+				return nil
+			}
+			err = queue.Subscribe(context.Background(), &pkg.Subscribe{
+				Topic:    "orders.released",
+				Callback: handler.releaseStock,
+			})
+			if err != nil {
+				return nil
+			}
+			go handler.repeat(schedulerCtx, "handler.forwardScheduled", time.Second*30, service.forwardScheduled)
+			go handler.repeat(schedulerCtx, "handler.deliverWebhooks", time.Second*10, service.deliverWebhooks)
+			go handler.repeat(schedulerCtx, "handler.releaseExpired", time.Minute, service.releaseExpired)
 			go handler.server.Serve(lis)
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
+			stopScheduler()
 			tracer.Shutdown(ctx)
 			postgres.Close(ctx)
 			queue.Close()
@@ -60,7 +87,7 @@ func NewHandler(lifecycle fx.Lifecycle, service Service, queue pkg.Queue, postgr
 func (h *handler) GetPartnerProducts(ctx context.Context, req *products.GetAllRequest) (*products.GetAllResponse, error) {
 	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetPartnerProducts")
 	defer span.End()
-	resp, err := h.service.getPartnerProducts(ctx)
+	resp, err := h.service.getPartnerProducts(ctx, req)
 	if err != nil {
 		err = errors.Wrap(err, "h.service.getPartnerProducts")
 		span.RecordError(err)
@@ -100,4 +127,417 @@ func (h *handler) sendToPartner(ctx context.Context, span pkg.Span, msg pkg.Mess
 	return nil
 }
 
+// repeat runs the job every `interval` until ctx is cancelled. The jobs keep their state
+// in the database, so it survives restarts and is shared by the instances.
+func (h *handler) repeat(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			spanCtx, span := h.tracer.StartFromContext(ctx, name)
+			err := job(spanCtx)
+			if err != nil {
+				span.RecordError(errors.Wrap(err, name))
+			}
+			span.End()
+		}
+	}
+}
+
+func (h *handler) updateRating(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+	ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.updateRating")
+	defer span.End()
+	var rating = &orders.PartnerRating{}
+	err := json.Unmarshal(msg.Body(), rating)
+	if err != nil {
+		err = errors.Wrap(err, "json.Unmarshal")
+		span.RecordError(err)
+		return err
+	}
+	err = h.service.updateRating(ctx, rating)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updateRating")
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) holdStock(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+	ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.holdStock")
+	defer span.End()
+	var req = &orders.StockReservation{}
+	err := json.Unmarshal(msg.Body(), req)
+	if err != nil {
+		err = errors.Wrap(err, "json.Unmarshal")
+		span.RecordError(err)
+		return err
+	}
+	err = h.service.holdStock(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.holdStock")
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) releaseStock(ctx context.Context, span pkg.Span, msg pkg.Message) error {
+	ctx, span = h.tracer.StartFromSpan(ctx, span, "handler.releaseStock")
+	defer span.End()
+	var req = &orders.ReleasedOrders{}
+	err := json.Unmarshal(msg.Body(), req)
+	if err != nil {
+		err = errors.Wrap(err, "json.Unmarshal")
+		span.RecordError(err)
+		return err
+	}
+	err = h.service.releaseStock(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.releaseStock")
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) CreatePartner(ctx context.Context, req *PartnerInfo) (*PartnerInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreatePartner")
+	defer span.End()
+	resp, err := h.service.createPartner(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createPartner")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) UpdatePartner(ctx context.Context, req *PartnerInfo) (*PartnerInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.UpdatePartner")
+	defer span.End()
+	resp, err := h.service.updatePartner(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updatePartner")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetPartner(ctx context.Context, req *GetPartnerRequest) (*PartnerInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetPartner")
+	defer span.End()
+	resp, err := h.service.getPartner(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getPartner")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListPartners(ctx context.Context, req *ListPartnersRequest) (*ListPartnersResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListPartners")
+	defer span.End()
+	resp, err := h.service.listPartners(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listPartners")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetPartnerEnabled(ctx context.Context, req *SetPartnerEnabledRequest) (*PartnerInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetPartnerEnabled")
+	defer span.End()
+	resp, err := h.service.setPartnerEnabled(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setPartnerEnabled")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetPartnerPaused(ctx context.Context, req *SetPartnerPausedRequest) (*PartnerInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetPartnerPaused")
+	defer span.End()
+	resp, err := h.service.setPartnerPaused(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setPartnerPaused")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetOpeningHours(ctx context.Context, req *GetPartnerRequest) (*OpeningHours, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetOpeningHours")
+	defer span.End()
+	resp, err := h.service.getOpeningHours(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getOpeningHours")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetOpeningHours(ctx context.Context, req *OpeningHours) (*OpeningHours, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetOpeningHours")
+	defer span.End()
+	resp, err := h.service.setOpeningHours(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setOpeningHours")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetProductOptions(ctx context.Context, req *ProductOptionsRequest) (*ProductOptions, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetProductOptions")
+	defer span.End()
+	resp, err := h.service.getProductOptions(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getProductOptions")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetProductOptions(ctx context.Context, req *ProductOptions) (*ProductOptions, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetProductOptions")
+	defer span.End()
+	resp, err := h.service.setProductOptions(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setProductOptions")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) RotateWebhookSecret(ctx context.Context, req *GetPartnerRequest) (*WebhookSecret, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.RotateWebhookSecret")
+	defer span.End()
+	resp, err := h.service.rotateWebhookSecret(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.rotateWebhookSecret")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListWebhookDeliveries(ctx context.Context, req *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListWebhookDeliveries")
+	defer span.End()
+	resp, err := h.service.listWebhookDeliveries(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listWebhookDeliveries")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetWebhookDelivery(ctx context.Context, req *GetDeliveryRequest) (*WebhookDelivery, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetWebhookDelivery")
+	defer span.End()
+	resp, err := h.service.getWebhookDelivery(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getWebhookDelivery")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ReplayWebhookDelivery(ctx context.Context, req *GetDeliveryRequest) (*WebhookDelivery, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ReplayWebhookDelivery")
+	defer span.End()
+	resp, err := h.service.replayWebhookDelivery(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.replayWebhookDelivery")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreateProduct(ctx context.Context, req *products.ProductInfo) (*products.ProductInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreateProduct")
+	defer span.End()
+	resp, err := h.service.createProduct(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createProduct")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) UpdateProduct(ctx context.Context, req *products.ProductInfo) (*products.ProductInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.UpdateProduct")
+	defer span.End()
+	resp, err := h.service.updateProduct(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updateProduct")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetProduct(ctx context.Context, req *products.GetProductRequest) (*products.ProductInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetProduct")
+	defer span.End()
+	resp, err := h.service.getProduct(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getProduct")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListProducts(ctx context.Context, req *products.ListProductsRequest) (*products.ListProductsResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListProducts")
+	defer span.End()
+	resp, err := h.service.listProducts(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listProducts")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetProductPicture(ctx context.Context, req *products.ProductPictureRequest) (*products.ProductInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetProductPicture")
+	defer span.End()
+	resp, err := h.service.setProductPicture(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setProductPicture")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) DeleteProduct(ctx context.Context, req *products.DeleteProductRequest) (*products.ProductInfo, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.DeleteProduct")
+	defer span.End()
+	resp, err := h.service.deleteProduct(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.deleteProduct")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetProductLabels(ctx context.Context, req *products.ProductLabels) (*products.ProductLabels, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetProductLabels")
+	defer span.End()
+	resp, err := h.service.setProductLabels(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setProductLabels")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) CreateCategory(ctx context.Context, req *products.Category) (*products.Category, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.CreateCategory")
+	defer span.End()
+	resp, err := h.service.createCategory(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.createCategory")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) UpdateCategory(ctx context.Context, req *products.Category) (*products.Category, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.UpdateCategory")
+	defer span.End()
+	resp, err := h.service.updateCategory(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.updateCategory")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) DeleteCategory(ctx context.Context, req *products.DeleteCategoryRequest) (*products.Category, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.DeleteCategory")
+	defer span.End()
+	resp, err := h.service.deleteCategory(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.deleteCategory")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) GetCategories(ctx context.Context, req *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.GetCategories")
+	defer span.End()
+	resp, err := h.service.getCategories(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.getCategories")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) SetAvailable(ctx context.Context, req *products.Availability) (*products.Availability, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.SetAvailable")
+	defer span.End()
+	resp, err := h.service.setAvailable(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.setAvailable")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) ListAvailable(ctx context.Context, req *products.ListAvailableRequest) (*products.ListAvailableResponse, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.ListAvailable")
+	defer span.End()
+	resp, err := h.service.listAvailable(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.listAvailable")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (h *handler) DeleteAvailable(ctx context.Context, req *products.DeleteAvailableRequest) (*products.Availability, error) {
+	ctx, span := h.tracer.StartFromContext(ctx, "handler.DeleteAvailable")
+	defer span.End()
+	resp, err := h.service.deleteAvailable(ctx, req)
+	if err != nil {
+		err = errors.Wrap(err, "h.service.deleteAvailable")
+		span.RecordError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (h *handler) mustEmbedUnimplementedPartnersServer() {}
@@ -63,6 +63,186 @@ func (mr *MockPartnersClientMockRecorder) CheckPartnerProducts(ctx, in any, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPartnerProducts", reflect.TypeOf((*MockPartnersClient)(nil).CheckPartnerProducts), varargs...)
 }
 
+// CreateCategory mocks base method.
+func (m *MockPartnersClient) CreateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCategory", varargs...)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockPartnersClientMockRecorder) CreateCategory(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockPartnersClient)(nil).CreateCategory), varargs...)
+}
+
+// CreatePartner mocks base method.
+func (m *MockPartnersClient) CreatePartner(ctx context.Context, in *partners.PartnerInfo, opts ...grpc.CallOption) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreatePartner", varargs...)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartner indicates an expected call of CreatePartner.
+func (mr *MockPartnersClientMockRecorder) CreatePartner(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartner", reflect.TypeOf((*MockPartnersClient)(nil).CreatePartner), varargs...)
+}
+
+// CreateProduct mocks base method.
+func (m *MockPartnersClient) CreateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateProduct", varargs...)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockPartnersClientMockRecorder) CreateProduct(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockPartnersClient)(nil).CreateProduct), varargs...)
+}
+
+// DeleteAvailable mocks base method.
+func (m *MockPartnersClient) DeleteAvailable(ctx context.Context, in *products.DeleteAvailableRequest, opts ...grpc.CallOption) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteAvailable", varargs...)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvailable indicates an expected call of DeleteAvailable.
+func (mr *MockPartnersClientMockRecorder) DeleteAvailable(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvailable", reflect.TypeOf((*MockPartnersClient)(nil).DeleteAvailable), varargs...)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockPartnersClient) DeleteCategory(ctx context.Context, in *products.DeleteCategoryRequest, opts ...grpc.CallOption) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCategory", varargs...)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockPartnersClientMockRecorder) DeleteCategory(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockPartnersClient)(nil).DeleteCategory), varargs...)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockPartnersClient) DeleteProduct(ctx context.Context, in *products.DeleteProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteProduct", varargs...)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockPartnersClientMockRecorder) DeleteProduct(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockPartnersClient)(nil).DeleteProduct), varargs...)
+}
+
+// GetCategories mocks base method.
+func (m *MockPartnersClient) GetCategories(ctx context.Context, in *products.GetCategoriesRequest, opts ...grpc.CallOption) (*products.GetCategoriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCategories", varargs...)
+	ret0, _ := ret[0].(*products.GetCategoriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockPartnersClientMockRecorder) GetCategories(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockPartnersClient)(nil).GetCategories), varargs...)
+}
+
+// GetOpeningHours mocks base method.
+func (m *MockPartnersClient) GetOpeningHours(ctx context.Context, in *partners.GetPartnerRequest, opts ...grpc.CallOption) (*partners.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetOpeningHours", varargs...)
+	ret0, _ := ret[0].(*partners.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningHours indicates an expected call of GetOpeningHours.
+func (mr *MockPartnersClientMockRecorder) GetOpeningHours(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningHours", reflect.TypeOf((*MockPartnersClient)(nil).GetOpeningHours), varargs...)
+}
+
+// GetPartner mocks base method.
+func (m *MockPartnersClient) GetPartner(ctx context.Context, in *partners.GetPartnerRequest, opts ...grpc.CallOption) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPartner", varargs...)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartner indicates an expected call of GetPartner.
+func (mr *MockPartnersClientMockRecorder) GetPartner(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartner", reflect.TypeOf((*MockPartnersClient)(nil).GetPartner), varargs...)
+}
+
 // GetPartnerProducts mocks base method.
 func (m *MockPartnersClient) GetPartnerProducts(ctx context.Context, in *products.GetAllRequest, opts ...grpc.CallOption) (*products.GetAllResponse, error) {
 	m.ctrl.T.Helper()
@@ -70,17 +250,397 @@ func (m *MockPartnersClient) GetPartnerProducts(ctx context.Context, in *product
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "GetPartnerProducts", varargs...)
-	ret0, _ := ret[0].(*products.GetAllResponse)
+	ret := m.ctrl.Call(m, "GetPartnerProducts", varargs...)
+	ret0, _ := ret[0].(*products.GetAllResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartnerProducts indicates an expected call of GetPartnerProducts.
+func (mr *MockPartnersClientMockRecorder) GetPartnerProducts(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerProducts", reflect.TypeOf((*MockPartnersClient)(nil).GetPartnerProducts), varargs...)
+}
+
+// GetProduct mocks base method.
+func (m *MockPartnersClient) GetProduct(ctx context.Context, in *products.GetProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProduct", varargs...)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockPartnersClientMockRecorder) GetProduct(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockPartnersClient)(nil).GetProduct), varargs...)
+}
+
+// GetProductOptions mocks base method.
+func (m *MockPartnersClient) GetProductOptions(ctx context.Context, in *partners.ProductOptionsRequest, opts ...grpc.CallOption) (*partners.ProductOptions, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetProductOptions", varargs...)
+	ret0, _ := ret[0].(*partners.ProductOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductOptions indicates an expected call of GetProductOptions.
+func (mr *MockPartnersClientMockRecorder) GetProductOptions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOptions", reflect.TypeOf((*MockPartnersClient)(nil).GetProductOptions), varargs...)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockPartnersClient) GetWebhookDelivery(ctx context.Context, in *partners.GetDeliveryRequest, opts ...grpc.CallOption) (*partners.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", varargs...)
+	ret0, _ := ret[0].(*partners.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockPartnersClientMockRecorder) GetWebhookDelivery(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockPartnersClient)(nil).GetWebhookDelivery), varargs...)
+}
+
+// ListAvailable mocks base method.
+func (m *MockPartnersClient) ListAvailable(ctx context.Context, in *products.ListAvailableRequest, opts ...grpc.CallOption) (*products.ListAvailableResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAvailable", varargs...)
+	ret0, _ := ret[0].(*products.ListAvailableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailable indicates an expected call of ListAvailable.
+func (mr *MockPartnersClientMockRecorder) ListAvailable(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailable", reflect.TypeOf((*MockPartnersClient)(nil).ListAvailable), varargs...)
+}
+
+// ListPartners mocks base method.
+func (m *MockPartnersClient) ListPartners(ctx context.Context, in *partners.ListPartnersRequest, opts ...grpc.CallOption) (*partners.ListPartnersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListPartners", varargs...)
+	ret0, _ := ret[0].(*partners.ListPartnersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartners indicates an expected call of ListPartners.
+func (mr *MockPartnersClientMockRecorder) ListPartners(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartners", reflect.TypeOf((*MockPartnersClient)(nil).ListPartners), varargs...)
+}
+
+// ListProducts mocks base method.
+func (m *MockPartnersClient) ListProducts(ctx context.Context, in *products.ListProductsRequest, opts ...grpc.CallOption) (*products.ListProductsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListProducts", varargs...)
+	ret0, _ := ret[0].(*products.ListProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockPartnersClientMockRecorder) ListProducts(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockPartnersClient)(nil).ListProducts), varargs...)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockPartnersClient) ListWebhookDeliveries(ctx context.Context, in *partners.ListDeliveriesRequest, opts ...grpc.CallOption) (*partners.ListDeliveriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", varargs...)
+	ret0, _ := ret[0].(*partners.ListDeliveriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockPartnersClientMockRecorder) ListWebhookDeliveries(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockPartnersClient)(nil).ListWebhookDeliveries), varargs...)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockPartnersClient) ReplayWebhookDelivery(ctx context.Context, in *partners.GetDeliveryRequest, opts ...grpc.CallOption) (*partners.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", varargs...)
+	ret0, _ := ret[0].(*partners.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockPartnersClientMockRecorder) ReplayWebhookDelivery(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockPartnersClient)(nil).ReplayWebhookDelivery), varargs...)
+}
+
+// RotateWebhookSecret mocks base method.
+func (m *MockPartnersClient) RotateWebhookSecret(ctx context.Context, in *partners.GetPartnerRequest, opts ...grpc.CallOption) (*partners.WebhookSecret, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateWebhookSecret", varargs...)
+	ret0, _ := ret[0].(*partners.WebhookSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhookSecret indicates an expected call of RotateWebhookSecret.
+func (mr *MockPartnersClientMockRecorder) RotateWebhookSecret(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhookSecret", reflect.TypeOf((*MockPartnersClient)(nil).RotateWebhookSecret), varargs...)
+}
+
+// SetAvailable mocks base method.
+func (m *MockPartnersClient) SetAvailable(ctx context.Context, in *products.Availability, opts ...grpc.CallOption) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetAvailable", varargs...)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAvailable indicates an expected call of SetAvailable.
+func (mr *MockPartnersClientMockRecorder) SetAvailable(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailable", reflect.TypeOf((*MockPartnersClient)(nil).SetAvailable), varargs...)
+}
+
+// SetOpeningHours mocks base method.
+func (m *MockPartnersClient) SetOpeningHours(ctx context.Context, in *partners.OpeningHours, opts ...grpc.CallOption) (*partners.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetOpeningHours", varargs...)
+	ret0, _ := ret[0].(*partners.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOpeningHours indicates an expected call of SetOpeningHours.
+func (mr *MockPartnersClientMockRecorder) SetOpeningHours(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOpeningHours", reflect.TypeOf((*MockPartnersClient)(nil).SetOpeningHours), varargs...)
+}
+
+// SetPartnerEnabled mocks base method.
+func (m *MockPartnersClient) SetPartnerEnabled(ctx context.Context, in *partners.SetPartnerEnabledRequest, opts ...grpc.CallOption) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetPartnerEnabled", varargs...)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPartnerEnabled indicates an expected call of SetPartnerEnabled.
+func (mr *MockPartnersClientMockRecorder) SetPartnerEnabled(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartnerEnabled", reflect.TypeOf((*MockPartnersClient)(nil).SetPartnerEnabled), varargs...)
+}
+
+// SetPartnerPaused mocks base method.
+func (m *MockPartnersClient) SetPartnerPaused(ctx context.Context, in *partners.SetPartnerPausedRequest, opts ...grpc.CallOption) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetPartnerPaused", varargs...)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPartnerPaused indicates an expected call of SetPartnerPaused.
+func (mr *MockPartnersClientMockRecorder) SetPartnerPaused(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartnerPaused", reflect.TypeOf((*MockPartnersClient)(nil).SetPartnerPaused), varargs...)
+}
+
+// SetProductLabels mocks base method.
+func (m *MockPartnersClient) SetProductLabels(ctx context.Context, in *products.ProductLabels, opts ...grpc.CallOption) (*products.ProductLabels, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProductLabels", varargs...)
+	ret0, _ := ret[0].(*products.ProductLabels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductLabels indicates an expected call of SetProductLabels.
+func (mr *MockPartnersClientMockRecorder) SetProductLabels(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductLabels", reflect.TypeOf((*MockPartnersClient)(nil).SetProductLabels), varargs...)
+}
+
+// SetProductOptions mocks base method.
+func (m *MockPartnersClient) SetProductOptions(ctx context.Context, in *partners.ProductOptions, opts ...grpc.CallOption) (*partners.ProductOptions, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProductOptions", varargs...)
+	ret0, _ := ret[0].(*partners.ProductOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductOptions indicates an expected call of SetProductOptions.
+func (mr *MockPartnersClientMockRecorder) SetProductOptions(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductOptions", reflect.TypeOf((*MockPartnersClient)(nil).SetProductOptions), varargs...)
+}
+
+// SetProductPicture mocks base method.
+func (m *MockPartnersClient) SetProductPicture(ctx context.Context, in *products.ProductPictureRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetProductPicture", varargs...)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductPicture indicates an expected call of SetProductPicture.
+func (mr *MockPartnersClientMockRecorder) SetProductPicture(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductPicture", reflect.TypeOf((*MockPartnersClient)(nil).SetProductPicture), varargs...)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockPartnersClient) UpdateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCategory", varargs...)
+	ret0, _ := ret[0].(*products.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPartnerProducts indicates an expected call of GetPartnerProducts.
-func (mr *MockPartnersClientMockRecorder) GetPartnerProducts(ctx, in any, opts ...any) *gomock.Call {
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockPartnersClientMockRecorder) UpdateCategory(ctx, in any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]any{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerProducts", reflect.TypeOf((*MockPartnersClient)(nil).GetPartnerProducts), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockPartnersClient)(nil).UpdateCategory), varargs...)
+}
+
+// UpdatePartner mocks base method.
+func (m *MockPartnersClient) UpdatePartner(ctx context.Context, in *partners.PartnerInfo, opts ...grpc.CallOption) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdatePartner", varargs...)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePartner indicates an expected call of UpdatePartner.
+func (mr *MockPartnersClientMockRecorder) UpdatePartner(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePartner", reflect.TypeOf((*MockPartnersClient)(nil).UpdatePartner), varargs...)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockPartnersClient) UpdateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateProduct", varargs...)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockPartnersClientMockRecorder) UpdateProduct(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockPartnersClient)(nil).UpdateProduct), varargs...)
 }
 
 // MockPartnersServer is a mock of PartnersServer interface.
@@ -122,6 +682,141 @@ func (mr *MockPartnersServerMockRecorder) CheckPartnerProducts(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPartnerProducts", reflect.TypeOf((*MockPartnersServer)(nil).CheckPartnerProducts), arg0, arg1)
 }
 
+// CreateCategory mocks base method.
+func (m *MockPartnersServer) CreateCategory(arg0 context.Context, arg1 *products.Category) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockPartnersServerMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockPartnersServer)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreatePartner mocks base method.
+func (m *MockPartnersServer) CreatePartner(arg0 context.Context, arg1 *partners.PartnerInfo) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartner", arg0, arg1)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartner indicates an expected call of CreatePartner.
+func (mr *MockPartnersServerMockRecorder) CreatePartner(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartner", reflect.TypeOf((*MockPartnersServer)(nil).CreatePartner), arg0, arg1)
+}
+
+// CreateProduct mocks base method.
+func (m *MockPartnersServer) CreateProduct(arg0 context.Context, arg1 *products.ProductInfo) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockPartnersServerMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockPartnersServer)(nil).CreateProduct), arg0, arg1)
+}
+
+// DeleteAvailable mocks base method.
+func (m *MockPartnersServer) DeleteAvailable(arg0 context.Context, arg1 *products.DeleteAvailableRequest) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvailable indicates an expected call of DeleteAvailable.
+func (mr *MockPartnersServerMockRecorder) DeleteAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvailable", reflect.TypeOf((*MockPartnersServer)(nil).DeleteAvailable), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockPartnersServer) DeleteCategory(arg0 context.Context, arg1 *products.DeleteCategoryRequest) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockPartnersServerMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockPartnersServer)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockPartnersServer) DeleteProduct(arg0 context.Context, arg1 *products.DeleteProductRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockPartnersServerMockRecorder) DeleteProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockPartnersServer)(nil).DeleteProduct), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *MockPartnersServer) GetCategories(arg0 context.Context, arg1 *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
+	ret0, _ := ret[0].(*products.GetCategoriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockPartnersServerMockRecorder) GetCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockPartnersServer)(nil).GetCategories), arg0, arg1)
+}
+
+// GetOpeningHours mocks base method.
+func (m *MockPartnersServer) GetOpeningHours(arg0 context.Context, arg1 *partners.GetPartnerRequest) (*partners.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].(*partners.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningHours indicates an expected call of GetOpeningHours.
+func (mr *MockPartnersServerMockRecorder) GetOpeningHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningHours", reflect.TypeOf((*MockPartnersServer)(nil).GetOpeningHours), arg0, arg1)
+}
+
+// GetPartner mocks base method.
+func (m *MockPartnersServer) GetPartner(arg0 context.Context, arg1 *partners.GetPartnerRequest) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPartner", arg0, arg1)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPartner indicates an expected call of GetPartner.
+func (mr *MockPartnersServerMockRecorder) GetPartner(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartner", reflect.TypeOf((*MockPartnersServer)(nil).GetPartner), arg0, arg1)
+}
+
 // GetPartnerProducts mocks base method.
 func (m *MockPartnersServer) GetPartnerProducts(arg0 context.Context, arg1 *products.GetAllRequest) (*products.GetAllResponse, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +832,291 @@ func (mr *MockPartnersServerMockRecorder) GetPartnerProducts(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerProducts", reflect.TypeOf((*MockPartnersServer)(nil).GetPartnerProducts), arg0, arg1)
 }
 
+// GetProduct mocks base method.
+func (m *MockPartnersServer) GetProduct(arg0 context.Context, arg1 *products.GetProductRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockPartnersServerMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockPartnersServer)(nil).GetProduct), arg0, arg1)
+}
+
+// GetProductOptions mocks base method.
+func (m *MockPartnersServer) GetProductOptions(arg0 context.Context, arg1 *partners.ProductOptionsRequest) (*partners.ProductOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductOptions", arg0, arg1)
+	ret0, _ := ret[0].(*partners.ProductOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductOptions indicates an expected call of GetProductOptions.
+func (mr *MockPartnersServerMockRecorder) GetProductOptions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductOptions", reflect.TypeOf((*MockPartnersServer)(nil).GetProductOptions), arg0, arg1)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockPartnersServer) GetWebhookDelivery(arg0 context.Context, arg1 *partners.GetDeliveryRequest) (*partners.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(*partners.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockPartnersServerMockRecorder) GetWebhookDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockPartnersServer)(nil).GetWebhookDelivery), arg0, arg1)
+}
+
+// ListAvailable mocks base method.
+func (m *MockPartnersServer) ListAvailable(arg0 context.Context, arg1 *products.ListAvailableRequest) (*products.ListAvailableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.ListAvailableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailable indicates an expected call of ListAvailable.
+func (mr *MockPartnersServerMockRecorder) ListAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailable", reflect.TypeOf((*MockPartnersServer)(nil).ListAvailable), arg0, arg1)
+}
+
+// ListPartners mocks base method.
+func (m *MockPartnersServer) ListPartners(arg0 context.Context, arg1 *partners.ListPartnersRequest) (*partners.ListPartnersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartners", arg0, arg1)
+	ret0, _ := ret[0].(*partners.ListPartnersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartners indicates an expected call of ListPartners.
+func (mr *MockPartnersServerMockRecorder) ListPartners(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartners", reflect.TypeOf((*MockPartnersServer)(nil).ListPartners), arg0, arg1)
+}
+
+// ListProducts mocks base method.
+func (m *MockPartnersServer) ListProducts(arg0 context.Context, arg1 *products.ListProductsRequest) (*products.ListProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
+	ret0, _ := ret[0].(*products.ListProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockPartnersServerMockRecorder) ListProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockPartnersServer)(nil).ListProducts), arg0, arg1)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockPartnersServer) ListWebhookDeliveries(arg0 context.Context, arg1 *partners.ListDeliveriesRequest) (*partners.ListDeliveriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(*partners.ListDeliveriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockPartnersServerMockRecorder) ListWebhookDeliveries(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockPartnersServer)(nil).ListWebhookDeliveries), arg0, arg1)
+}
+
+// ReplayWebhookDelivery mocks base method.
+func (m *MockPartnersServer) ReplayWebhookDelivery(arg0 context.Context, arg1 *partners.GetDeliveryRequest) (*partners.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(*partners.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayWebhookDelivery indicates an expected call of ReplayWebhookDelivery.
+func (mr *MockPartnersServerMockRecorder) ReplayWebhookDelivery(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayWebhookDelivery", reflect.TypeOf((*MockPartnersServer)(nil).ReplayWebhookDelivery), arg0, arg1)
+}
+
+// RotateWebhookSecret mocks base method.
+func (m *MockPartnersServer) RotateWebhookSecret(arg0 context.Context, arg1 *partners.GetPartnerRequest) (*partners.WebhookSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateWebhookSecret", arg0, arg1)
+	ret0, _ := ret[0].(*partners.WebhookSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhookSecret indicates an expected call of RotateWebhookSecret.
+func (mr *MockPartnersServerMockRecorder) RotateWebhookSecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhookSecret", reflect.TypeOf((*MockPartnersServer)(nil).RotateWebhookSecret), arg0, arg1)
+}
+
+// SetAvailable mocks base method.
+func (m *MockPartnersServer) SetAvailable(arg0 context.Context, arg1 *products.Availability) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAvailable indicates an expected call of SetAvailable.
+func (mr *MockPartnersServerMockRecorder) SetAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailable", reflect.TypeOf((*MockPartnersServer)(nil).SetAvailable), arg0, arg1)
+}
+
+// SetOpeningHours mocks base method.
+func (m *MockPartnersServer) SetOpeningHours(arg0 context.Context, arg1 *partners.OpeningHours) (*partners.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOpeningHours", arg0, arg1)
+	ret0, _ := ret[0].(*partners.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOpeningHours indicates an expected call of SetOpeningHours.
+func (mr *MockPartnersServerMockRecorder) SetOpeningHours(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOpeningHours", reflect.TypeOf((*MockPartnersServer)(nil).SetOpeningHours), arg0, arg1)
+}
+
+// SetPartnerEnabled mocks base method.
+func (m *MockPartnersServer) SetPartnerEnabled(arg0 context.Context, arg1 *partners.SetPartnerEnabledRequest) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPartnerEnabled", arg0, arg1)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPartnerEnabled indicates an expected call of SetPartnerEnabled.
+func (mr *MockPartnersServerMockRecorder) SetPartnerEnabled(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartnerEnabled", reflect.TypeOf((*MockPartnersServer)(nil).SetPartnerEnabled), arg0, arg1)
+}
+
+// SetPartnerPaused mocks base method.
+func (m *MockPartnersServer) SetPartnerPaused(arg0 context.Context, arg1 *partners.SetPartnerPausedRequest) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPartnerPaused", arg0, arg1)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPartnerPaused indicates an expected call of SetPartnerPaused.
+func (mr *MockPartnersServerMockRecorder) SetPartnerPaused(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartnerPaused", reflect.TypeOf((*MockPartnersServer)(nil).SetPartnerPaused), arg0, arg1)
+}
+
+// SetProductLabels mocks base method.
+func (m *MockPartnersServer) SetProductLabels(arg0 context.Context, arg1 *products.ProductLabels) (*products.ProductLabels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductLabels", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductLabels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductLabels indicates an expected call of SetProductLabels.
+func (mr *MockPartnersServerMockRecorder) SetProductLabels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductLabels", reflect.TypeOf((*MockPartnersServer)(nil).SetProductLabels), arg0, arg1)
+}
+
+// SetProductOptions mocks base method.
+func (m *MockPartnersServer) SetProductOptions(arg0 context.Context, arg1 *partners.ProductOptions) (*partners.ProductOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductOptions", arg0, arg1)
+	ret0, _ := ret[0].(*partners.ProductOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductOptions indicates an expected call of SetProductOptions.
+func (mr *MockPartnersServerMockRecorder) SetProductOptions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductOptions", reflect.TypeOf((*MockPartnersServer)(nil).SetProductOptions), arg0, arg1)
+}
+
+// SetProductPicture mocks base method.
+func (m *MockPartnersServer) SetProductPicture(arg0 context.Context, arg1 *products.ProductPictureRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductPicture", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductPicture indicates an expected call of SetProductPicture.
+func (mr *MockPartnersServerMockRecorder) SetProductPicture(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductPicture", reflect.TypeOf((*MockPartnersServer)(nil).SetProductPicture), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockPartnersServer) UpdateCategory(arg0 context.Context, arg1 *products.Category) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockPartnersServerMockRecorder) UpdateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockPartnersServer)(nil).UpdateCategory), arg0, arg1)
+}
+
+// UpdatePartner mocks base method.
+func (m *MockPartnersServer) UpdatePartner(arg0 context.Context, arg1 *partners.PartnerInfo) (*partners.PartnerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePartner", arg0, arg1)
+	ret0, _ := ret[0].(*partners.PartnerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePartner indicates an expected call of UpdatePartner.
+func (mr *MockPartnersServerMockRecorder) UpdatePartner(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePartner", reflect.TypeOf((*MockPartnersServer)(nil).UpdatePartner), arg0, arg1)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockPartnersServer) UpdateProduct(arg0 context.Context, arg1 *products.ProductInfo) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockPartnersServerMockRecorder) UpdateProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockPartnersServer)(nil).UpdateProduct), arg0, arg1)
+}
+
 // mustEmbedUnimplementedPartnersServer mocks base method.
 func (m *MockPartnersServer) mustEmbedUnimplementedPartnersServer() {
 	m.ctrl.T.Helper()
@@ -0,0 +1,71 @@
+package partners
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shahzodshafizod/gocloud/internal/orders"
+)
+
+// priceOptions prices one of the product with the options chosen: the variant's price instead of
+// the listed one, plus the modifiers'. The chosen options are returned described for the partner.
+func priceOptions(options *ProductOptions, product *orders.Product, listed int32) (int32, []*orders.SelectedOption, error) {
+	var productID = strconv.Itoa(int(product.ID))
+	var price = listed
+	var selected = make([]*orders.SelectedOption, 0, len(product.ModifierIDs)+1)
+
+	if len(options.Variants) > 0 {
+		var variant *Variant
+		for _, v := range options.Variants {
+			if v.ID == product.VariantID {
+				variant = v
+				break
+			}
+		}
+		if variant == nil {
+			return 0, nil, errors.New("product " + productID + ": one of its variants must be chosen")
+		}
+		price = variant.Price
+		selected = append(selected, &orders.SelectedOption{
+			ID:    variant.ID,
+			Title: variant.Title,
+			Price: variant.Price,
+		})
+	} else if product.VariantID != 0 {
+		return 0, nil, errors.New("product " + productID + " has no variants")
+	}
+
+	var chosen = make(map[int32]bool, len(product.ModifierIDs))
+	for _, id := range product.ModifierIDs {
+		if chosen[id] {
+			return 0, nil, errors.New("product " + productID + ": modifier " + strconv.Itoa(int(id)) + " is chosen twice")
+		}
+		chosen[id] = true
+	}
+	for _, group := range options.ModifierGroups {
+		var count int32
+		for _, modifier := range group.Modifiers {
+			if !chosen[modifier.ID] {
+				continue
+			}
+			delete(chosen, modifier.ID)
+			count++
+			price += modifier.Price
+			selected = append(selected, &orders.SelectedOption{
+				ID:    modifier.ID,
+				Group: group.Title,
+				Title: modifier.Title,
+				Price: modifier.Price,
+			})
+		}
+		if count < group.MinSelections || count > group.MaxSelections {
+			return 0, nil, errors.New("product " + productID + ": " +
+				strconv.Itoa(int(group.MinSelections)) + " to " + strconv.Itoa(int(group.MaxSelections)) +
+				" of " + group.Title + " must be chosen")
+		}
+	}
+	if len(chosen) > 0 {
+		return 0, nil, errors.New("product " + productID + ": no such modifiers")
+	}
+	return price, selected, nil
+}
@@ -25,6 +25,34 @@ const _ = grpc.SupportPackageIsVersion7
 type PartnersClient interface {
 	GetPartnerProducts(ctx context.Context, in *products.GetAllRequest, opts ...grpc.CallOption) (*products.GetAllResponse, error)
 	CheckPartnerProducts(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	CreatePartner(ctx context.Context, in *PartnerInfo, opts ...grpc.CallOption) (*PartnerInfo, error)
+	UpdatePartner(ctx context.Context, in *PartnerInfo, opts ...grpc.CallOption) (*PartnerInfo, error)
+	GetPartner(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*PartnerInfo, error)
+	ListPartners(ctx context.Context, in *ListPartnersRequest, opts ...grpc.CallOption) (*ListPartnersResponse, error)
+	SetPartnerEnabled(ctx context.Context, in *SetPartnerEnabledRequest, opts ...grpc.CallOption) (*PartnerInfo, error)
+	SetPartnerPaused(ctx context.Context, in *SetPartnerPausedRequest, opts ...grpc.CallOption) (*PartnerInfo, error)
+	GetOpeningHours(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*OpeningHours, error)
+	SetOpeningHours(ctx context.Context, in *OpeningHours, opts ...grpc.CallOption) (*OpeningHours, error)
+	GetProductOptions(ctx context.Context, in *ProductOptionsRequest, opts ...grpc.CallOption) (*ProductOptions, error)
+	SetProductOptions(ctx context.Context, in *ProductOptions, opts ...grpc.CallOption) (*ProductOptions, error)
+	RotateWebhookSecret(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*WebhookSecret, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+	GetWebhookDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	ReplayWebhookDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	CreateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error)
+	UpdateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error)
+	GetProduct(ctx context.Context, in *products.GetProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error)
+	ListProducts(ctx context.Context, in *products.ListProductsRequest, opts ...grpc.CallOption) (*products.ListProductsResponse, error)
+	SetProductPicture(ctx context.Context, in *products.ProductPictureRequest, opts ...grpc.CallOption) (*products.ProductInfo, error)
+	DeleteProduct(ctx context.Context, in *products.DeleteProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error)
+	SetProductLabels(ctx context.Context, in *products.ProductLabels, opts ...grpc.CallOption) (*products.ProductLabels, error)
+	CreateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error)
+	UpdateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error)
+	DeleteCategory(ctx context.Context, in *products.DeleteCategoryRequest, opts ...grpc.CallOption) (*products.Category, error)
+	GetCategories(ctx context.Context, in *products.GetCategoriesRequest, opts ...grpc.CallOption) (*products.GetCategoriesResponse, error)
+	SetAvailable(ctx context.Context, in *products.Availability, opts ...grpc.CallOption) (*products.Availability, error)
+	ListAvailable(ctx context.Context, in *products.ListAvailableRequest, opts ...grpc.CallOption) (*products.ListAvailableResponse, error)
+	DeleteAvailable(ctx context.Context, in *products.DeleteAvailableRequest, opts ...grpc.CallOption) (*products.Availability, error)
 }
 
 type partnersClient struct {
@@ -53,12 +81,292 @@ func (c *partnersClient) CheckPartnerProducts(ctx context.Context, in *CheckRequ
 	return out, nil
 }
 
+func (c *partnersClient) CreatePartner(ctx context.Context, in *PartnerInfo, opts ...grpc.CallOption) (*PartnerInfo, error) {
+	out := new(PartnerInfo)
+	err := c.cc.Invoke(ctx, "/Partners/CreatePartner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) UpdatePartner(ctx context.Context, in *PartnerInfo, opts ...grpc.CallOption) (*PartnerInfo, error) {
+	out := new(PartnerInfo)
+	err := c.cc.Invoke(ctx, "/Partners/UpdatePartner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetPartner(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*PartnerInfo, error) {
+	out := new(PartnerInfo)
+	err := c.cc.Invoke(ctx, "/Partners/GetPartner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) ListPartners(ctx context.Context, in *ListPartnersRequest, opts ...grpc.CallOption) (*ListPartnersResponse, error) {
+	out := new(ListPartnersResponse)
+	err := c.cc.Invoke(ctx, "/Partners/ListPartners", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetPartnerEnabled(ctx context.Context, in *SetPartnerEnabledRequest, opts ...grpc.CallOption) (*PartnerInfo, error) {
+	out := new(PartnerInfo)
+	err := c.cc.Invoke(ctx, "/Partners/SetPartnerEnabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetPartnerPaused(ctx context.Context, in *SetPartnerPausedRequest, opts ...grpc.CallOption) (*PartnerInfo, error) {
+	out := new(PartnerInfo)
+	err := c.cc.Invoke(ctx, "/Partners/SetPartnerPaused", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetOpeningHours(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*OpeningHours, error) {
+	out := new(OpeningHours)
+	err := c.cc.Invoke(ctx, "/Partners/GetOpeningHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetOpeningHours(ctx context.Context, in *OpeningHours, opts ...grpc.CallOption) (*OpeningHours, error) {
+	out := new(OpeningHours)
+	err := c.cc.Invoke(ctx, "/Partners/SetOpeningHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetProductOptions(ctx context.Context, in *ProductOptionsRequest, opts ...grpc.CallOption) (*ProductOptions, error) {
+	out := new(ProductOptions)
+	err := c.cc.Invoke(ctx, "/Partners/GetProductOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetProductOptions(ctx context.Context, in *ProductOptions, opts ...grpc.CallOption) (*ProductOptions, error) {
+	out := new(ProductOptions)
+	err := c.cc.Invoke(ctx, "/Partners/SetProductOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) RotateWebhookSecret(ctx context.Context, in *GetPartnerRequest, opts ...grpc.CallOption) (*WebhookSecret, error) {
+	out := new(WebhookSecret)
+	err := c.cc.Invoke(ctx, "/Partners/RotateWebhookSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) ListWebhookDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/Partners/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetWebhookDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/Partners/GetWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) ReplayWebhookDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/Partners/ReplayWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) CreateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	out := new(products.ProductInfo)
+	err := c.cc.Invoke(ctx, "/Partners/CreateProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) UpdateProduct(ctx context.Context, in *products.ProductInfo, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	out := new(products.ProductInfo)
+	err := c.cc.Invoke(ctx, "/Partners/UpdateProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetProduct(ctx context.Context, in *products.GetProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	out := new(products.ProductInfo)
+	err := c.cc.Invoke(ctx, "/Partners/GetProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) ListProducts(ctx context.Context, in *products.ListProductsRequest, opts ...grpc.CallOption) (*products.ListProductsResponse, error) {
+	out := new(products.ListProductsResponse)
+	err := c.cc.Invoke(ctx, "/Partners/ListProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetProductPicture(ctx context.Context, in *products.ProductPictureRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	out := new(products.ProductInfo)
+	err := c.cc.Invoke(ctx, "/Partners/SetProductPicture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) DeleteProduct(ctx context.Context, in *products.DeleteProductRequest, opts ...grpc.CallOption) (*products.ProductInfo, error) {
+	out := new(products.ProductInfo)
+	err := c.cc.Invoke(ctx, "/Partners/DeleteProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetProductLabels(ctx context.Context, in *products.ProductLabels, opts ...grpc.CallOption) (*products.ProductLabels, error) {
+	out := new(products.ProductLabels)
+	err := c.cc.Invoke(ctx, "/Partners/SetProductLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) CreateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error) {
+	out := new(products.Category)
+	err := c.cc.Invoke(ctx, "/Partners/CreateCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) UpdateCategory(ctx context.Context, in *products.Category, opts ...grpc.CallOption) (*products.Category, error) {
+	out := new(products.Category)
+	err := c.cc.Invoke(ctx, "/Partners/UpdateCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) DeleteCategory(ctx context.Context, in *products.DeleteCategoryRequest, opts ...grpc.CallOption) (*products.Category, error) {
+	out := new(products.Category)
+	err := c.cc.Invoke(ctx, "/Partners/DeleteCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) GetCategories(ctx context.Context, in *products.GetCategoriesRequest, opts ...grpc.CallOption) (*products.GetCategoriesResponse, error) {
+	out := new(products.GetCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/Partners/GetCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) SetAvailable(ctx context.Context, in *products.Availability, opts ...grpc.CallOption) (*products.Availability, error) {
+	out := new(products.Availability)
+	err := c.cc.Invoke(ctx, "/Partners/SetAvailable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) ListAvailable(ctx context.Context, in *products.ListAvailableRequest, opts ...grpc.CallOption) (*products.ListAvailableResponse, error) {
+	out := new(products.ListAvailableResponse)
+	err := c.cc.Invoke(ctx, "/Partners/ListAvailable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partnersClient) DeleteAvailable(ctx context.Context, in *products.DeleteAvailableRequest, opts ...grpc.CallOption) (*products.Availability, error) {
+	out := new(products.Availability)
+	err := c.cc.Invoke(ctx, "/Partners/DeleteAvailable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PartnersServer is the server API for Partners service.
 // All implementations must embed UnimplementedPartnersServer
 // for forward compatibility
 type PartnersServer interface {
 	GetPartnerProducts(context.Context, *products.GetAllRequest) (*products.GetAllResponse, error)
 	CheckPartnerProducts(context.Context, *CheckRequest) (*CheckResponse, error)
+	CreatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	UpdatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	GetPartner(context.Context, *GetPartnerRequest) (*PartnerInfo, error)
+	ListPartners(context.Context, *ListPartnersRequest) (*ListPartnersResponse, error)
+	SetPartnerEnabled(context.Context, *SetPartnerEnabledRequest) (*PartnerInfo, error)
+	SetPartnerPaused(context.Context, *SetPartnerPausedRequest) (*PartnerInfo, error)
+	GetOpeningHours(context.Context, *GetPartnerRequest) (*OpeningHours, error)
+	SetOpeningHours(context.Context, *OpeningHours) (*OpeningHours, error)
+	GetProductOptions(context.Context, *ProductOptionsRequest) (*ProductOptions, error)
+	SetProductOptions(context.Context, *ProductOptions) (*ProductOptions, error)
+	RotateWebhookSecret(context.Context, *GetPartnerRequest) (*WebhookSecret, error)
+	ListWebhookDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	GetWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error)
+	ReplayWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error)
+	CreateProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error)
+	UpdateProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error)
+	GetProduct(context.Context, *products.GetProductRequest) (*products.ProductInfo, error)
+	ListProducts(context.Context, *products.ListProductsRequest) (*products.ListProductsResponse, error)
+	SetProductPicture(context.Context, *products.ProductPictureRequest) (*products.ProductInfo, error)
+	DeleteProduct(context.Context, *products.DeleteProductRequest) (*products.ProductInfo, error)
+	SetProductLabels(context.Context, *products.ProductLabels) (*products.ProductLabels, error)
+	CreateCategory(context.Context, *products.Category) (*products.Category, error)
+	UpdateCategory(context.Context, *products.Category) (*products.Category, error)
+	DeleteCategory(context.Context, *products.DeleteCategoryRequest) (*products.Category, error)
+	GetCategories(context.Context, *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error)
+	SetAvailable(context.Context, *products.Availability) (*products.Availability, error)
+	ListAvailable(context.Context, *products.ListAvailableRequest) (*products.ListAvailableResponse, error)
+	DeleteAvailable(context.Context, *products.DeleteAvailableRequest) (*products.Availability, error)
 	mustEmbedUnimplementedPartnersServer()
 }
 
@@ -72,6 +380,90 @@ func (UnimplementedPartnersServer) GetPartnerProducts(context.Context, *products
 func (UnimplementedPartnersServer) CheckPartnerProducts(context.Context, *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPartnerProducts not implemented")
 }
+func (UnimplementedPartnersServer) CreatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePartner not implemented")
+}
+func (UnimplementedPartnersServer) UpdatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePartner not implemented")
+}
+func (UnimplementedPartnersServer) GetPartner(context.Context, *GetPartnerRequest) (*PartnerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPartner not implemented")
+}
+func (UnimplementedPartnersServer) ListPartners(context.Context, *ListPartnersRequest) (*ListPartnersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPartners not implemented")
+}
+func (UnimplementedPartnersServer) SetPartnerEnabled(context.Context, *SetPartnerEnabledRequest) (*PartnerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPartnerEnabled not implemented")
+}
+func (UnimplementedPartnersServer) SetPartnerPaused(context.Context, *SetPartnerPausedRequest) (*PartnerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPartnerPaused not implemented")
+}
+func (UnimplementedPartnersServer) GetOpeningHours(context.Context, *GetPartnerRequest) (*OpeningHours, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpeningHours not implemented")
+}
+func (UnimplementedPartnersServer) SetOpeningHours(context.Context, *OpeningHours) (*OpeningHours, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOpeningHours not implemented")
+}
+func (UnimplementedPartnersServer) GetProductOptions(context.Context, *ProductOptionsRequest) (*ProductOptions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductOptions not implemented")
+}
+func (UnimplementedPartnersServer) SetProductOptions(context.Context, *ProductOptions) (*ProductOptions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductOptions not implemented")
+}
+func (UnimplementedPartnersServer) RotateWebhookSecret(context.Context, *GetPartnerRequest) (*WebhookSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateWebhookSecret not implemented")
+}
+func (UnimplementedPartnersServer) ListWebhookDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedPartnersServer) GetWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDelivery not implemented")
+}
+func (UnimplementedPartnersServer) ReplayWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedPartnersServer) CreateProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedPartnersServer) UpdateProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProduct not implemented")
+}
+func (UnimplementedPartnersServer) GetProduct(context.Context, *products.GetProductRequest) (*products.ProductInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedPartnersServer) ListProducts(context.Context, *products.ListProductsRequest) (*products.ListProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedPartnersServer) SetProductPicture(context.Context, *products.ProductPictureRequest) (*products.ProductInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductPicture not implemented")
+}
+func (UnimplementedPartnersServer) DeleteProduct(context.Context, *products.DeleteProductRequest) (*products.ProductInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedPartnersServer) SetProductLabels(context.Context, *products.ProductLabels) (*products.ProductLabels, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductLabels not implemented")
+}
+func (UnimplementedPartnersServer) CreateCategory(context.Context, *products.Category) (*products.Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedPartnersServer) UpdateCategory(context.Context, *products.Category) (*products.Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedPartnersServer) DeleteCategory(context.Context, *products.DeleteCategoryRequest) (*products.Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedPartnersServer) GetCategories(context.Context, *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategories not implemented")
+}
+func (UnimplementedPartnersServer) SetAvailable(context.Context, *products.Availability) (*products.Availability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAvailable not implemented")
+}
+func (UnimplementedPartnersServer) ListAvailable(context.Context, *products.ListAvailableRequest) (*products.ListAvailableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAvailable not implemented")
+}
+func (UnimplementedPartnersServer) DeleteAvailable(context.Context, *products.DeleteAvailableRequest) (*products.Availability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAvailable not implemented")
+}
 func (UnimplementedPartnersServer) mustEmbedUnimplementedPartnersServer() {}
 
 // UnsafePartnersServer may be embedded to opt out of forward compatibility for this service.
@@ -121,20 +513,636 @@ func _Partners_CheckPartnerProducts_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
-// Partners_ServiceDesc is the grpc.ServiceDesc for Partners service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Partners_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "Partners",
-	HandlerType: (*PartnersServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetPartnerProducts",
-			Handler:    _Partners_GetPartnerProducts_Handler,
-		},
-		{
-			MethodName: "CheckPartnerProducts",
-			Handler:    _Partners_CheckPartnerProducts_Handler,
+func _Partners_CreatePartner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartnerInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).CreatePartner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/CreatePartner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).CreatePartner(ctx, req.(*PartnerInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_UpdatePartner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartnerInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).UpdatePartner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/UpdatePartner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).UpdatePartner(ctx, req.(*PartnerInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetPartner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetPartner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetPartner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetPartner(ctx, req.(*GetPartnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_ListPartners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPartnersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).ListPartners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/ListPartners",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).ListPartners(ctx, req.(*ListPartnersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetPartnerEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPartnerEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetPartnerEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetPartnerEnabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetPartnerEnabled(ctx, req.(*SetPartnerEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetPartnerPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPartnerPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetPartnerPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetPartnerPaused",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetPartnerPaused(ctx, req.(*SetPartnerPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetOpeningHours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetOpeningHours(ctx, req.(*GetPartnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpeningHours)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetOpeningHours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetOpeningHours(ctx, req.(*OpeningHours))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetProductOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetProductOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetProductOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetProductOptions(ctx, req.(*ProductOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetProductOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetProductOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetProductOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetProductOptions(ctx, req.(*ProductOptions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_RotateWebhookSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).RotateWebhookSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/RotateWebhookSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).RotateWebhookSecret(ctx, req.(*GetPartnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).ListWebhookDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetWebhookDelivery(ctx, req.(*GetDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/ReplayWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).ReplayWebhookDelivery(ctx, req.(*GetDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ProductInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/CreateProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).CreateProduct(ctx, req.(*products.ProductInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_UpdateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ProductInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).UpdateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/UpdateProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).UpdateProduct(ctx, req.(*products.ProductInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetProduct(ctx, req.(*products.GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/ListProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).ListProducts(ctx, req.(*products.ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetProductPicture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ProductPictureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetProductPicture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetProductPicture",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetProductPicture(ctx, req.(*products.ProductPictureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/DeleteProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).DeleteProduct(ctx, req.(*products.DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetProductLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ProductLabels)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetProductLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetProductLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetProductLabels(ctx, req.(*products.ProductLabels))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.Category)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/CreateCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).CreateCategory(ctx, req.(*products.Category))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.Category)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/UpdateCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).UpdateCategory(ctx, req.(*products.Category))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/DeleteCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).DeleteCategory(ctx, req.(*products.DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_GetCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.GetCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).GetCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/GetCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).GetCategories(ctx, req.(*products.GetCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_SetAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.Availability)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).SetAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/SetAvailable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).SetAvailable(ctx, req.(*products.Availability))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_ListAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.ListAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).ListAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/ListAvailable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).ListAvailable(ctx, req.(*products.ListAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Partners_DeleteAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(products.DeleteAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartnersServer).DeleteAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Partners/DeleteAvailable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartnersServer).DeleteAvailable(ctx, req.(*products.DeleteAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Partners_ServiceDesc is the grpc.ServiceDesc for Partners service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Partners_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Partners",
+	HandlerType: (*PartnersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPartnerProducts",
+			Handler:    _Partners_GetPartnerProducts_Handler,
+		},
+		{
+			MethodName: "CheckPartnerProducts",
+			Handler:    _Partners_CheckPartnerProducts_Handler,
+		},
+		{
+			MethodName: "CreatePartner",
+			Handler:    _Partners_CreatePartner_Handler,
+		},
+		{
+			MethodName: "UpdatePartner",
+			Handler:    _Partners_UpdatePartner_Handler,
+		},
+		{
+			MethodName: "GetPartner",
+			Handler:    _Partners_GetPartner_Handler,
+		},
+		{
+			MethodName: "ListPartners",
+			Handler:    _Partners_ListPartners_Handler,
+		},
+		{
+			MethodName: "SetPartnerEnabled",
+			Handler:    _Partners_SetPartnerEnabled_Handler,
+		},
+		{
+			MethodName: "SetPartnerPaused",
+			Handler:    _Partners_SetPartnerPaused_Handler,
+		},
+		{
+			MethodName: "GetOpeningHours",
+			Handler:    _Partners_GetOpeningHours_Handler,
+		},
+		{
+			MethodName: "SetOpeningHours",
+			Handler:    _Partners_SetOpeningHours_Handler,
+		},
+		{
+			MethodName: "GetProductOptions",
+			Handler:    _Partners_GetProductOptions_Handler,
+		},
+		{
+			MethodName: "SetProductOptions",
+			Handler:    _Partners_SetProductOptions_Handler,
+		},
+		{
+			MethodName: "RotateWebhookSecret",
+			Handler:    _Partners_RotateWebhookSecret_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Partners_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "GetWebhookDelivery",
+			Handler:    _Partners_GetWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _Partners_ReplayWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "CreateProduct",
+			Handler:    _Partners_CreateProduct_Handler,
+		},
+		{
+			MethodName: "UpdateProduct",
+			Handler:    _Partners_UpdateProduct_Handler,
+		},
+		{
+			MethodName: "GetProduct",
+			Handler:    _Partners_GetProduct_Handler,
+		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _Partners_ListProducts_Handler,
+		},
+		{
+			MethodName: "SetProductPicture",
+			Handler:    _Partners_SetProductPicture_Handler,
+		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _Partners_DeleteProduct_Handler,
+		},
+		{
+			MethodName: "SetProductLabels",
+			Handler:    _Partners_SetProductLabels_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _Partners_CreateCategory_Handler,
+		},
+		{
+			MethodName: "UpdateCategory",
+			Handler:    _Partners_UpdateCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _Partners_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "GetCategories",
+			Handler:    _Partners_GetCategories_Handler,
+		},
+		{
+			MethodName: "SetAvailable",
+			Handler:    _Partners_SetAvailable_Handler,
+		},
+		{
+			MethodName: "ListAvailable",
+			Handler:    _Partners_ListAvailable_Handler,
+		},
+		{
+			MethodName: "DeleteAvailable",
+			Handler:    _Partners_DeleteAvailable_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
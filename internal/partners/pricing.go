@@ -0,0 +1,44 @@
+package partners
+
+import (
+	"math"
+
+	"github.com/shahzodshafizod/gocloud/internal/orders"
+)
+
+// pricingRules are the fees and the tax added on top of the products of an order.
+// Amounts are in the minor units of the currency, rates are in basis points.
+type pricingRules struct {
+	BaseDeliveryFee     int64
+	IncludedKm          float64
+	PerKmFee            int64
+	SmallOrderThreshold int64
+	SmallOrderSurcharge int64
+	ServiceFeeRate      int64
+	TaxRate             int64
+}
+
+func (p *pricingRules) price(subtotal int64, distanceKm float64) *orders.Pricing {
+	var pricing = &orders.Pricing{
+		Subtotal:    subtotal,
+		DeliveryFee: p.BaseDeliveryFee,
+		DistanceKm:  distanceKm,
+		TaxRate:     p.TaxRate,
+	}
+	if extraKm := distanceKm - p.IncludedKm; extraKm > 0 {
+		pricing.DeliveryFee += int64(math.Ceil(extraKm)) * p.PerKmFee
+	}
+	if subtotal < p.SmallOrderThreshold {
+		pricing.SmallOrderFee = p.SmallOrderSurcharge
+	}
+	pricing.ServiceFee = applyRate(subtotal, p.ServiceFeeRate)
+	beforeTax := subtotal + pricing.DeliveryFee + pricing.SmallOrderFee + pricing.ServiceFee
+	pricing.Tax = applyRate(beforeTax, p.TaxRate)
+	pricing.Total = beforeTax + pricing.Tax
+	return pricing
+}
+
+// applyRate returns the `rate` basis points of the amount, rounded half up.
+func applyRate(amount int64, rate int64) int64 {
+	return (amount*rate + 5000) / 10000
+}
@@ -2,14 +2,44 @@ package partners
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shahzodshafizod/gocloud/internal/orders"
 	"github.com/shahzodshafizod/gocloud/pkg"
 )
 
 type Repository interface {
 	checkPartnerProducts(context.Context, *CheckRequest) (*CheckResponse, error)
-	getPartnerApiURL(context.Context, int) (string, error)
+	updateRating(context.Context, *orders.PartnerRating) error
+	closedReason(ctx context.Context, partnerID int32, at time.Time) (string, error)
+	scheduleForward(ctx context.Context, order *orders.PaidOrder, forwardAt time.Time) error
+	claimDueForwards(ctx context.Context, limit int, lockFor time.Duration) ([]*orders.PaidOrder, error)
+	markForwarded(ctx context.Context, orderID int64) error
+	failForward(ctx context.Context, orderID int64, reason string) error
+	createPartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	updatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	getPartner(ctx context.Context, id int32) (*PartnerInfo, error)
+	getPartnerByUser(ctx context.Context, userID string) (*PartnerInfo, error)
+	reserveStock(ctx context.Context, reservationID string, partnerID int32, products []*orders.Product, expiresAt time.Time) ([]*StockShortage, error)
+	holdStock(ctx context.Context, reservationID string, orderID int64) error
+	releaseOrders(ctx context.Context, orderIDs []int64) error
+	releaseExpired(context.Context) error
+	listPartners(ctx context.Context, enabledOnly bool) ([]*PartnerInfo, error)
+	setPartnerEnabled(ctx context.Context, id int32, enabled bool) (*PartnerInfo, error)
+	setPartnerPaused(ctx context.Context, id int32, paused bool) (*PartnerInfo, error)
+	getOpeningHours(ctx context.Context, partnerID int32) (*OpeningHours, error)
+	setOpeningHours(context.Context, *OpeningHours) error
+	getProductOptions(ctx context.Context, productID int32, partnerID int32) (*ProductOptions, error)
+	setProductOptions(context.Context, *ProductOptions) error
+	rotateWebhookSecret(ctx context.Context, partnerID int32, secret string) error
+	enqueueWebhook(ctx context.Context, event string, orderID int64, partnerID int, payload any, lockFor time.Duration) (*webhook, error)
+	claimDueWebhooks(ctx context.Context, limit int, lockFor time.Duration) ([]*webhook, error)
+	replayWebhook(ctx context.Context, deliveryID int64, lockFor time.Duration) (*webhook, error)
+	logWebhookAttempt(ctx context.Context, deliveryID int64, attempt *WebhookAttempt, status string, nextAttemptAt time.Time) error
+	listWebhookDeliveries(context.Context, *ListDeliveriesRequest) ([]*WebhookDelivery, error)
+	getWebhookDelivery(ctx context.Context, deliveryID int64) (*WebhookDelivery, error)
 }
 
 type repository struct {
@@ -28,43 +58,998 @@ func (r *repository) checkPartnerProducts(ctx context.Context, req *CheckRequest
 		pts.title
 		, pts.brand
 		, ava.price
+		, pts.latitude
+		, pts.longitude
+		, pds.title
+		, pts.email
+		, GREATEST($3 - COALESCE(ava.stock, $3), 0)
+		, EXISTS (SELECT 1 FROM product_variants WHERE product_id = ava.product_id AND partner_id = ava.partner_id)
+			OR EXISTS (SELECT 1 FROM modifier_groups WHERE product_id = ava.product_id AND partner_id = ava.partner_id)
 	FROM available ava
 	INNER JOIN partners pts ON pts.id = ava.partner_id AND pts.enabled
 	INNER JOIN products pds ON pds.id = ava.product_id
 	WHERE ava.active AND ava.product_id = $1 AND ava.partner_id = $2`
 	var err error
-	var title, brand string
+	var title, brand, email string
+	var latitude, longitude float64
 	var totalAmount int64 = 0
+	var products = make([]*orders.Product, 0, len(req.Products))
+	var unavailable []*orders.Product
+	var shortages []*StockShortage
 	for idx := range req.Products {
+		var short int32 // how many of the product the stock is short of
+		var hasOptions bool
 		err = r.postgres.QueryRow(ctx, query,
 			req.Products[idx].ID,
 			req.PartnerID,
+			req.Products[idx].Quantity,
 		).Scan(
 			&title,
 			&brand,
 			&req.Products[idx].Price,
+			&latitude,
+			&longitude,
+			&req.Products[idx].Title,
+			&email,
+			&short,
+			&hasOptions,
 		)
+		if errors.Is(err, pkg.ErrNoRows) {
+			err, short = nil, req.Products[idx].Quantity
+		}
 		if err != nil {
 			return nil, errors.Wrap(err, "r.postgres.QueryRow.Scan")
 		}
+		if short > 0 {
+			if req.Reorder {
+				unavailable = append(unavailable, req.Products[idx])
+				continue
+			}
+			shortages = append(shortages, &StockShortage{
+				ProductID: req.Products[idx].ID,
+				Title:     req.Products[idx].Title,
+				Requested: req.Products[idx].Quantity,
+				Available: req.Products[idx].Quantity - short,
+			})
+			continue
+		}
+
+		var options = &ProductOptions{}
+		if hasOptions {
+			options, err = r.getProductOptions(ctx, req.Products[idx].ID, req.PartnerID)
+			if err != nil {
+				return nil, errors.Wrap(err, "r.getProductOptions")
+			}
+		}
+		var price int32
+		var selected []*orders.SelectedOption
+		price, selected, err = priceOptions(options, req.Products[idx], req.Products[idx].Price)
+		if err != nil {
+			// the options of a past order might be gone since
+			if req.Reorder {
+				unavailable = append(unavailable, req.Products[idx])
+				continue
+			}
+			return nil, errors.Wrap(err, "priceOptions")
+		}
+		req.Products[idx].Price = price
+		req.Products[idx].Options = selected
+
+		products = append(products, req.Products[idx])
 		totalAmount += int64(req.Products[idx].Price * req.Products[idx].Quantity)
 	}
-	if totalAmount != req.TotalAmount {
+	if len(shortages) > 0 {
+		return &CheckResponse{
+			PartnerTitle: title,
+			PartnerBrand: brand,
+			Products:     products,
+			Shortages:    shortages,
+		}, nil
+	}
+	if len(products) == 0 {
+		return nil, errors.New("none of the products is available")
+	}
+	if !req.Reorder && totalAmount != req.TotalAmount {
 		return nil, errors.New("incorrectly calculated TotalAmount")
 	}
+
+	rules, err := r.getPricingRules(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.getPricingRules")
+	}
+	var distance float64
+	if address := req.DeliveryAddress; address != nil {
+		// an address without the coordinates would be delivered for the base fee from anywhere
+		if (latitude != 0 || longitude != 0) && address.Latitude == 0 && address.Longitude == 0 {
+			return nil, errors.New("the delivery address has no coordinates")
+		}
+		distance = orders.DistanceKm(latitude, longitude, address.Latitude, address.Longitude)
+	}
+
+	// the delivery address can't be changed farther from the partner later
+	pricing := rules.price(totalAmount, distance)
+	pricing.OriginLatitude, pricing.OriginLongitude = latitude, longitude
+
 	return &CheckResponse{
 		PartnerTitle: title,
 		PartnerBrand: brand,
-		Products:     req.Products,
+		PartnerEmail: email,
+		Products:     products,
+		Unavailable:  unavailable,
+		Pricing:      pricing,
 	}, nil
 }
 
-func (r *repository) getPartnerApiURL(ctx context.Context, partnerID int) (string, error) {
-	query := `SELECT api_url FROM partners WHERE id = $1`
-	var apiURL string
-	err := r.postgres.QueryRow(ctx, query, partnerID).Scan(&apiURL)
+// reserveStock takes the products off the stock under the reservation, replacing the previous one
+// under the same id. The products short of stock are returned, nothing is reserved then.
+func (r *repository) reserveStock(ctx context.Context, reservationID string, partnerID int32, products []*orders.Product, expiresAt time.Time) ([]*StockShortage, error) {
+	for _, product := range products {
+		// a negative quantity would put the stock back on sale instead
+		if product.Quantity <= 0 {
+			return nil, errors.New("product " + strconv.Itoa(int(product.ID)) + ": quantity must be positive")
+		}
+	}
+
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	err = tx.Exec(ctx, releaseQuery(`reservation_id = $1 AND order_id IS NULL`), reservationID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "release stock_reservations")
+	}
+
+	var shortages []*StockShortage
+	for _, product := range products {
+		// the stock not tracked is left NULL
+		query := `UPDATE available
+		SET stock = stock - $3
+		WHERE product_id = $1 AND partner_id = $2 AND (stock IS NULL OR stock >= $3)
+		RETURNING stock IS NOT NULL`
+		var tracked bool
+		err = tx.QueryRow(ctx, query, product.ID, partnerID, product.Quantity).Scan(&tracked)
+		if errors.Is(err, pkg.ErrNoRows) {
+			var left int32
+			query = `SELECT COALESCE(stock, 0) FROM available WHERE product_id = $1 AND partner_id = $2`
+			err = tx.QueryRow(ctx, query, product.ID, partnerID).Scan(&left)
+			if err != nil && !errors.Is(err, pkg.ErrNoRows) {
+				tx.Rollback(ctx)
+				return nil, errors.Wrap(err, "SELECT available")
+			}
+			shortages = append(shortages, &StockShortage{
+				ProductID: product.ID,
+				Title:     product.Title,
+				Requested: product.Quantity,
+				Available: left,
+			})
+			continue
+		}
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, errors.Wrap(err, "UPDATE available")
+		}
+		if !tracked {
+			continue
+		}
+
+		query = `INSERT INTO stock_reservations (reservation_id, product_id, partner_id, quantity, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+		err = tx.Exec(ctx, query, reservationID, product.ID, partnerID, product.Quantity, expiresAt)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, errors.Wrap(err, "INSERT INTO stock_reservations")
+		}
+	}
+	if len(shortages) > 0 {
+		tx.Rollback(ctx)
+		return shortages, nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Commit")
+	}
+	return nil, nil
+}
+
+// holdStock holds the reservation for the order, so it doesn't expire. The reservation is gone
+// if it has expired already, or there is none if the stock of the products isn't tracked.
+func (r *repository) holdStock(ctx context.Context, reservationID string, orderID int64) error {
+	query := `UPDATE stock_reservations
+	SET
+		order_id = $2
+		, expires_at = NULL
+	WHERE reservation_id = $1 AND order_id IS NULL`
+	err := r.postgres.Exec(ctx, query, reservationID, orderID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+func (r *repository) releaseOrders(ctx context.Context, orderIDs []int64) error {
+	err := r.postgres.Exec(ctx, releaseQuery(`order_id = ANY($1)`), orderIDs)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+func (r *repository) releaseExpired(ctx context.Context) error {
+	err := r.postgres.Exec(ctx, releaseQuery(`order_id IS NULL AND expires_at < now()`))
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// releaseQuery deletes the reservations matching the condition
+// and puts their stock back on sale, in one statement.
+func releaseQuery(condition string) string {
+	return `WITH released AS (
+		DELETE FROM stock_reservations
+		WHERE ` + condition + `
+		RETURNING product_id, partner_id, quantity
+	)
+	UPDATE available ava
+	SET stock = ava.stock + rel.quantity
+	FROM (
+		SELECT product_id, partner_id, sum(quantity) AS quantity
+		FROM released
+		GROUP BY product_id, partner_id
+	) rel
+	WHERE ava.product_id = rel.product_id AND ava.partner_id = rel.partner_id AND ava.stock IS NOT NULL`
+}
+
+func (r *repository) getPricingRules(ctx context.Context) (*pricingRules, error) {
+	query := `SELECT
+		base_delivery_fee
+		, included_km
+		, per_km_fee
+		, small_order_threshold
+		, small_order_surcharge
+		, service_fee_rate
+		, tax_rate
+	FROM pricing
+	WHERE active
+	ORDER BY id DESC
+	LIMIT 1`
+	var rules = &pricingRules{}
+	err := r.postgres.QueryRow(ctx, query).Scan(
+		&rules.BaseDeliveryFee,
+		&rules.IncludedKm,
+		&rules.PerKmFee,
+		&rules.SmallOrderThreshold,
+		&rules.SmallOrderSurcharge,
+		&rules.ServiceFeeRate,
+		&rules.TaxRate,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return rules, nil
+}
+
+func (r *repository) updateRating(ctx context.Context, rating *orders.PartnerRating) error {
+	query := `UPDATE partners SET rating_sum = $1, rating_count = $2 WHERE id = $3`
+	err := r.postgres.Exec(ctx, query, rating.RatingSum, rating.RatingCount, rating.PartnerID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// closedReason tells why the partner takes no orders at the time, in the partner's timezone:
+// paused, holiday or closed (out of the opening hours); empty - it's open.
+func (r *repository) closedReason(ctx context.Context, partnerID int32, at time.Time) (string, error) {
+	query := `SELECT COALESCE(partner_closed_reason($1, $2), '')`
+	var reason string
+	err := r.postgres.QueryRow(ctx, query, partnerID, at).Scan(&reason)
 	if err != nil {
 		return "", errors.Wrap(err, "r.postgres.QueryRow")
 	}
-	return apiURL, nil
+	return reason, nil
+}
+
+func (r *repository) scheduleForward(ctx context.Context, order *orders.PaidOrder, forwardAt time.Time) error {
+	// a redelivered orders.paid message must not schedule the order twice
+	query := `INSERT INTO scheduled_forwards (order_id, partner_id, payload, forward_at)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (order_id) DO NOTHING`
+	err := r.postgres.Exec(ctx, query, order.OrderID, order.PartnerID, order, forwardAt)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// claimDueForwards locks the due orders for `lockFor`, so the other instances skip them,
+// and the ones that fail to be forwarded are retried once the lock expires.
+func (r *repository) claimDueForwards(ctx context.Context, limit int, lockFor time.Duration) ([]*orders.PaidOrder, error) {
+	query := `UPDATE scheduled_forwards
+	SET
+		locked_until = now() + make_interval(secs => $2)
+		, attempts = attempts + 1
+	WHERE order_id IN (
+		SELECT order_id
+		FROM scheduled_forwards
+		WHERE
+			forwarded_at IS NULL
+			AND forward_at <= now()
+			AND (locked_until IS NULL OR locked_until < now())
+		ORDER BY forward_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING payload`
+	rows, err := r.postgres.Query(ctx, query, limit, lockFor.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var due = make([]*orders.PaidOrder, 0)
+	for rows.Next() {
+		var order = &orders.PaidOrder{}
+		err = rows.Scan(order)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		due = append(due, order)
+	}
+	return due, nil
+}
+
+func (r *repository) markForwarded(ctx context.Context, orderID int64) error {
+	query := `UPDATE scheduled_forwards SET forwarded_at = now(), last_error = '' WHERE order_id = $1`
+	err := r.postgres.Exec(ctx, query, orderID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+func (r *repository) failForward(ctx context.Context, orderID int64, reason string) error {
+	query := `UPDATE scheduled_forwards SET last_error = $1 WHERE order_id = $2`
+	err := r.postgres.Exec(ctx, query, reason, orderID)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+const partnerColumns = `id, title, brand, phone, email, address, api_url, latitude, longitude, timezone, verified, enabled, rating, COALESCE(user_id, ''), paused`
+
+func scanPartner(row pkg.Row) (*PartnerInfo, error) {
+	var partner = &PartnerInfo{}
+	err := row.Scan(
+		&partner.ID,
+		&partner.Title,
+		&partner.Brand,
+		&partner.Phone,
+		&partner.Email,
+		&partner.Address,
+		&partner.ApiURL,
+		&partner.Latitude,
+		&partner.Longitude,
+		&partner.Timezone,
+		&partner.Verified,
+		&partner.Enabled,
+		&partner.Rating,
+		&partner.UserID,
+		&partner.Paused,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return partner, nil
+}
+
+func (r *repository) createPartner(ctx context.Context, p *PartnerInfo) (*PartnerInfo, error) {
+	query := `INSERT INTO partners (
+		title
+		, brand
+		, phone
+		, email
+		, address
+		, api_url
+		, latitude
+		, longitude
+		, timezone
+		, verified
+		, enabled
+		, user_id
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))
+	RETURNING ` + partnerColumns
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query,
+		p.Title,
+		p.Brand,
+		p.Phone,
+		p.Email,
+		p.Address,
+		p.ApiURL,
+		p.Latitude,
+		p.Longitude,
+		p.Timezone,
+		p.Verified,
+		p.Enabled,
+		p.UserID,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+func (r *repository) updatePartner(ctx context.Context, p *PartnerInfo) (*PartnerInfo, error) {
+	query := `UPDATE partners
+	SET
+		title = $2
+		, brand = $3
+		, phone = $4
+		, email = $5
+		, address = $6
+		, api_url = $7
+		, latitude = $8
+		, longitude = $9
+		, timezone = $10
+		, verified = $11
+		, user_id = NULLIF($12, '')
+		, updated_at = now()
+	WHERE id = $1
+	RETURNING ` + partnerColumns
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query,
+		p.ID,
+		p.Title,
+		p.Brand,
+		p.Phone,
+		p.Email,
+		p.Address,
+		p.ApiURL,
+		p.Latitude,
+		p.Longitude,
+		p.Timezone,
+		p.Verified,
+		p.UserID,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+func (r *repository) getPartner(ctx context.Context, id int32) (*PartnerInfo, error) {
+	query := `SELECT ` + partnerColumns + ` FROM partners WHERE id = $1`
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+// getPartnerByUser finds the partner of its staff's account.
+func (r *repository) getPartnerByUser(ctx context.Context, userID string) (*PartnerInfo, error) {
+	query := `SELECT ` + partnerColumns + ` FROM partners WHERE user_id = $1`
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query, userID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+func (r *repository) listPartners(ctx context.Context, enabledOnly bool) ([]*PartnerInfo, error) {
+	query := `SELECT ` + partnerColumns + ` FROM partners WHERE enabled OR NOT $1 ORDER BY id`
+	rows, err := r.postgres.Query(ctx, query, enabledOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var partners = make([]*PartnerInfo, 0)
+	for rows.Next() {
+		partner, err := scanPartner(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		partners = append(partners, partner)
+	}
+	return partners, nil
+}
+
+func (r *repository) setPartnerEnabled(ctx context.Context, id int32, enabled bool) (*PartnerInfo, error) {
+	query := `UPDATE partners SET enabled = $2, updated_at = now() WHERE id = $1 RETURNING ` + partnerColumns
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query, id, enabled))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+func (r *repository) setPartnerPaused(ctx context.Context, id int32, paused bool) (*PartnerInfo, error) {
+	query := `UPDATE partners SET paused = $2, updated_at = now() WHERE id = $1 RETURNING ` + partnerColumns
+	partner, err := scanPartner(r.postgres.QueryRow(ctx, query, id, paused))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return partner, nil
+}
+
+// getOpeningHours gets the partner's weekly opening hours and the closures not over yet.
+func (r *repository) getOpeningHours(ctx context.Context, partnerID int32) (*OpeningHours, error) {
+	var hours = &OpeningHours{
+		PartnerID: partnerID,
+		Weekly:    make([]*OpeningPeriod, 0),
+		Closures:  make([]*Closure, 0),
+	}
+	query := `SELECT timezone FROM partners WHERE id = $1`
+	err := r.postgres.QueryRow(ctx, query, partnerID).Scan(&hours.Timezone)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+
+	query = `SELECT weekday, to_char(opens_at, 'HH24:MI'), to_char(closes_at, 'HH24:MI')
+	FROM opening_hours
+	WHERE partner_id = $1
+	ORDER BY weekday, opens_at`
+	rows, err := r.postgres.Query(ctx, query, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query opening_hours")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var period = &OpeningPeriod{}
+		err = rows.Scan(&period.Weekday, &period.OpensAt, &period.ClosesAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan opening_hours")
+		}
+		hours.Weekly = append(hours.Weekly, period)
+	}
+
+	query = `SELECT to_char(pc.starts_on, 'YYYY-MM-DD'), to_char(pc.ends_on, 'YYYY-MM-DD'), pc.reason
+	FROM partner_closures pc
+	INNER JOIN partners pts ON pts.id = pc.partner_id
+	WHERE pc.partner_id = $1 AND pc.ends_on >= (now() AT TIME ZONE pts.timezone)::DATE
+	ORDER BY pc.starts_on`
+	closures, err := r.postgres.Query(ctx, query, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query partner_closures")
+	}
+	defer closures.Close()
+	for closures.Next() {
+		var closure = &Closure{}
+		err = closures.Scan(&closure.StartsOn, &closure.EndsOn, &closure.Reason)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan partner_closures")
+		}
+		hours.Closures = append(hours.Closures, closure)
+	}
+	return hours, nil
+}
+
+// setOpeningHours replaces the partner's weekly opening hours and closures.
+func (r *repository) setOpeningHours(ctx context.Context, hours *OpeningHours) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM opening_hours WHERE partner_id = $1`, hours.PartnerID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM opening_hours")
+	}
+	for _, period := range hours.Weekly {
+		query := `INSERT INTO opening_hours (partner_id, weekday, opens_at, closes_at)
+		VALUES ($1, $2, $3::TIME, $4::TIME)`
+		err = tx.Exec(ctx, query, hours.PartnerID, period.Weekday, period.OpensAt, period.ClosesAt)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO opening_hours")
+		}
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM partner_closures WHERE partner_id = $1`, hours.PartnerID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM partner_closures")
+	}
+	for _, closure := range hours.Closures {
+		query := `INSERT INTO partner_closures (partner_id, starts_on, ends_on, reason)
+		VALUES ($1, $2::DATE, $3::DATE, $4)`
+		err = tx.Exec(ctx, query, hours.PartnerID, closure.StartsOn, closure.EndsOn, closure.Reason)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO partner_closures")
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+// getProductOptions gets the variants and the modifier groups the partner sells its product with.
+func (r *repository) getProductOptions(ctx context.Context, productID int32, partnerID int32) (*ProductOptions, error) {
+	var options = &ProductOptions{
+		ProductID:      productID,
+		PartnerID:      partnerID,
+		Variants:       make([]*Variant, 0),
+		ModifierGroups: make([]*ModifierGroup, 0),
+	}
+	query := `SELECT id, title, price
+	FROM product_variants
+	WHERE product_id = $1 AND partner_id = $2
+	ORDER BY id`
+	rows, err := r.postgres.Query(ctx, query, productID, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query product_variants")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var variant = &Variant{}
+		err = rows.Scan(&variant.ID, &variant.Title, &variant.Price)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan product_variants")
+		}
+		options.Variants = append(options.Variants, variant)
+	}
+
+	// a group's modifiers follow it
+	query = `SELECT mg.id, mg.title, mg.min_selections, mg.max_selections, mds.id, mds.title, mds.price
+	FROM modifier_groups mg
+	INNER JOIN modifiers mds ON mds.group_id = mg.id
+	WHERE mg.product_id = $1 AND mg.partner_id = $2
+	ORDER BY mg.id, mds.id`
+	modifiers, err := r.postgres.Query(ctx, query, productID, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query modifier_groups")
+	}
+	defer modifiers.Close()
+	var group *ModifierGroup
+	for modifiers.Next() {
+		var g = &ModifierGroup{}
+		var modifier = &Modifier{}
+		err = modifiers.Scan(&g.ID, &g.Title, &g.MinSelections, &g.MaxSelections, &modifier.ID, &modifier.Title, &modifier.Price)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan modifier_groups")
+		}
+		if group == nil || group.ID != g.ID {
+			group = g
+			options.ModifierGroups = append(options.ModifierGroups, group)
+		}
+		group.Modifiers = append(group.Modifiers, modifier)
+	}
+	return options, nil
+}
+
+// setProductOptions replaces the variants and the modifier groups of the partner's product,
+// pkg.ErrNoRows is returned if the partner doesn't sell it.
+func (r *repository) setProductOptions(ctx context.Context, options *ProductOptions) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	var listed bool
+	query := `SELECT TRUE FROM available WHERE product_id = $1 AND partner_id = $2 FOR UPDATE`
+	err = tx.QueryRow(ctx, query, options.ProductID, options.PartnerID).Scan(&listed)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "SELECT available")
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM product_variants WHERE product_id = $1 AND partner_id = $2`, options.ProductID, options.PartnerID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM product_variants")
+	}
+	for _, variant := range options.Variants {
+		query = `INSERT INTO product_variants (product_id, partner_id, title, price) VALUES ($1, $2, $3, $4)`
+		err = tx.Exec(ctx, query, options.ProductID, options.PartnerID, variant.Title, variant.Price)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO product_variants")
+		}
+	}
+
+	// the modifiers are deleted with their groups
+	err = tx.Exec(ctx, `DELETE FROM modifier_groups WHERE product_id = $1 AND partner_id = $2`, options.ProductID, options.PartnerID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM modifier_groups")
+	}
+	for _, group := range options.ModifierGroups {
+		var groupID int32
+		query = `INSERT INTO modifier_groups (product_id, partner_id, title, min_selections, max_selections)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+		err = tx.QueryRow(ctx, query,
+			options.ProductID,
+			options.PartnerID,
+			group.Title,
+			group.MinSelections,
+			group.MaxSelections,
+		).Scan(&groupID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO modifier_groups")
+		}
+		for _, modifier := range group.Modifiers {
+			query = `INSERT INTO modifiers (group_id, title, price) VALUES ($1, $2, $3)`
+			err = tx.Exec(ctx, query, groupID, modifier.Title, modifier.Price)
+			if err != nil {
+				tx.Rollback(ctx)
+				return errors.Wrap(err, "INSERT INTO modifiers")
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+func (r *repository) rotateWebhookSecret(ctx context.Context, partnerID int32, secret string) error {
+	query := `UPDATE partners SET webhook_secret = $2 WHERE id = $1`
+	err := r.postgres.Exec(ctx, query, partnerID, secret)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Exec")
+	}
+	return nil
+}
+
+// webhookTarget joins a claimed delivery (d) with the partner API it's sent to,
+// the partner's api url and secret are taken at the attempt.
+const webhookTarget = `SELECT d.id, d.partner_id, d.event, d.payload, d.attempts, pts.api_url, pts.webhook_secret
+	FROM d
+	INNER JOIN partners pts ON pts.id = d.partner_id`
+
+func scanWebhook(row pkg.Row) (*webhook, error) {
+	var hook = &webhook{}
+	err := row.Scan(
+		&hook.ID,
+		&hook.PartnerID,
+		&hook.Event,
+		&hook.Payload,
+		&hook.Attempts,
+		&hook.APIURL,
+		&hook.Secret,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// enqueueWebhook queues the event claimed for its first attempt, pkg.ErrNoRows is returned
+// if it's queued already.
+func (r *repository) enqueueWebhook(ctx context.Context, event string, orderID int64, partnerID int, payload any, lockFor time.Duration) (*webhook, error) {
+	query := `WITH d AS (
+		INSERT INTO webhook_deliveries (partner_id, event, order_id, payload, locked_until)
+		VALUES ($1, $2, $3, $4, now() + make_interval(secs => $5))
+		ON CONFLICT (event, order_id) DO NOTHING
+		RETURNING id, partner_id, event, payload, attempts
+	)
+	` + webhookTarget
+	hook, err := scanWebhook(r.postgres.QueryRow(ctx, query, partnerID, event, orderID, payload, lockFor.Seconds()))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return hook, nil
+}
+
+// claimDueWebhooks locks the pending deliveries whose backoff is over for `lockFor`, so the other instances skip them.
+func (r *repository) claimDueWebhooks(ctx context.Context, limit int, lockFor time.Duration) ([]*webhook, error) {
+	query := `WITH d AS (
+		UPDATE webhook_deliveries
+		SET locked_until = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE
+				status = 'pending'
+				AND next_attempt_at <= now()
+				AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, partner_id, event, payload, attempts
+	)
+	` + webhookTarget
+	rows, err := r.postgres.Query(ctx, query, limit, lockFor.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var due = make([]*webhook, 0)
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		due = append(due, hook)
+	}
+	return due, nil
+}
+
+// replayWebhook puts the delivery back to pending with all the attempts and claims it,
+// pkg.ErrNoRows is returned if there is no such delivery or it's being attempted.
+func (r *repository) replayWebhook(ctx context.Context, deliveryID int64, lockFor time.Duration) (*webhook, error) {
+	query := `WITH d AS (
+		UPDATE webhook_deliveries
+		SET
+			status = 'pending'
+			, attempts = 0
+			, next_attempt_at = now()
+			, locked_until = now() + make_interval(secs => $2)
+		WHERE id = $1 AND (locked_until IS NULL OR locked_until < now())
+		RETURNING id, partner_id, event, payload, attempts
+	)
+	` + webhookTarget
+	hook, err := scanWebhook(r.postgres.QueryRow(ctx, query, deliveryID, lockFor.Seconds()))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return hook, nil
+}
+
+// logWebhookAttempt adds the attempt to the delivery log and releases the delivery
+// with its status after the attempt.
+func (r *repository) logWebhookAttempt(ctx context.Context, deliveryID int64, attempt *WebhookAttempt, status string, nextAttemptAt time.Time) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	query := `INSERT INTO webhook_attempts (delivery_id, attempt, status_code, error, duration_ms)
+	VALUES ($1, $2, $3, $4, $5)`
+	err = tx.Exec(ctx, query, deliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error, attempt.DurationMs)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "INSERT INTO webhook_attempts")
+	}
+
+	query = `UPDATE webhook_deliveries
+	SET
+		status = $2
+		, attempts = $3
+		, next_attempt_at = $4
+		, locked_until = NULL
+		, last_status_code = $5
+		, last_error = $6
+		, delivered_at = CASE WHEN $2 = 'delivered' THEN now() END
+	WHERE id = $1`
+	err = tx.Exec(ctx, query,
+		deliveryID,
+		status,
+		attempt.Attempt,
+		nextAttemptAt,
+		attempt.StatusCode,
+		attempt.Error,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "UPDATE webhook_deliveries")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+const deliveryColumns = `id
+	, partner_id
+	, event
+	, order_id
+	, payload::TEXT
+	, status
+	, attempts
+	, next_attempt_at
+	, last_status_code
+	, last_error
+	, created_at
+	, delivered_at`
+
+func scanDelivery(row pkg.Row) (*WebhookDelivery, error) {
+	var delivery = &WebhookDelivery{}
+	var nextAttemptAt, createdAt time.Time
+	var deliveredAt *time.Time
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.PartnerID,
+		&delivery.Event,
+		&delivery.OrderID,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&nextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&createdAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == "pending" {
+		delivery.NextAttemptAt = nextAttemptAt.Format(time.RFC3339)
+	}
+	delivery.CreatedAt = createdAt.Format(time.RFC3339)
+	if deliveredAt != nil {
+		delivery.DeliveredAt = deliveredAt.Format(time.RFC3339)
+	}
+	return delivery, nil
+}
+
+func (r *repository) listWebhookDeliveries(ctx context.Context, req *ListDeliveriesRequest) ([]*WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+	FROM webhook_deliveries
+	WHERE
+		($1 = 0 OR partner_id = $1)
+		AND ($2 = '' OR status = $2)
+		AND ($3 = 0 OR order_id = $3)
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4 OFFSET $5`
+	rows, err := r.postgres.Query(ctx, query,
+		req.PartnerID,
+		req.Status,
+		req.OrderID,
+		req.Limit,
+		req.Offset,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var deliveries = make([]*WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// getWebhookDelivery gets the delivery with its log, the latest attempts first.
+func (r *repository) getWebhookDelivery(ctx context.Context, deliveryID int64) (*WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	delivery, err := scanDelivery(r.postgres.QueryRow(ctx, query, deliveryID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+
+	query = `SELECT attempt, status_code, error, duration_ms, attempted_at
+	FROM webhook_attempts
+	WHERE delivery_id = $1
+	ORDER BY attempted_at DESC`
+	rows, err := r.postgres.Query(ctx, query, deliveryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+	delivery.Log = make([]*WebhookAttempt, 0)
+	for rows.Next() {
+		var attempt = &WebhookAttempt{}
+		var attemptedAt time.Time
+		err = rows.Scan(&attempt.Attempt, &attempt.StatusCode, &attempt.Error, &attempt.DurationMs, &attemptedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		attempt.AttemptedAt = attemptedAt.Format(time.RFC3339)
+		delivery.Log = append(delivery.Log, attempt)
+	}
+	return delivery, nil
 }
@@ -1,10 +1,15 @@
 package partners
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
+	"crypto/rand"
+	"encoding/hex"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shahzodshafizod/gocloud/internal/orders"
@@ -13,27 +18,85 @@ import (
 )
 
 type Service interface {
-	getPartnerProducts(context.Context) (*products.GetAllResponse, error)
+	getPartnerProducts(context.Context, *products.GetAllRequest) (*products.GetAllResponse, error)
 	checkPartnerProducts(context.Context, *CheckRequest) (*CheckResponse, error)
 	sendToPartner(context.Context, *orders.PaidOrder) error
+	updateRating(context.Context, *orders.PartnerRating) error
+	forwardScheduled(context.Context) error
+	deliverWebhooks(context.Context) error
+	holdStock(context.Context, *orders.StockReservation) error
+	releaseStock(context.Context, *orders.ReleasedOrders) error
+	releaseExpired(context.Context) error
+	createPartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	updatePartner(context.Context, *PartnerInfo) (*PartnerInfo, error)
+	getPartner(context.Context, *GetPartnerRequest) (*PartnerInfo, error)
+	listPartners(context.Context, *ListPartnersRequest) (*ListPartnersResponse, error)
+	setPartnerEnabled(context.Context, *SetPartnerEnabledRequest) (*PartnerInfo, error)
+	setPartnerPaused(context.Context, *SetPartnerPausedRequest) (*PartnerInfo, error)
+	getOpeningHours(context.Context, *GetPartnerRequest) (*OpeningHours, error)
+	setOpeningHours(context.Context, *OpeningHours) (*OpeningHours, error)
+	getProductOptions(context.Context, *ProductOptionsRequest) (*ProductOptions, error)
+	setProductOptions(context.Context, *ProductOptions) (*ProductOptions, error)
+	rotateWebhookSecret(context.Context, *GetPartnerRequest) (*WebhookSecret, error)
+	listWebhookDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	getWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error)
+	replayWebhookDelivery(context.Context, *GetDeliveryRequest) (*WebhookDelivery, error)
+	createProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error)
+	updateProduct(context.Context, *products.ProductInfo) (*products.ProductInfo, error)
+	getProduct(context.Context, *products.GetProductRequest) (*products.ProductInfo, error)
+	listProducts(context.Context, *products.ListProductsRequest) (*products.ListProductsResponse, error)
+	setProductPicture(context.Context, *products.ProductPictureRequest) (*products.ProductInfo, error)
+	deleteProduct(context.Context, *products.DeleteProductRequest) (*products.ProductInfo, error)
+	setProductLabels(context.Context, *products.ProductLabels) (*products.ProductLabels, error)
+	createCategory(context.Context, *products.Category) (*products.Category, error)
+	updateCategory(context.Context, *products.Category) (*products.Category, error)
+	deleteCategory(context.Context, *products.DeleteCategoryRequest) (*products.Category, error)
+	getCategories(context.Context, *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error)
+	setAvailable(context.Context, *products.Availability) (*products.Availability, error)
+	listAvailable(context.Context, *products.ListAvailableRequest) (*products.ListAvailableResponse, error)
+	deleteAvailable(context.Context, *products.DeleteAvailableRequest) (*products.Availability, error)
 }
 
+// scheduleAhead is how far in the future an order can be scheduled.
+const scheduleAhead = time.Hour * 24 * 7
+
+// reservationTTL matches the gateway's cache of the checked order, the order can be created until then.
+const reservationTTL = time.Minute * 10
+
+// clockLayout is the time of day of the opening hours.
+const clockLayout = "15:04"
+
+// defaultTimezone is the partners.timezone column default.
+const defaultTimezone = "Asia/Dushanbe"
+
 type service struct {
-	repository Repository
-	httpClient pkg.HTTPClient
-	products   products.Service
+	repository        Repository
+	webhooks          *dispatcher
+	products          products.Service
+	leadTime          time.Duration // the earliest a scheduled order can be delivered from now
+	preparationWindow time.Duration // how long before its slot a scheduled order is sent to the partner
 }
 
 func NewService(repository Repository, httpClient pkg.HTTPClient, products products.Service) Service {
+	leadTime, err := time.ParseDuration(os.Getenv("SCHEDULE_LEAD_TIME"))
+	if err != nil {
+		leadTime = time.Hour
+	}
+	preparationWindow, err := time.ParseDuration(os.Getenv("PREPARATION_WINDOW"))
+	if err != nil {
+		preparationWindow = time.Minute * 45
+	}
 	return &service{
-		repository: repository,
-		httpClient: httpClient,
-		products:   products,
+		repository:        repository,
+		webhooks:          newDispatcher(repository, httpClient),
+		products:          products,
+		leadTime:          leadTime,
+		preparationWindow: preparationWindow,
 	}
 }
 
-func (s *service) getPartnerProducts(ctx context.Context) (*products.GetAllResponse, error) {
-	resp, err := s.products.GetPartnerProducts(ctx)
+func (s *service) getPartnerProducts(ctx context.Context, req *products.GetAllRequest) (*products.GetAllResponse, error) {
+	resp, err := s.products.GetPartnerProducts(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "s.products.GetPartnerProducts")
 	}
@@ -41,28 +104,592 @@ func (s *service) getPartnerProducts(ctx context.Context) (*products.GetAllRespo
 }
 
 func (s *service) checkPartnerProducts(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
+	for _, product := range req.Products {
+		if product.Quantity <= 0 {
+			return nil, errors.New("product " + strconv.Itoa(int(product.ID)) + ": quantity must be positive")
+		}
+	}
+
+	var at = time.Now()
+	if req.ScheduledAt != "" {
+		var err error
+		at, err = s.checkSchedule(req.ScheduledAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.checkSchedule")
+		}
+	}
+	closed, err := s.repository.closedReason(ctx, req.PartnerID, at)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.closedReason")
+	}
+	if closed != "" {
+		return &CheckResponse{Closed: closed}, nil
+	}
+
 	resp, err := s.repository.checkPartnerProducts(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "s.repository.checkPartnerProducts")
 	}
+	if req.ReservationID == "" || len(resp.Shortages) > 0 {
+		return resp, nil
+	}
+
+	// the stock might have been reserved by the others since it was checked
+	shortages, err := s.repository.reserveStock(ctx, req.ReservationID, req.PartnerID, resp.Products, time.Now().Add(reservationTTL))
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.reserveStock")
+	}
+	if len(shortages) > 0 {
+		resp.Shortages = shortages
+		resp.Pricing = nil
+	}
 	return resp, nil
 }
 
+// checkSchedule checks the delivery slot against the lead time,
+// the partner's opening hours are checked at the slot as for an order placed now.
+func (s *service) checkSchedule(scheduledAt string) (time.Time, error) {
+	at, err := time.Parse(time.RFC3339, scheduledAt)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "time.Parse")
+	}
+	now := time.Now()
+	if at.Before(now.Add(s.leadTime)) {
+		return time.Time{}, errors.New("scheduled time must be at least " + s.leadTime.String() + " from now")
+	}
+	if at.After(now.Add(scheduleAhead)) {
+		return time.Time{}, errors.New("scheduled time must be within " + scheduleAhead.String() + " from now")
+	}
+	return at, nil
+}
+
+// sendToPartner forwards the paid order to the partner API, or holds a scheduled
+// order back until the preparation window before its delivery slot.
 func (s *service) sendToPartner(ctx context.Context, order *orders.PaidOrder) error {
-	apiURL, err := s.repository.getPartnerApiURL(ctx, order.PartnerID)
+	if order.ScheduledAt != nil {
+		forwardAt := order.ScheduledAt.Add(-s.preparationWindow)
+		if forwardAt.After(time.Now()) {
+			err := s.repository.scheduleForward(ctx, order, forwardAt)
+			if err != nil {
+				return errors.Wrap(err, "s.repository.scheduleForward")
+			}
+			return nil
+		}
+	}
+
+	err := s.forward(ctx, order)
+	if err != nil {
+		return errors.Wrap(err, "s.forward")
+	}
+	return nil
+}
+
+// forward hands the paid order to the webhook dispatcher, it's delivered to the partner API
+// at once or retried until it is.
+func (s *service) forward(ctx context.Context, order *orders.PaidOrder) error {
+	err := s.webhooks.dispatch(ctx, "orders.paid", order.OrderID, order.PartnerID, order)
+	if err != nil {
+		return errors.Wrap(err, "s.webhooks.dispatch")
+	}
+	return nil
+}
+
+func (s *service) deliverWebhooks(ctx context.Context) error {
+	err := s.webhooks.deliverDue(ctx)
+	if err != nil {
+		return errors.Wrap(err, "s.webhooks.deliverDue")
+	}
+	return nil
+}
+
+// forwardScheduled forwards the scheduled orders whose preparation window has come.
+// A failed order is retried by a later run, the other orders are forwarded anyway.
+func (s *service) forwardScheduled(ctx context.Context) error {
+	due, err := s.repository.claimDueForwards(ctx, 50, time.Minute*5)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.claimDueForwards")
+	}
+
+	var lastErr error
+	for _, order := range due {
+		err = s.forward(ctx, order)
+		if err != nil {
+			lastErr = errors.Wrapf(err, "s.forward: order %d", order.OrderID)
+			err = s.repository.failForward(ctx, order.OrderID, err.Error())
+			if err != nil {
+				lastErr = errors.Wrap(err, "s.repository.failForward")
+			}
+			continue
+		}
+		err = s.repository.markForwarded(ctx, order.OrderID)
+		if err != nil {
+			lastErr = errors.Wrap(err, "s.repository.markForwarded")
+		}
+	}
+	return lastErr
+}
+
+func (s *service) updateRating(ctx context.Context, rating *orders.PartnerRating) error {
+	err := s.repository.updateRating(ctx, rating)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.updateRating")
+	}
+	return nil
+}
+
+// holdStock holds the stock reserved when the order was checked until the order is paid or released.
+func (s *service) holdStock(ctx context.Context, reservation *orders.StockReservation) error {
+	err := s.repository.holdStock(ctx, reservation.ReservationID, reservation.OrderID)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.holdStock")
+	}
+	return nil
+}
+
+// releaseStock puts the stock held for the cancelled or expired orders back on sale.
+func (s *service) releaseStock(ctx context.Context, released *orders.ReleasedOrders) error {
+	err := s.repository.releaseOrders(ctx, released.OrderIDs)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.releaseOrders")
+	}
+	return nil
+}
+
+// releaseExpired puts the stock reserved for the checked orders never created back on sale.
+func (s *service) releaseExpired(ctx context.Context) error {
+	err := s.repository.releaseExpired(ctx)
+	if err != nil {
+		return errors.Wrap(err, "s.repository.releaseExpired")
+	}
+	return nil
+}
+
+func (s *service) createPartner(ctx context.Context, req *PartnerInfo) (*PartnerInfo, error) {
+	err := checkPartner(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkPartner")
+	}
+	partner, err := s.repository.createPartner(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createPartner")
+	}
+	return partner, nil
+}
+
+func (s *service) updatePartner(ctx context.Context, req *PartnerInfo) (*PartnerInfo, error) {
+	err := checkPartner(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkPartner")
+	}
+	partner, err := s.repository.updatePartner(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.updatePartner")
+	}
+	return partner, nil
+}
+
+func (s *service) getPartner(ctx context.Context, req *GetPartnerRequest) (*PartnerInfo, error) {
+	if req.ID == 0 && req.UserID != "" {
+		partner, err := s.repository.getPartnerByUser(ctx, req.UserID)
+		if errors.Is(err, pkg.ErrNoRows) {
+			return nil, errors.New("the user is not staff of any partner")
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.getPartnerByUser")
+		}
+		return partner, nil
+	}
+	partner, err := s.repository.getPartner(ctx, req.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getPartner")
+	}
+	return partner, nil
+}
+
+func (s *service) listPartners(ctx context.Context, req *ListPartnersRequest) (*ListPartnersResponse, error) {
+	partners, err := s.repository.listPartners(ctx, req.EnabledOnly)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listPartners")
+	}
+	return &ListPartnersResponse{Partners: partners}, nil
+}
+
+func (s *service) setPartnerEnabled(ctx context.Context, req *SetPartnerEnabledRequest) (*PartnerInfo, error) {
+	partner, err := s.repository.setPartnerEnabled(ctx, req.ID, req.Enabled)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setPartnerEnabled")
+	}
+	return partner, nil
+}
+
+// setPartnerPaused pauses ordering from the partner at once, whatever its opening hours are, or resumes it.
+func (s *service) setPartnerPaused(ctx context.Context, req *SetPartnerPausedRequest) (*PartnerInfo, error) {
+	partner, err := s.repository.setPartnerPaused(ctx, req.ID, req.Paused)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("partner not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setPartnerPaused")
+	}
+	return partner, nil
+}
+
+func (s *service) getOpeningHours(ctx context.Context, req *GetPartnerRequest) (*OpeningHours, error) {
+	hours, err := s.repository.getOpeningHours(ctx, req.ID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("partner not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getOpeningHours")
+	}
+	return hours, nil
+}
+
+// setOpeningHours replaces the partner's weekly opening hours and closures with the given ones.
+func (s *service) setOpeningHours(ctx context.Context, req *OpeningHours) (*OpeningHours, error) {
+	err := checkOpeningHours(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkOpeningHours")
+	}
+	err = s.repository.setOpeningHours(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setOpeningHours")
+	}
+	hours, err := s.repository.getOpeningHours(ctx, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getOpeningHours")
+	}
+	return hours, nil
+}
+
+func (s *service) getProductOptions(ctx context.Context, req *ProductOptionsRequest) (*ProductOptions, error) {
+	options, err := s.repository.getProductOptions(ctx, req.ProductID, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getProductOptions")
+	}
+	return options, nil
+}
+
+// setProductOptions replaces the variants and the modifier groups of the partner's product with the given ones.
+func (s *service) setProductOptions(ctx context.Context, req *ProductOptions) (*ProductOptions, error) {
+	err := checkProductOptions(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkProductOptions")
+	}
+	err = s.repository.setProductOptions(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner doesn't sell the product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setProductOptions")
+	}
+	options, err := s.repository.getProductOptions(ctx, req.ProductID, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getProductOptions")
+	}
+	return options, nil
+}
+
+// rotateWebhookSecret replaces the partner's webhook secret with a new random one, the next deliveries are signed with it.
+func (s *service) rotateWebhookSecret(ctx context.Context, req *GetPartnerRequest) (*WebhookSecret, error) {
+	var key = make([]byte, 32)
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "rand.Read")
+	}
+	var secret = &WebhookSecret{PartnerID: req.ID, Secret: hex.EncodeToString(key)}
+	err = s.repository.rotateWebhookSecret(ctx, secret.PartnerID, secret.Secret)
+	if errors.Is(err, pkg.ErrNoRowsAffected) {
+		return nil, errors.New("partner not found")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.rotateWebhookSecret")
+	}
+	return secret, nil
+}
+
+func (s *service) listWebhookDeliveries(ctx context.Context, req *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	deliveries, err := s.repository.listWebhookDeliveries(ctx, req)
 	if err != nil {
-		return errors.Wrap(err, "s.repository.getPartnerApiURL")
+		return nil, errors.Wrap(err, "s.repository.listWebhookDeliveries")
 	}
+	return &ListDeliveriesResponse{Deliveries: deliveries}, nil
+}
 
-	body, err := json.Marshal(order)
+func (s *service) getWebhookDelivery(ctx context.Context, req *GetDeliveryRequest) (*WebhookDelivery, error) {
+	delivery, err := s.repository.getWebhookDelivery(ctx, req.ID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("delivery not found")
+	}
 	if err != nil {
-		return errors.Wrap(err, "json.Marshal")
+		return nil, errors.Wrap(err, "s.repository.getWebhookDelivery")
 	}
+	return delivery, nil
+}
 
-	_, err = s.httpClient.SendRequest(http.MethodPost, apiURL, bytes.NewReader(body))
+// replayWebhookDelivery attempts the delivery again at once, it's retried as a new one if the attempt fails.
+func (s *service) replayWebhookDelivery(ctx context.Context, req *GetDeliveryRequest) (*WebhookDelivery, error) {
+	err := s.webhooks.replay(ctx, req.ID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("no such delivery, or it's being attempted")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.webhooks.replay")
+	}
+	delivery, err := s.repository.getWebhookDelivery(ctx, req.ID)
 	if err != nil {
-		return errors.Wrap(err, "s.httpClient.SendRequest")
+		return nil, errors.Wrap(err, "s.repository.getWebhookDelivery")
 	}
+	return delivery, nil
+}
+
+func (s *service) createProduct(ctx context.Context, req *products.ProductInfo) (*products.ProductInfo, error) {
+	resp, err := s.products.CreateProduct(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.CreateProduct")
+	}
+	return resp, nil
+}
+
+func (s *service) updateProduct(ctx context.Context, req *products.ProductInfo) (*products.ProductInfo, error) {
+	resp, err := s.products.UpdateProduct(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.UpdateProduct")
+	}
+	return resp, nil
+}
+
+func (s *service) getProduct(ctx context.Context, req *products.GetProductRequest) (*products.ProductInfo, error) {
+	resp, err := s.products.GetProduct(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.GetProduct")
+	}
+	return resp, nil
+}
+
+func (s *service) listProducts(ctx context.Context, req *products.ListProductsRequest) (*products.ListProductsResponse, error) {
+	resp, err := s.products.ListProducts(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.ListProducts")
+	}
+	return resp, nil
+}
+
+func (s *service) setProductPicture(ctx context.Context, req *products.ProductPictureRequest) (*products.ProductInfo, error) {
+	resp, err := s.products.SetProductPicture(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.SetProductPicture")
+	}
+	return resp, nil
+}
+
+func (s *service) deleteProduct(ctx context.Context, req *products.DeleteProductRequest) (*products.ProductInfo, error) {
+	resp, err := s.products.DeleteProduct(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.DeleteProduct")
+	}
+	return resp, nil
+}
+
+func (s *service) setProductLabels(ctx context.Context, req *products.ProductLabels) (*products.ProductLabels, error) {
+	resp, err := s.products.SetProductLabels(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.SetProductLabels")
+	}
+	return resp, nil
+}
 
+func (s *service) createCategory(ctx context.Context, req *products.Category) (*products.Category, error) {
+	resp, err := s.products.CreateCategory(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.CreateCategory")
+	}
+	return resp, nil
+}
+
+func (s *service) updateCategory(ctx context.Context, req *products.Category) (*products.Category, error) {
+	resp, err := s.products.UpdateCategory(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.UpdateCategory")
+	}
+	return resp, nil
+}
+
+func (s *service) deleteCategory(ctx context.Context, req *products.DeleteCategoryRequest) (*products.Category, error) {
+	resp, err := s.products.DeleteCategory(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.DeleteCategory")
+	}
+	return resp, nil
+}
+
+func (s *service) getCategories(ctx context.Context, req *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error) {
+	resp, err := s.products.GetCategories(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.GetCategories")
+	}
+	return resp, nil
+}
+
+func (s *service) setAvailable(ctx context.Context, req *products.Availability) (*products.Availability, error) {
+	resp, err := s.products.SetAvailable(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.SetAvailable")
+	}
+	return resp, nil
+}
+
+func (s *service) listAvailable(ctx context.Context, req *products.ListAvailableRequest) (*products.ListAvailableResponse, error) {
+	resp, err := s.products.ListAvailable(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.ListAvailable")
+	}
+	return resp, nil
+}
+
+func (s *service) deleteAvailable(ctx context.Context, req *products.DeleteAvailableRequest) (*products.Availability, error) {
+	resp, err := s.products.DeleteAvailable(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.products.DeleteAvailable")
+	}
+	return resp, nil
+}
+
+// phoneRegexp is the E.164 format: [+] [country code] [subscriber number], up to fifteen digits.
+var phoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// checkPartner checks what the service relies on: the paid orders are sent to the API URL
+// and the opening hours are read in the timezone.
+func checkPartner(partner *PartnerInfo) error {
+	apiURL, err := url.Parse(partner.ApiURL)
+	if err != nil {
+		return errors.Wrap(err, "url.Parse")
+	}
+	if (apiURL.Scheme != "http" && apiURL.Scheme != "https") || apiURL.Host == "" {
+		return errors.New("api url must be an absolute http(s) url: " + partner.ApiURL)
+	}
+	if !phoneRegexp.MatchString(partner.Phone) {
+		return errors.New("phone must be in the international format: " + partner.Phone)
+	}
+	_, err = mail.ParseAddress(partner.Email)
+	if err != nil {
+		return errors.Wrap(err, "mail.ParseAddress")
+	}
+	if partner.Timezone == "" {
+		partner.Timezone = defaultTimezone
+	}
+	_, err = time.LoadLocation(partner.Timezone)
+	if err != nil {
+		return errors.Wrap(err, "time.LoadLocation")
+	}
+	return nil
+}
+
+// checkOpeningHours validates the periods, a day's periods must not overlap, and the closures' dates.
+func checkOpeningHours(hours *OpeningHours) error {
+	type period struct{ opens, closes time.Time }
+	var days = make(map[int32][]period)
+	for _, p := range hours.Weekly {
+		if p.Weekday < 0 || p.Weekday > 6 {
+			return errors.New("weekday must be 0 (Sunday) to 6")
+		}
+		opens, err := time.Parse(clockLayout, p.OpensAt)
+		if err != nil {
+			return errors.New("invalid opening time " + p.OpensAt)
+		}
+		closes, err := time.Parse(clockLayout, p.ClosesAt)
+		if err != nil {
+			return errors.New("invalid closing time " + p.ClosesAt)
+		}
+		if !closes.After(opens) {
+			return errors.New("closing time must be later than opening time on the same day")
+		}
+		for _, other := range days[p.Weekday] {
+			if opens.Before(other.closes) && other.opens.Before(closes) {
+				return errors.New("opening hours overlap on weekday " + strconv.Itoa(int(p.Weekday)))
+			}
+		}
+		days[p.Weekday] = append(days[p.Weekday], period{opens, closes})
+	}
+
+	var starts = make(map[string]bool, len(hours.Closures))
+	for _, closure := range hours.Closures {
+		if starts[closure.StartsOn] {
+			return errors.New("two closures start on " + closure.StartsOn)
+		}
+		starts[closure.StartsOn] = true
+		startsOn, err := time.Parse(time.DateOnly, closure.StartsOn)
+		if err != nil {
+			return errors.New("invalid closure start date " + closure.StartsOn)
+		}
+		endsOn, err := time.Parse(time.DateOnly, closure.EndsOn)
+		if err != nil {
+			return errors.New("invalid closure end date " + closure.EndsOn)
+		}
+		if endsOn.Before(startsOn) {
+			return errors.New("closure must not end before it starts")
+		}
+		if len(closure.Reason) > 200 {
+			return errors.New("closure reason is too long")
+		}
+	}
+	return nil
+}
+
+// checkProductOptions validates the variants and the modifier groups, their titles must be unique.
+func checkProductOptions(options *ProductOptions) error {
+	if len(options.Variants) > 20 || len(options.ModifierGroups) > 20 {
+		return errors.New("at most 20 variants and 20 modifier groups")
+	}
+	var variants = make(map[string]bool, len(options.Variants))
+	for _, variant := range options.Variants {
+		if variant.Title == "" || len(variant.Title) > 50 {
+			return errors.New("variant title is required, 50 characters at most")
+		}
+		if variants[variant.Title] {
+			return errors.New("two variants are titled " + variant.Title)
+		}
+		variants[variant.Title] = true
+		if variant.Price <= 0 {
+			return errors.New("variant " + variant.Title + " must be priced")
+		}
+	}
+
+	var groups = make(map[string]bool, len(options.ModifierGroups))
+	for _, group := range options.ModifierGroups {
+		if group.Title == "" || len(group.Title) > 50 {
+			return errors.New("modifier group title is required, 50 characters at most")
+		}
+		if groups[group.Title] {
+			return errors.New("two modifier groups are titled " + group.Title)
+		}
+		groups[group.Title] = true
+		if len(group.Modifiers) == 0 || len(group.Modifiers) > 50 {
+			return errors.New("modifier group " + group.Title + " must have 1 to 50 modifiers")
+		}
+		if group.MinSelections < 0 || group.MaxSelections < 1 ||
+			group.MinSelections > group.MaxSelections || int(group.MaxSelections) > len(group.Modifiers) {
+			return errors.New("modifier group " + group.Title + ": selections must be 0 <= min <= max <= the modifiers, max at least 1")
+		}
+		var modifiers = make(map[string]bool, len(group.Modifiers))
+		for _, modifier := range group.Modifiers {
+			if modifier.Title == "" || len(modifier.Title) > 50 {
+				return errors.New("modifier title is required, 50 characters at most")
+			}
+			if modifiers[modifier.Title] {
+				return errors.New("two modifiers of " + group.Title + " are titled " + modifier.Title)
+			}
+			modifiers[modifier.Title] = true
+			if modifier.Price < 0 {
+				return errors.New("modifier " + modifier.Title + " must not be priced negative")
+			}
+		}
+	}
 	return nil
 }
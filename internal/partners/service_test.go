@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shahzodshafizod/gocloud/internal/orders"
 	"github.com/shahzodshafizod/gocloud/internal/products"
 	productsmocks "github.com/shahzodshafizod/gocloud/internal/products/mocks"
+	"github.com/shahzodshafizod/gocloud/pkg"
 	"github.com/shahzodshafizod/gocloud/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -45,14 +47,14 @@ func TestGetPartnerProducts(t *testing.T) {
 
 	// Test case #1
 	targetError := errors.New("products GetPartnerProducts error")
-	cfg.productsSvc.EXPECT().GetPartnerProducts(gomock.Any()).Return(nil, targetError)
-	_, err := cfg.service.getPartnerProducts(ctx)
+	cfg.productsSvc.EXPECT().GetPartnerProducts(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.getPartnerProducts(ctx, &products.GetAllRequest{})
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.productsSvc.EXPECT().GetPartnerProducts(gomock.Any()).Return(&products.GetAllResponse{}, nil).AnyTimes()
+	cfg.productsSvc.EXPECT().GetPartnerProducts(gomock.Any(), gomock.Any()).Return(&products.GetAllResponse{}, nil).AnyTimes()
 
 	// Test case #2: Success
-	resp, err := cfg.service.getPartnerProducts(ctx)
+	resp, err := cfg.service.getPartnerProducts(ctx, &products.GetAllRequest{})
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 }
@@ -74,13 +76,18 @@ func TestCheckPartnerProducts(t *testing.T) {
 
 	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
 
+	// the partner is open, no reason it's closed
+	scanOpen := func() { cfg.row.EXPECT().Scan(gomock.Any()).Return(nil) }
+
 	// Test case #1
 	targetError := errors.New("Scan error")
+	scanOpen()
 	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
 	_, err := cfg.service.checkPartnerProducts(ctx, req)
 	assert.True(t, errors.Is(err, targetError))
 
 	// Test case #2: Wrong calculated Total Amount
+	scanOpen()
 	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
 		*dest[0].(*string) = "test partner title"
 		*dest[1].(*string) = "test partner brand"
@@ -90,16 +97,184 @@ func TestCheckPartnerProducts(t *testing.T) {
 	_, err = cfg.service.checkPartnerProducts(ctx, req)
 	assert.Error(t, err)
 
-	// Test case #3: Success
-	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+	scanProduct := func(dest ...any) error {
 		*dest[0].(*string) = "test partner title"
 		*dest[1].(*string) = "test partner brand"
 		*dest[2].(*int32) = 100
+		*dest[3].(*float64) = 38.5598
+		*dest[4].(*float64) = 68.7870
+		return nil
+	}
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #3
+	targetError = errors.New("getPricingRules error")
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct).Times(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	_, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #4: Success
+	req.DeliveryAddress = &orders.Address{Latitude: 38.5737, Longitude: 68.7738} // ~1.9 km away
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct).Times(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int64) = 500  // base delivery fee
+		*dest[1].(*float64) = 1  // included km
+		*dest[2].(*int64) = 100  // per km fee
+		*dest[3].(*int64) = 2000 // small order threshold
+		*dest[4].(*int64) = 300  // small order surcharge
+		*dest[5].(*int64) = 500  // service fee rate: 5%
+		*dest[6].(*int64) = 1500 // tax rate: 15%
 		return nil
-	}).AnyTimes()
+	})
 	resp, err := cfg.service.checkPartnerProducts(ctx, req)
 	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+	assert.Equal(t, int64(300), resp.Pricing.Subtotal)
+	assert.Equal(t, int64(600), resp.Pricing.DeliveryFee)
+	assert.Equal(t, int64(300), resp.Pricing.SmallOrderFee)
+	assert.Equal(t, int64(15), resp.Pricing.ServiceFee)
+	assert.Equal(t, int64(182), resp.Pricing.Tax) // 15% of 1215 = 182.25
+	assert.Equal(t, int64(1397), resp.Pricing.Total)
+
+	// Test case #5: The address has no coordinates, it'd be delivered for the base fee
+	req.DeliveryAddress = &orders.Address{Street: "Rudaki Ave 1", City: "Dushanbe"}
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct).Times(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	_, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.EqualError(t, err, "s.repository.checkPartnerProducts: the delivery address has no coordinates")
+	req.DeliveryAddress = &orders.Address{Latitude: 38.5737, Longitude: 68.7738}
+
+	// Test case #6: Scheduled earlier than the lead time
+	req.ScheduledAt = time.Now().Add(time.Minute * 10).Format(time.RFC3339)
+	_, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #7: Partner is closed at the scheduled time, the order isn't checked
+	req.ScheduledAt = time.Now().Add(time.Hour * 3).Format(time.RFC3339)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "closed"
+		return nil
+	})
+	resp, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "closed", resp.Closed)
+	assert.Nil(t, resp.Pricing)
+
+	// Test case #8: Reorder leaves the products no longer available out
+	req.ScheduledAt = ""
+	req.Reorder = true
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	resp, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*orders.Product{req.Products[1]}, resp.Products)
+	assert.Equal(t, []*orders.Product{req.Products[0]}, resp.Unavailable)
+	assert.Equal(t, int64(100), resp.Pricing.Subtotal)
+
+	// Test case #9: The stock is short of a product, the order isn't priced
+	req.Reorder = false
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[5].(*string) = "test product"
+		*dest[7].(*int32) = 1
+		return nil
+	})
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct)
+	resp, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*StockShortage{{Title: "test product", Requested: 2, Available: 1}}, resp.Shortages)
+	assert.Nil(t, resp.Pricing)
+
+	// Test case #10: The stock is reserved by the others since it was checked
+	req.ReservationID = "ORDER::customer-1"
+	tx := mocks.NewMockTx(cfg.ctrl)
+	scanOpen()
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanProduct).Times(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil)
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pkg.ErrNoRowsAffected)
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).Times(3)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*bool) = true
+		return nil
+	})
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	tx.EXPECT().Rollback(gomock.Any()).Return(nil)
+	resp, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*StockShortage{{Requested: 1}}, resp.Shortages)
+	assert.Nil(t, resp.Pricing)
+
+	// Test case #11: A large one with extra cheese, priced with its options
+	req.ReservationID = ""
+	req.TotalAmount = 360
+	req.Products = []*orders.Product{{ID: 7, Quantity: 2, VariantID: 11, ModifierIDs: []int32{21}}}
+	scanOptions := func() {
+		cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[2].(*int32) = 100
+			*dest[8].(*bool) = true
+			return nil
+		})
+		variants := mocks.NewMockRows(cfg.ctrl)
+		modifiers := mocks.NewMockRows(cfg.ctrl)
+		cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(variants, nil)
+		cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(modifiers, nil)
+		variants.EXPECT().Next().Return(true).Times(2)
+		variants.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int32), *dest[1].(*string), *dest[2].(*int32) = 10, "small", 90
+			return nil
+		})
+		variants.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int32), *dest[1].(*string), *dest[2].(*int32) = 11, "large", 150
+			return nil
+		})
+		variants.EXPECT().Next().Return(false)
+		variants.EXPECT().Close()
+		modifiers.EXPECT().Next().Return(true).Times(2)
+		for _, modifier := range []struct {
+			id    int32
+			title string
+			price int32
+		}{{21, "cheese", 30}, {22, "olives", 20}} {
+			modifiers.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+				*dest[0].(*int32), *dest[1].(*string), *dest[2].(*int32), *dest[3].(*int32) = 5, "extras", 0, 1
+				*dest[4].(*int32), *dest[5].(*string), *dest[6].(*int32) = modifier.id, modifier.title, modifier.price
+				return nil
+			})
+		}
+		modifiers.EXPECT().Next().Return(false)
+		modifiers.EXPECT().Close()
+	}
+	scanOpen()
+	scanOptions()
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	resp, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(180), resp.Products[0].Price)
+	assert.Equal(t, []*orders.SelectedOption{
+		{ID: 11, Title: "large", Price: 150},
+		{ID: 21, Group: "extras", Title: "cheese", Price: 30},
+	}, resp.Products[0].Options)
+	assert.Equal(t, int64(360), resp.Pricing.Subtotal)
+
+	// Test case #12: More extras than allowed
+	req.Products = []*orders.Product{{ID: 7, Quantity: 2, VariantID: 11, ModifierIDs: []int32{21, 22}}}
+	scanOpen()
+	scanOptions()
+	_, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #13: A negative quantity would add to the stock, it isn't checked any further
+	req.Products = []*orders.Product{{ID: 7, Quantity: -5, VariantID: 11}}
+	_, err = cfg.service.checkPartnerProducts(ctx, req)
+	assert.Error(t, err)
 }
 
 // go test -v -count=1 ./internal/partners/ -run ^TestSendToPartner$
@@ -117,26 +292,249 @@ func TestSendToPartner(t *testing.T) {
 			{ID: 2, Quantity: 1, Price: 100},
 		},
 	}
+	payload := []byte(`{"order_id": 100000}`)
 
 	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
 
+	// the order is queued as delivery 7 with `attempts` made before
+	scanQueued := func(attempts int32) {
+		cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int64) = 7
+			*dest[1].(*int32) = 1
+			*dest[2].(*string) = "orders.paid"
+			*dest[3].(*[]byte) = payload
+			*dest[4].(*int32) = attempts
+			*dest[5].(*string) = "https://partner.example/orders"
+			*dest[6].(*string) = "secret"
+			return nil
+		})
+	}
+	// the attempt is logged with the delivery's status after it
+	tx := mocks.NewMockTx(cfg.ctrl)
+	expectLogged := func(status string, attempt int32) {
+		cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil)
+		tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, sql string, args ...any) error {
+			assert.Equal(t, status, args[1])
+			assert.Equal(t, attempt, args[2])
+			return nil
+		})
+		tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	}
+
 	// Test case #1
-	targetError := errors.New("getPartnerApiURL error")
+	targetError := errors.New("enqueueWebhook error")
 	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
 	err := cfg.service.sendToPartner(ctx, order)
 	assert.True(t, errors.Is(err, targetError))
 
-	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil).AnyTimes()
+	// Test case #2: A redelivered message, the order is queued already
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	err = cfg.service.sendToPartner(ctx, order)
+	assert.NoError(t, err)
 
-	// Test case #2
-	targetError = errors.New("httpClient Send error")
-	cfg.httpClient.EXPECT().SendRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
+	// Test case #3: Success, the webhook is signed with the partner's secret
+	scanQueued(0)
+	cfg.httpClient.EXPECT().Post(gomock.Any(), "https://partner.example/orders", gomock.Any(), payload).DoAndReturn(
+		func(ctx context.Context, url string, headers map[string]string, body []byte) (int, []byte, error) {
+			assert.Equal(t, "7", headers["X-Webhook-ID"])
+			assert.Equal(t, "orders.paid", headers["X-Webhook-Event"])
+			assert.Equal(t, signWebhook("secret", headers["X-Timestamp"], "7", payload), headers["X-Signature"])
+			return 200, nil, nil
+		})
+	expectLogged("delivered", 1)
 	err = cfg.service.sendToPartner(ctx, order)
-	assert.True(t, errors.Is(err, targetError))
+	assert.NoError(t, err)
+
+	// Test case #4: A non-2xx response is retried later, the message isn't failed
+	scanQueued(0)
+	cfg.httpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(503, []byte("busy"), nil)
+	expectLogged("pending", 1)
+	err = cfg.service.sendToPartner(ctx, order)
+	assert.NoError(t, err)
 
-	cfg.httpClient.EXPECT().SendRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte{}, nil).AnyTimes()
+	// Test case #5: Dead after the last attempt times out
+	scanQueued(9)
+	cfg.httpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, nil, context.DeadlineExceeded)
+	expectLogged("dead", 10)
+	err = cfg.service.sendToPartner(ctx, order)
+	assert.NoError(t, err)
 
-	// Test case #3: Success
+	// Test case #6: Scheduled order is held back
+	scheduledAt := time.Now().Add(time.Hour * 3)
+	order.ScheduledAt = &scheduledAt
+	cfg.postgres.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	err = cfg.service.sendToPartner(ctx, order)
 	assert.NoError(t, err)
 }
+
+// go test -v -count=1 ./internal/partners/ -run ^TestWebhookBackoff$
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, time.Second*30, webhookBackoff(time.Second*30, 1))
+	assert.Equal(t, time.Minute*4, webhookBackoff(time.Second*30, 4))
+	assert.Equal(t, maxWebhookBackoff, webhookBackoff(time.Second*30, 30))
+}
+
+// go test -v -count=1 ./internal/partners/ -run ^TestCreatePartner$
+func TestCreatePartner(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	partner := &PartnerInfo{
+		Title:   "Test Partner",
+		Brand:   "test",
+		Phone:   "+992900000000",
+		Email:   "partner@test.tj",
+		Address: "Dushanbe",
+		ApiURL:  "ftp://partner.test.tj/orders",
+	}
+
+	// Test case #1: Not an http url
+	_, err := cfg.service.createPartner(ctx, partner)
+	assert.Error(t, err)
+
+	// Test case #2: Invalid phone number
+	partner.ApiURL = "https://partner.test.tj/orders"
+	partner.Phone = "900000000"
+	_, err = cfg.service.createPartner(ctx, partner)
+	assert.Error(t, err)
+
+	// Test case #3: Invalid email
+	partner.Phone = "+992900000000"
+	partner.Email = "partner"
+	_, err = cfg.service.createPartner(ctx, partner)
+	assert.Error(t, err)
+
+	// Test case #4: Unknown timezone
+	partner.Email = "partner@test.tj"
+	partner.Timezone = "Mars/Olympus"
+	_, err = cfg.service.createPartner(ctx, partner)
+	assert.Error(t, err)
+
+	partner.Timezone = ""
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #5
+	targetError := errors.New("insert partner error")
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(targetError)
+	_, err = cfg.service.createPartner(ctx, partner)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #6: Success, the default timezone is set
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	_, err = cfg.service.createPartner(ctx, partner)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTimezone, partner.Timezone)
+}
+
+// go test -v -count=1 ./internal/partners/ -run ^TestSetOpeningHours$
+func TestSetOpeningHours(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &OpeningHours{
+		PartnerID: 1,
+		Weekly: []*OpeningPeriod{
+			{Weekday: 1, OpensAt: "09:00", ClosesAt: "14:00"},
+			{Weekday: 1, OpensAt: "13:00", ClosesAt: "22:00"},
+		},
+		Closures: []*Closure{{StartsOn: "2025-01-01", EndsOn: "2025-01-02", Reason: "New Year"}},
+	}
+
+	// Test case #1: The periods of a day overlap
+	_, err := cfg.service.setOpeningHours(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #2: Closing time is past midnight
+	req.Weekly[1] = &OpeningPeriod{Weekday: 1, OpensAt: "18:00", ClosesAt: "02:00"}
+	_, err = cfg.service.setOpeningHours(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #3: The closure ends before it starts
+	req.Weekly[1] = &OpeningPeriod{Weekday: 1, OpensAt: "15:00", ClosesAt: "22:00"}
+	req.Closures[0].EndsOn = "2024-12-31"
+	_, err = cfg.service.setOpeningHours(ctx, req)
+	assert.Error(t, err)
+
+	req.Closures[0].EndsOn = "2025-01-02"
+	tx := mocks.NewMockTx(cfg.ctrl)
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil).Times(2)
+
+	// Test case #4: Nothing is replaced if any period fails
+	targetError := errors.New("INSERT INTO opening_hours error")
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pkg.ErrNoRowsAffected)
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(targetError)
+	tx.EXPECT().Rollback(gomock.Any()).Return(nil)
+	_, err = cfg.service.setOpeningHours(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #5: Success, the saved hours are returned
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(5)
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "Asia/Dushanbe"
+		return nil
+	})
+	rows := mocks.NewMockRows(cfg.ctrl)
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil).Times(2)
+	rows.EXPECT().Next().Return(false).Times(2)
+	rows.EXPECT().Close().Times(2)
+	resp, err := cfg.service.setOpeningHours(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Dushanbe", resp.Timezone)
+}
+
+// go test -v -count=1 ./internal/partners/ -run ^TestSetProductOptions$
+func TestSetProductOptions(t *testing.T) {
+	cfg := __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &ProductOptions{
+		ProductID: 7,
+		PartnerID: 1,
+		Variants:  []*Variant{{Title: "small", Price: 90}, {Title: "large", Price: 150}},
+		ModifierGroups: []*ModifierGroup{{
+			Title:         "extras",
+			MaxSelections: 3,
+			Modifiers:     []*Modifier{{Title: "cheese", Price: 30}, {Title: "olives", Price: 20}},
+		}},
+	}
+
+	// Test case #1: More selections allowed than there are modifiers
+	_, err := cfg.service.setProductOptions(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #2: Two variants are titled the same
+	req.ModifierGroups[0].MaxSelections = 2
+	req.Variants[1].Title = "small"
+	_, err = cfg.service.setProductOptions(ctx, req)
+	assert.Error(t, err)
+
+	req.Variants[1].Title = "large"
+	tx := mocks.NewMockTx(cfg.ctrl)
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(tx, nil).Times(2)
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row)
+
+	// Test case #3: The partner doesn't sell the product
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	tx.EXPECT().Rollback(gomock.Any()).Return(nil)
+	_, err = cfg.service.setProductOptions(ctx, req)
+	assert.EqualError(t, err, "the partner doesn't sell the product")
+
+	// Test case #4: Success, the saved options are returned
+	tx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).Times(2)
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil).Times(2)
+	tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(6)
+	tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	rows := mocks.NewMockRows(cfg.ctrl)
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil).Times(2)
+	rows.EXPECT().Next().Return(false).Times(2)
+	rows.EXPECT().Close().Times(2)
+	resp, err := cfg.service.setProductOptions(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), resp.ProductID)
+}
@@ -0,0 +1,177 @@
+package partners
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shahzodshafizod/gocloud/pkg"
+)
+
+// maxWebhookBackoff caps the exponential backoff between the attempts of a delivery.
+const maxWebhookBackoff = time.Hour * 6
+
+// webhook is a delivery claimed to be attempted, with the partner API it's sent to.
+type webhook struct {
+	ID        int64
+	PartnerID int32
+	Event     string
+	Payload   []byte
+	Attempts  int32 // made before this one
+	APIURL    string
+	Secret    string
+}
+
+// dispatcher delivers the events to the partner APIs as webhooks signed with the partner's secret.
+// A delivery failed by an error, a timeout or a non-2xx response is retried with exponential backoff,
+// and is dead after maxAttempts, until an admin replays it. Every attempt is logged.
+type dispatcher struct {
+	repository  Repository
+	httpClient  pkg.HTTPClient
+	maxAttempts int32
+	backoff     time.Duration // before the second attempt, doubled for each next one
+	timeout     time.Duration // of an attempt
+}
+
+func newDispatcher(repository Repository, httpClient pkg.HTTPClient) *dispatcher {
+	maxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+	backoff, err := time.ParseDuration(os.Getenv("WEBHOOK_BACKOFF"))
+	if err != nil {
+		backoff = time.Second * 30
+	}
+	timeout, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT"))
+	if err != nil {
+		timeout = time.Second * 10
+	}
+	return &dispatcher{
+		repository:  repository,
+		httpClient:  httpClient,
+		maxAttempts: int32(maxAttempts),
+		backoff:     backoff,
+		timeout:     timeout,
+	}
+}
+
+// lockFor is how long a claimed delivery is skipped by the other instances.
+func (d *dispatcher) lockFor() time.Duration {
+	return d.timeout + time.Second*30
+}
+
+// dispatch queues the event for the partner and makes its first attempt at once. An event
+// queued already, e.g. by a redelivered message, isn't sent again.
+func (d *dispatcher) dispatch(ctx context.Context, event string, orderID int64, partnerID int, payload any) error {
+	hook, err := d.repository.enqueueWebhook(ctx, event, orderID, partnerID, payload, d.lockFor())
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "d.repository.enqueueWebhook")
+	}
+	err = d.deliver(ctx, hook)
+	if err != nil {
+		return errors.Wrap(err, "d.deliver")
+	}
+	return nil
+}
+
+// deliverDue attempts the deliveries whose backoff is over. A failed delivery doesn't stop the others.
+func (d *dispatcher) deliverDue(ctx context.Context) error {
+	due, err := d.repository.claimDueWebhooks(ctx, 50, d.lockFor())
+	if err != nil {
+		return errors.Wrap(err, "d.repository.claimDueWebhooks")
+	}
+	var lastErr error
+	for _, hook := range due {
+		err = d.deliver(ctx, hook)
+		if err != nil {
+			lastErr = errors.Wrapf(err, "d.deliver: delivery %d", hook.ID)
+		}
+	}
+	return lastErr
+}
+
+// replay attempts a delivery again at once with all the attempts, e.g. a dead one once the partner API is fixed.
+func (d *dispatcher) replay(ctx context.Context, deliveryID int64) error {
+	hook, err := d.repository.replayWebhook(ctx, deliveryID, d.lockFor())
+	if err != nil {
+		return errors.Wrap(err, "d.repository.replayWebhook")
+	}
+	err = d.deliver(ctx, hook)
+	if err != nil {
+		return errors.Wrap(err, "d.deliver")
+	}
+	return nil
+}
+
+// deliver makes an attempt of the delivery and logs it. Only the failure to log it is returned,
+// the failed attempt is retried later.
+func (d *dispatcher) deliver(ctx context.Context, hook *webhook) error {
+	deliveryID := strconv.FormatInt(hook.ID, 10)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":    "application/json",
+		"X-Webhook-ID":    deliveryID,
+		"X-Webhook-Event": hook.Event,
+		"X-Timestamp":     timestamp,
+		"X-Signature":     signWebhook(hook.Secret, timestamp, deliveryID, hook.Payload),
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, d.timeout)
+	started := time.Now()
+	statusCode, body, err := d.httpClient.Post(attemptCtx, hook.APIURL, headers, hook.Payload)
+	cancel()
+
+	var attempt = &WebhookAttempt{
+		Attempt:    hook.Attempts + 1,
+		StatusCode: int32(statusCode),
+		DurationMs: int32(time.Since(started).Milliseconds()),
+	}
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case statusCode < 200 || statusCode >= 300:
+		if len(body) > 200 {
+			body = body[:200]
+		}
+		attempt.Error = "responded with " + strconv.Itoa(statusCode) + ": " + string(body)
+	}
+
+	var status, nextAttemptAt = "delivered", time.Now()
+	if attempt.Error != "" {
+		status = "pending"
+		nextAttemptAt = nextAttemptAt.Add(webhookBackoff(d.backoff, attempt.Attempt))
+		if attempt.Attempt >= d.maxAttempts {
+			status = "dead"
+		}
+	}
+	err = d.repository.logWebhookAttempt(ctx, hook.ID, attempt, status, nextAttemptAt)
+	if err != nil {
+		return errors.Wrap(err, "d.repository.logWebhookAttempt")
+	}
+	return nil
+}
+
+// signWebhook is hex(hmac_sha256(timestamp + "." + deliveryID + "." + body, secret)), the partner API
+// checks it with its secret and rejects the old timestamps, and the deliveries it has got already by the ID.
+func signWebhook(secret string, timestamp string, deliveryID string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + deliveryID + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff is the wait after the failed `attempt`: doubled after each, up to maxWebhookBackoff.
+func webhookBackoff(backoff time.Duration, attempt int32) time.Duration {
+	for i := int32(1); i < attempt && backoff < maxWebhookBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxWebhookBackoff)
+}
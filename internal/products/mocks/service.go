@@ -41,17 +41,227 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateCategory mocks base method.
+func (m *MockService) CreateCategory(arg0 context.Context, arg1 *products.Category) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockServiceMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockService)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateProduct mocks base method.
+func (m *MockService) CreateProduct(arg0 context.Context, arg1 *products.ProductInfo) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockServiceMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockService)(nil).CreateProduct), arg0, arg1)
+}
+
+// DeleteAvailable mocks base method.
+func (m *MockService) DeleteAvailable(arg0 context.Context, arg1 *products.DeleteAvailableRequest) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAvailable indicates an expected call of DeleteAvailable.
+func (mr *MockServiceMockRecorder) DeleteAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvailable", reflect.TypeOf((*MockService)(nil).DeleteAvailable), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockService) DeleteCategory(arg0 context.Context, arg1 *products.DeleteCategoryRequest) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockServiceMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockService)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockService) DeleteProduct(arg0 context.Context, arg1 *products.DeleteProductRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockServiceMockRecorder) DeleteProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockService)(nil).DeleteProduct), arg0, arg1)
+}
+
+// GetCategories mocks base method.
+func (m *MockService) GetCategories(arg0 context.Context, arg1 *products.GetCategoriesRequest) (*products.GetCategoriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", arg0, arg1)
+	ret0, _ := ret[0].(*products.GetCategoriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockServiceMockRecorder) GetCategories(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockService)(nil).GetCategories), arg0, arg1)
+}
+
 // GetPartnerProducts mocks base method.
-func (m *MockService) GetPartnerProducts(arg0 context.Context) (*products.GetAllResponse, error) {
+func (m *MockService) GetPartnerProducts(arg0 context.Context, arg1 *products.GetAllRequest) (*products.GetAllResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPartnerProducts", arg0)
+	ret := m.ctrl.Call(m, "GetPartnerProducts", arg0, arg1)
 	ret0, _ := ret[0].(*products.GetAllResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPartnerProducts indicates an expected call of GetPartnerProducts.
-func (mr *MockServiceMockRecorder) GetPartnerProducts(arg0 any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetPartnerProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerProducts", reflect.TypeOf((*MockService)(nil).GetPartnerProducts), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockService) GetProduct(arg0 context.Context, arg1 *products.GetProductRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockServiceMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockService)(nil).GetProduct), arg0, arg1)
+}
+
+// ListAvailable mocks base method.
+func (m *MockService) ListAvailable(arg0 context.Context, arg1 *products.ListAvailableRequest) (*products.ListAvailableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.ListAvailableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailable indicates an expected call of ListAvailable.
+func (mr *MockServiceMockRecorder) ListAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailable", reflect.TypeOf((*MockService)(nil).ListAvailable), arg0, arg1)
+}
+
+// ListProducts mocks base method.
+func (m *MockService) ListProducts(arg0 context.Context, arg1 *products.ListProductsRequest) (*products.ListProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
+	ret0, _ := ret[0].(*products.ListProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockServiceMockRecorder) ListProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockService)(nil).ListProducts), arg0, arg1)
+}
+
+// SetAvailable mocks base method.
+func (m *MockService) SetAvailable(arg0 context.Context, arg1 *products.Availability) (*products.Availability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAvailable", arg0, arg1)
+	ret0, _ := ret[0].(*products.Availability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAvailable indicates an expected call of SetAvailable.
+func (mr *MockServiceMockRecorder) SetAvailable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailable", reflect.TypeOf((*MockService)(nil).SetAvailable), arg0, arg1)
+}
+
+// SetProductLabels mocks base method.
+func (m *MockService) SetProductLabels(arg0 context.Context, arg1 *products.ProductLabels) (*products.ProductLabels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductLabels", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductLabels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductLabels indicates an expected call of SetProductLabels.
+func (mr *MockServiceMockRecorder) SetProductLabels(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductLabels", reflect.TypeOf((*MockService)(nil).SetProductLabels), arg0, arg1)
+}
+
+// SetProductPicture mocks base method.
+func (m *MockService) SetProductPicture(arg0 context.Context, arg1 *products.ProductPictureRequest) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductPicture", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductPicture indicates an expected call of SetProductPicture.
+func (mr *MockServiceMockRecorder) SetProductPicture(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductPicture", reflect.TypeOf((*MockService)(nil).SetProductPicture), arg0, arg1)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockService) UpdateCategory(arg0 context.Context, arg1 *products.Category) (*products.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(*products.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockServiceMockRecorder) UpdateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockService)(nil).UpdateCategory), arg0, arg1)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockService) UpdateProduct(arg0 context.Context, arg1 *products.ProductInfo) (*products.ProductInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", arg0, arg1)
+	ret0, _ := ret[0].(*products.ProductInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockServiceMockRecorder) UpdateProduct(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartnerProducts", reflect.TypeOf((*MockService)(nil).GetPartnerProducts), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockService)(nil).UpdateProduct), arg0, arg1)
 }
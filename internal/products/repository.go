@@ -2,13 +2,30 @@ package products
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 
 	"github.com/pkg/errors"
 	"github.com/shahzodshafizod/gocloud/pkg"
 )
 
 type Repository interface {
-	getPartnerProducts(context.Context) (*GetAllResponse, error)
+	getPartnerProducts(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	createProduct(context.Context, *ProductInfo) (*ProductInfo, error)
+	updateProduct(context.Context, *ProductInfo) (*ProductInfo, error)
+	getProduct(ctx context.Context, id int32) (*ProductInfo, error)
+	listProducts(ctx context.Context, partnerID int32) ([]*ProductInfo, error)
+	setProductPicture(context.Context, *ProductPictureRequest) (*ProductInfo, error)
+	deleteProduct(context.Context, *DeleteProductRequest) (*ProductInfo, error)
+	setAvailable(context.Context, *Availability) (*Availability, error)
+	listAvailable(ctx context.Context, partnerID int32) ([]*Availability, error)
+	deleteAvailable(context.Context, *DeleteAvailableRequest) (*Availability, error)
+	getFacets(context.Context, *GetAllRequest) (categories []*Facet, tags []*Facet, err error)
+	setProductLabels(context.Context, *ProductLabels) error
+	createCategory(context.Context, *Category) (*Category, error)
+	updateCategory(context.Context, *Category) (*Category, error)
+	deleteCategory(ctx context.Context, id int32) (*Category, error)
+	listCategories(context.Context) ([]*Category, error)
 }
 
 type repository struct {
@@ -21,24 +38,121 @@ func NewRepository(postgres pkg.Postgres) Repository {
 	}
 }
 
-func (r *repository) getPartnerProducts(ctx context.Context) (*GetAllResponse, error) {
-	var query = `SELECT
-		pts.id
-		, pts.title
-		, pts.brand
+// sortKeys are the sort orders of the products list, of the partners first and then of a partner's
+// products, all ascending: the descending values are negated. The partners open now come first.
+var sortKeys = map[string]string{
+	"":           "(cls.closed <> '')::FLOAT8, pts.id::FLOAT8, pds.id::FLOAT8",
+	"rating":     "(cls.closed <> '')::FLOAT8, -pts.rating, -pts.rating_count::FLOAT8, pts.id::FLOAT8, pds.id::FLOAT8",
+	"price_asc":  "(cls.closed <> '')::FLOAT8, pts.id::FLOAT8, ava.price::FLOAT8, pds.id::FLOAT8",
+	"price_desc": "(cls.closed <> '')::FLOAT8, pts.id::FLOAT8, -ava.price::FLOAT8, pds.id::FLOAT8",
+	"relevance":  "(cls.closed <> '')::FLOAT8, pts.id::FLOAT8, -ts_rank(pds.search, websearch_to_tsquery('simple', $1))::FLOAT8, pds.id::FLOAT8",
+}
 
-		, pds.id
-		, pds.title
-		, pds.description
-		, pds.picture_url
-		, ava.price
-	FROM available ava
-	INNER JOIN partners pts ON pts.id = ava.partner_id
-	INNER JOIN products pds ON pds.id = ava.product_id
-	WHERE ava.active AND pts.verified AND pts.enabled
-	ORDER BY pts.id, pds.id`
+// cursor is the sort key of the last product of a page, the next page starts after it.
+type cursor struct {
+	SortBy string    `json:"s"`
+	Key    []float64 `json:"k"`
+}
 
-	rows, err := r.postgres.Query(ctx, query)
+func encodeCursor(sortBy string, key []float64) (string, error) {
+	data, err := json.Marshal(&cursor{SortBy: sortBy, Key: key})
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor decodes the sort key to start the page after, nil - the first page.
+func decodeCursor(sortBy string, value string) ([]float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "base64.DecodeString")
+	}
+	var c cursor
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	if c.SortBy != sortBy || len(c.Key) == 0 {
+		return nil, errors.New("the cursor is of another sort order")
+	}
+	return c.Key, nil
+}
+
+// listedProducts makes the products on sale matching the filters of GetAllRequest, $1 to $6,
+// the listed table, with the keys to sort them by.
+func listedProducts(keys string) string {
+	return `WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE id = $5
+		UNION ALL
+		SELECT cat.id FROM categories cat INNER JOIN subtree st ON cat.parent_id = st.id
+	), listed AS (
+		SELECT
+			pts.id AS partner_id
+			, pts.title AS partner_title
+			, pts.brand
+			, pts.rating
+			, pts.rating_count
+
+			, pds.id
+			, pds.title
+			, pds.description
+			, pds.picture_url
+			, ava.price
+			, cls.closed
+			, ARRAY[` + keys + `] AS sort_key
+		FROM available ava
+		INNER JOIN partners pts ON pts.id = ava.partner_id
+		INNER JOIN products pds ON pds.id = ava.product_id
+		CROSS JOIN LATERAL (SELECT COALESCE(partner_closed_reason(pts.id, now()), '') AS closed) cls
+		WHERE
+			ava.active AND (ava.stock IS NULL OR ava.stock > 0) AND pts.verified AND pts.enabled
+			AND ($1 = '' OR pds.search @@ websearch_to_tsquery('simple', $1))
+			AND ($2 = 0 OR pts.id = $2)
+			AND ($3 = 0 OR ava.price >= $3)
+			AND ($4 = 0 OR ava.price <= $4)
+			AND ($5 = 0 OR EXISTS (
+				SELECT 1 FROM product_categories pc INNER JOIN subtree st ON st.id = pc.category_id
+				WHERE pc.product_id = pds.id
+			))
+			AND ($6 = '' OR EXISTS (
+				SELECT 1 FROM product_tags pt INNER JOIN tags t ON t.id = pt.tag_id
+				WHERE pt.product_id = pds.id AND t.name = lower($6)
+			))
+	)`
+}
+
+func (r *repository) getPartnerProducts(ctx context.Context, req *GetAllRequest) (*GetAllResponse, error) {
+	keys, found := sortKeys[req.GetSortBy()]
+	if !found {
+		return nil, errors.New("unknown sort order " + req.GetSortBy())
+	}
+	after, err := decodeCursor(req.GetSortBy(), req.GetCursor())
+	if err != nil {
+		return nil, errors.Wrap(err, "decodeCursor")
+	}
+
+	// the products of a partner must come in a row, so the partner id is always sorted by
+	var query = listedProducts(keys) + `
+	SELECT * FROM listed
+	WHERE $7::FLOAT8[] IS NULL OR sort_key > $7::FLOAT8[]
+	ORDER BY sort_key
+	LIMIT $8`
+
+	// one more product tells there is the next page
+	rows, err := r.postgres.Query(ctx, query,
+		req.GetQuery(),
+		req.GetPartnerID(),
+		req.GetMinPrice(),
+		req.GetMaxPrice(),
+		req.GetCategoryID(),
+		req.GetTag(),
+		after,
+		req.GetLimit()+1,
+	)
 	if err != nil {
 		return nil, errors.Wrap(err, "r.postgres.Query")
 	}
@@ -47,20 +161,36 @@ func (r *repository) getPartnerProducts(ctx context.Context) (*GetAllResponse, e
 	var prevID int32 = -1
 	var partner *Partner
 	var resp = &GetAllResponse{Partners: make([]*Partner, 0)}
+	var count int32
+	var lastKey []float64
 
 	for rows.Next() {
-		var id int32
-		var title, brand string
+		if count == req.GetLimit() {
+			resp.NextCursor, err = encodeCursor(req.GetSortBy(), lastKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "encodeCursor")
+			}
+			break
+		}
+		count++
+
+		var id, ratingCount int32
+		var title, brand, closed string
+		var rating float64
 		var product = &PartnerProduct{}
 		err = rows.Scan(
 			&id,
 			&title,
 			&brand,
+			&rating,
+			&ratingCount,
 			&product.ID,
 			&product.Title,
 			&product.Description,
 			&product.PictureURL,
 			&product.Price,
+			&closed,
+			&lastKey,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "rows.Scan")
@@ -68,10 +198,13 @@ func (r *repository) getPartnerProducts(ctx context.Context) (*GetAllResponse, e
 		if id != prevID {
 			resp.Partners = append(resp.Partners, partner)
 			partner = &Partner{
-				ID:       id,
-				Title:    title,
-				Brand:    brand,
-				Products: []*PartnerProduct{product},
+				ID:          id,
+				Title:       title,
+				Brand:       brand,
+				Products:    []*PartnerProduct{product},
+				Rating:      rating,
+				RatingCount: ratingCount,
+				Closed:      closed,
 			}
 		} else {
 			partner.Products = append(partner.Products, product)
@@ -83,3 +216,432 @@ func (r *repository) getPartnerProducts(ctx context.Context) (*GetAllResponse, e
 	resp.Partners = resp.Partners[1:]
 	return resp, nil
 }
+
+const productColumns = `id, title, description, picture_url, COALESCE(partner_id, 0)
+	, ARRAY(SELECT pc.category_id FROM product_categories pc WHERE pc.product_id = products.id ORDER BY 1)
+	, ARRAY(
+		SELECT t.name FROM product_tags pt INNER JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.product_id = products.id ORDER BY 1
+	)`
+
+func scanProduct(row pkg.Row) (*ProductInfo, error) {
+	var product = &ProductInfo{}
+	err := row.Scan(
+		&product.ID,
+		&product.Title,
+		&product.Description,
+		&product.PictureURL,
+		&product.PartnerID,
+		&product.CategoryIDs,
+		&product.Tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (r *repository) createProduct(ctx context.Context, p *ProductInfo) (*ProductInfo, error) {
+	query := `INSERT INTO products (title, description, partner_id)
+	VALUES ($1, $2, NULLIF($3, 0))
+	RETURNING ` + productColumns
+	product, err := scanProduct(r.postgres.QueryRow(ctx, query, p.Title, p.Description, p.PartnerID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return product, nil
+}
+
+// updateProduct changes the product of the admins' catalog only by an admin, i.e. the partner id is 0
+func (r *repository) updateProduct(ctx context.Context, p *ProductInfo) (*ProductInfo, error) {
+	query := `UPDATE products
+	SET
+		title = $2
+		, description = $3
+	WHERE id = $1 AND ($4 = 0 OR partner_id = $4)
+	RETURNING ` + productColumns
+	product, err := scanProduct(r.postgres.QueryRow(ctx, query, p.ID, p.Title, p.Description, p.PartnerID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return product, nil
+}
+
+func (r *repository) getProduct(ctx context.Context, id int32) (*ProductInfo, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE id = $1`
+	product, err := scanProduct(r.postgres.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return product, nil
+}
+
+func (r *repository) listProducts(ctx context.Context, partnerID int32) ([]*ProductInfo, error) {
+	query := `SELECT ` + productColumns + ` FROM products
+	WHERE $1 = 0 OR partner_id IS NULL OR partner_id = $1
+	ORDER BY id`
+	rows, err := r.postgres.Query(ctx, query, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var products = make([]*ProductInfo, 0)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+func (r *repository) setProductPicture(ctx context.Context, req *ProductPictureRequest) (*ProductInfo, error) {
+	query := `UPDATE products
+	SET picture_url = $2
+	WHERE id = $1 AND ($3 = 0 OR partner_id = $3)
+	RETURNING ` + productColumns
+	product, err := scanProduct(r.postgres.QueryRow(ctx, query, req.ID, req.PictureURL, req.PartnerID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return product, nil
+}
+
+// deleteProduct takes the product off sale by all the partners, then deletes it.
+func (r *repository) deleteProduct(ctx context.Context, req *DeleteProductRequest) (*ProductInfo, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	// the product is locked and its owner checked first, so no one else's listings are deleted
+	query := `SELECT ` + productColumns + ` FROM products
+	WHERE id = $1 AND ($2 = 0 OR partner_id = $2)
+	FOR UPDATE`
+	product, err := scanProduct(tx.QueryRow(ctx, query, req.ID, req.PartnerID))
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "SELECT products")
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM available WHERE product_id = $1`, product.ID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) { // the product might be sold by no one
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "DELETE FROM available")
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM products WHERE id = $1`, product.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, errors.Wrap(err, "DELETE FROM products")
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Commit")
+	}
+	return product, nil
+}
+
+const availableColumns = `ava.product_id, ava.partner_id, ava.price, ava.active, pds.title, COALESCE(ava.stock, -1)`
+
+func scanAvailable(row pkg.Row) (*Availability, error) {
+	var available = &Availability{}
+	err := row.Scan(
+		&available.ProductID,
+		&available.PartnerID,
+		&available.Price,
+		&available.Active,
+		&available.Title,
+		&available.Stock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return available, nil
+}
+
+// setAvailable puts the product on sale by the partner or changes its price, a partner can't sell
+// the products other partners added.
+func (r *repository) setAvailable(ctx context.Context, a *Availability) (*Availability, error) {
+	query := `WITH ava AS (
+		INSERT INTO available (product_id, partner_id, price, active, stock)
+		SELECT id, $2, $3, $4, NULLIF($5, -1)
+		FROM products
+		WHERE id = $1 AND (partner_id IS NULL OR partner_id = $2)
+		ON CONFLICT (product_id, partner_id) DO UPDATE
+		SET
+			price = EXCLUDED.price
+			, active = EXCLUDED.active
+			, stock = EXCLUDED.stock
+		RETURNING product_id, partner_id, price, active, stock
+	)
+	SELECT ` + availableColumns + `
+	FROM ava
+	INNER JOIN products pds ON pds.id = ava.product_id`
+	available, err := scanAvailable(r.postgres.QueryRow(ctx, query, a.ProductID, a.PartnerID, a.Price, a.Active, a.Stock))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return available, nil
+}
+
+func (r *repository) listAvailable(ctx context.Context, partnerID int32) ([]*Availability, error) {
+	query := `SELECT ` + availableColumns + `
+	FROM available ava
+	INNER JOIN products pds ON pds.id = ava.product_id
+	WHERE ava.partner_id = $1
+	ORDER BY ava.product_id`
+	rows, err := r.postgres.Query(ctx, query, partnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var list = make([]*Availability, 0)
+	for rows.Next() {
+		available, err := scanAvailable(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		list = append(list, available)
+	}
+	return list, nil
+}
+
+func (r *repository) deleteAvailable(ctx context.Context, req *DeleteAvailableRequest) (*Availability, error) {
+	query := `WITH ava AS (
+		DELETE FROM available
+		WHERE product_id = $1 AND partner_id = $2
+		RETURNING product_id, partner_id, price, active, stock
+	)
+	SELECT ` + availableColumns + `
+	FROM ava
+	INNER JOIN products pds ON pds.id = ava.product_id`
+	available, err := scanAvailable(r.postgres.QueryRow(ctx, query, req.ProductID, req.PartnerID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return available, nil
+}
+
+// getFacets counts the listed products by their categories and tags, the most common first.
+func (r *repository) getFacets(ctx context.Context, req *GetAllRequest) ([]*Facet, []*Facet, error) {
+	query := listedProducts(sortKeys[""]) + `
+	SELECT 'category', cat.id, cat.title, COUNT(*)::INT
+	FROM listed l
+	INNER JOIN product_categories pc ON pc.product_id = l.id
+	INNER JOIN categories cat ON cat.id = pc.category_id
+	GROUP BY cat.id, cat.title
+	UNION ALL
+	SELECT 'tag', t.id, t.name, COUNT(*)::INT
+	FROM listed l
+	INNER JOIN product_tags pt ON pt.product_id = l.id
+	INNER JOIN tags t ON t.id = pt.tag_id
+	GROUP BY t.id, t.name
+	ORDER BY 1, 4 DESC, 3`
+	rows, err := r.postgres.Query(ctx, query,
+		req.GetQuery(),
+		req.GetPartnerID(),
+		req.GetMinPrice(),
+		req.GetMaxPrice(),
+		req.GetCategoryID(),
+		req.GetTag(),
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var categories, tags = make([]*Facet, 0), make([]*Facet, 0)
+	for rows.Next() {
+		var kind string
+		var facet = &Facet{}
+		err = rows.Scan(&kind, &facet.ID, &facet.Title, &facet.Count)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "rows.Scan")
+		}
+		if kind == "category" {
+			categories = append(categories, facet)
+		} else {
+			tags = append(tags, facet)
+		}
+	}
+	return categories, tags, nil
+}
+
+// setProductLabels replaces the categories and the tags of the product, the tags are created if new.
+func (r *repository) setProductLabels(ctx context.Context, labels *ProductLabels) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "r.postgres.Begin")
+	}
+
+	// the product is locked and its owner checked first
+	var id int32
+	query := `SELECT id FROM products WHERE id = $1 AND ($2 = 0 OR partner_id = $2) FOR UPDATE`
+	err = tx.QueryRow(ctx, query, labels.ProductID, labels.PartnerID).Scan(&id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "SELECT products")
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM product_categories WHERE product_id = $1`, labels.ProductID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM product_categories")
+	}
+	if len(labels.CategoryIDs) > 0 {
+		var linked int
+		query = `WITH linked AS (
+			INSERT INTO product_categories (product_id, category_id)
+			SELECT $1, id FROM categories WHERE id = ANY($2)
+			RETURNING 1
+		)
+		SELECT COUNT(*) FROM linked`
+		err = tx.QueryRow(ctx, query, labels.ProductID, labels.CategoryIDs).Scan(&linked)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO product_categories")
+		}
+		if linked != len(labels.CategoryIDs) {
+			tx.Rollback(ctx)
+			return pkg.ErrNoRows // of a category
+		}
+	}
+
+	err = tx.Exec(ctx, `DELETE FROM product_tags WHERE product_id = $1`, labels.ProductID)
+	if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) {
+		tx.Rollback(ctx)
+		return errors.Wrap(err, "DELETE FROM product_tags")
+	}
+	if len(labels.Tags) > 0 {
+		query = `INSERT INTO tags (name) SELECT unnest($1::VARCHAR[]) ON CONFLICT (name) DO NOTHING`
+		err = tx.Exec(ctx, query, labels.Tags)
+		if err != nil && !errors.Is(err, pkg.ErrNoRowsAffected) { // the tags might exist already
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO tags")
+		}
+		query = `INSERT INTO product_tags (product_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2)`
+		err = tx.Exec(ctx, query, labels.ProductID, labels.Tags)
+		if err != nil {
+			tx.Rollback(ctx)
+			return errors.Wrap(err, "INSERT INTO product_tags")
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+const categoryColumns = `id, title, COALESCE(parent_id, 0)`
+
+func scanCategory(row pkg.Row) (*Category, error) {
+	var category = &Category{}
+	err := row.Scan(
+		&category.ID,
+		&category.Title,
+		&category.ParentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// createCategory creates the category unless its parent is missing or has a subcategory with the title.
+func (r *repository) createCategory(ctx context.Context, c *Category) (*Category, error) {
+	query := `INSERT INTO categories (title, parent_id)
+	SELECT $1, NULLIF($2, 0)
+	WHERE
+		($2 = 0 OR EXISTS (SELECT 1 FROM categories WHERE id = $2))
+		AND NOT EXISTS (SELECT 1 FROM categories WHERE COALESCE(parent_id, 0) = $2 AND lower(title) = lower($1))
+	RETURNING ` + categoryColumns
+	category, err := scanCategory(r.postgres.QueryRow(ctx, query, c.Title, c.ParentID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return category, nil
+}
+
+// updateCategory renames or moves the category, it can't be moved under itself or its subcategories.
+func (r *repository) updateCategory(ctx context.Context, c *Category) (*Category, error) {
+	query := `WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE id = $1
+		UNION ALL
+		SELECT cat.id FROM categories cat INNER JOIN subtree st ON cat.parent_id = st.id
+	)
+	UPDATE categories
+	SET
+		title = $2
+		, parent_id = NULLIF($3, 0)
+	WHERE
+		id = $1
+		AND ($3 = 0 OR EXISTS (SELECT 1 FROM categories WHERE id = $3))
+		AND NOT EXISTS (SELECT 1 FROM subtree WHERE id = $3)
+		AND NOT EXISTS (
+			SELECT 1 FROM categories
+			WHERE id <> $1 AND COALESCE(parent_id, 0) = $3 AND lower(title) = lower($2)
+		)
+	RETURNING ` + categoryColumns
+	category, err := scanCategory(r.postgres.QueryRow(ctx, query, c.ID, c.Title, c.ParentID))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return category, nil
+}
+
+// deleteCategory deletes the category without subcategories, its products are left uncategorized.
+func (r *repository) deleteCategory(ctx context.Context, id int32) (*Category, error) {
+	query := `DELETE FROM categories
+	WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)
+	RETURNING ` + categoryColumns
+	category, err := scanCategory(r.postgres.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.QueryRow")
+	}
+	return category, nil
+}
+
+// listCategories lists all the categories with the count of the products on sale by the enabled
+// partners of the category or any of its subcategories.
+func (r *repository) listCategories(ctx context.Context) ([]*Category, error) {
+	query := `WITH RECURSIVE tree AS (
+		SELECT id, id AS root FROM categories
+		UNION ALL
+		SELECT cat.id, tree.root FROM categories cat INNER JOIN tree ON cat.parent_id = tree.id
+	), sold AS (
+		SELECT DISTINCT ava.product_id
+		FROM available ava
+		INNER JOIN partners pts ON pts.id = ava.partner_id
+		WHERE ava.active AND (ava.stock IS NULL OR ava.stock > 0) AND pts.verified AND pts.enabled
+	)
+	SELECT cat.id, cat.title, COALESCE(cat.parent_id, 0), COUNT(DISTINCT sold.product_id)::INT
+	FROM categories cat
+	INNER JOIN tree ON tree.root = cat.id
+	LEFT JOIN product_categories pc ON pc.category_id = tree.id
+	LEFT JOIN sold ON sold.product_id = pc.product_id
+	GROUP BY cat.id, cat.title, cat.parent_id
+	ORDER BY cat.title`
+	rows, err := r.postgres.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "r.postgres.Query")
+	}
+	defer rows.Close()
+
+	var categories = make([]*Category, 0)
+	for rows.Next() {
+		var category = &Category{}
+		err = rows.Scan(&category.ID, &category.Title, &category.ParentID, &category.ProductCount)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
@@ -2,12 +2,30 @@ package products
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
+	"github.com/shahzodshafizod/gocloud/pkg"
 )
 
 type Service interface {
-	GetPartnerProducts(context.Context) (*GetAllResponse, error)
+	GetPartnerProducts(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	CreateProduct(context.Context, *ProductInfo) (*ProductInfo, error)
+	UpdateProduct(context.Context, *ProductInfo) (*ProductInfo, error)
+	GetProduct(context.Context, *GetProductRequest) (*ProductInfo, error)
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	SetProductPicture(context.Context, *ProductPictureRequest) (*ProductInfo, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*ProductInfo, error)
+	SetAvailable(context.Context, *Availability) (*Availability, error)
+	ListAvailable(context.Context, *ListAvailableRequest) (*ListAvailableResponse, error)
+	DeleteAvailable(context.Context, *DeleteAvailableRequest) (*Availability, error)
+	SetProductLabels(context.Context, *ProductLabels) (*ProductLabels, error)
+	CreateCategory(context.Context, *Category) (*Category, error)
+	UpdateCategory(context.Context, *Category) (*Category, error)
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*Category, error)
+	GetCategories(context.Context, *GetCategoriesRequest) (*GetCategoriesResponse, error)
 }
 
 type service struct {
@@ -20,10 +38,281 @@ func NewService(repository Repository) Service {
 	}
 }
 
-func (s *service) GetPartnerProducts(ctx context.Context) (*GetAllResponse, error) {
-	resp, err := s.repository.getPartnerProducts(ctx)
+// the page size of the products list
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+func (s *service) GetPartnerProducts(ctx context.Context, req *GetAllRequest) (*GetAllResponse, error) {
+	err := checkGetAll(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkGetAll")
+	}
+	resp, err := s.repository.getPartnerProducts(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, "s.repository.getPartnerProducts")
 	}
+	// the facets are the same for all the pages, they come with the first one
+	if req.Cursor == "" {
+		resp.Categories, resp.Tags, err = s.repository.getFacets(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "s.repository.getFacets")
+		}
+	}
+	return resp, nil
+}
+
+func (s *service) CreateProduct(ctx context.Context, req *ProductInfo) (*ProductInfo, error) {
+	err := checkProduct(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkProduct")
+	}
+	product, err := s.repository.createProduct(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createProduct")
+	}
+	return product, nil
+}
+
+func (s *service) UpdateProduct(ctx context.Context, req *ProductInfo) (*ProductInfo, error) {
+	err := checkProduct(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkProduct")
+	}
+	product, err := s.repository.updateProduct(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner has no such product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.updateProduct")
+	}
+	return product, nil
+}
+
+func (s *service) GetProduct(ctx context.Context, req *GetProductRequest) (*ProductInfo, error) {
+	product, err := s.repository.getProduct(ctx, req.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.getProduct")
+	}
+	return product, nil
+}
+
+func (s *service) ListProducts(ctx context.Context, req *ListProductsRequest) (*ListProductsResponse, error) {
+	products, err := s.repository.listProducts(ctx, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listProducts")
+	}
+	return &ListProductsResponse{Products: products}, nil
+}
+
+func (s *service) SetProductPicture(ctx context.Context, req *ProductPictureRequest) (*ProductInfo, error) {
+	product, err := s.repository.setProductPicture(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner has no such product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setProductPicture")
+	}
+	return product, nil
+}
+
+func (s *service) DeleteProduct(ctx context.Context, req *DeleteProductRequest) (*ProductInfo, error) {
+	product, err := s.repository.deleteProduct(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner has no such product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.deleteProduct")
+	}
+	return product, nil
+}
+
+func (s *service) SetAvailable(ctx context.Context, req *Availability) (*Availability, error) {
+	if req.PartnerID <= 0 {
+		return nil, errors.New("partner id is required")
+	}
+	if req.Price <= 0 {
+		return nil, errors.New("price must be positive")
+	}
+	if req.Stock < -1 {
+		return nil, errors.New("stock can't be negative")
+	}
+	available, err := s.repository.setAvailable(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner can't sell such product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setAvailable")
+	}
+	return available, nil
+}
+
+func (s *service) ListAvailable(ctx context.Context, req *ListAvailableRequest) (*ListAvailableResponse, error) {
+	available, err := s.repository.listAvailable(ctx, req.PartnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listAvailable")
+	}
+	return &ListAvailableResponse{Available: available}, nil
+}
+
+func (s *service) DeleteAvailable(ctx context.Context, req *DeleteAvailableRequest) (*Availability, error) {
+	available, err := s.repository.deleteAvailable(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner doesn't sell such product")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.deleteAvailable")
+	}
+	return available, nil
+}
+
+// checkProduct checks the product against the products table columns.
+func checkProduct(product *ProductInfo) error {
+	product.Title = strings.TrimSpace(product.Title)
+	if product.Title == "" || utf8.RuneCountInString(product.Title) > 100 {
+		return errors.New("title must be 1 to 100 characters long")
+	}
+	if utf8.RuneCountInString(product.Description) > 256 {
+		return errors.New("description must be at most 256 characters long")
+	}
+	return nil
+}
+
+// checkGetAll checks the filters of the products list and limits its page size.
+func checkGetAll(req *GetAllRequest) error {
+	req.Query = strings.TrimSpace(req.Query)
+	if utf8.RuneCountInString(req.Query) > 100 {
+		return errors.New("query must be at most 100 characters long")
+	}
+	if req.SortBy == "relevance" && req.Query == "" {
+		return errors.New("relevance sort requires a query")
+	}
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return errors.New("price bounds must not be negative")
+	}
+	if req.MaxPrice != 0 && req.MinPrice > req.MaxPrice {
+		return errors.New("min price must not exceed max price")
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultLimit
+	}
+	if req.Limit > maxLimit {
+		req.Limit = maxLimit
+	}
+	return nil
+}
+
+// SetProductLabels replaces the categories and the tags of the product.
+func (s *service) SetProductLabels(ctx context.Context, req *ProductLabels) (*ProductLabels, error) {
+	err := checkLabels(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkLabels")
+	}
+	err = s.repository.setProductLabels(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("the partner has no such product, or no such category")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.setProductLabels")
+	}
+	return req, nil
+}
+
+func (s *service) CreateCategory(ctx context.Context, req *Category) (*Category, error) {
+	err := checkCategory(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkCategory")
+	}
+	category, err := s.repository.createCategory(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("no such parent category, or it has a subcategory with the title already")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.createCategory")
+	}
+	return category, nil
+}
+
+func (s *service) UpdateCategory(ctx context.Context, req *Category) (*Category, error) {
+	err := checkCategory(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "checkCategory")
+	}
+	category, err := s.repository.updateCategory(ctx, req)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("no such category or parent, the parent is the category's subcategory, or it has a subcategory with the title already")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.updateCategory")
+	}
+	return category, nil
+}
+
+func (s *service) DeleteCategory(ctx context.Context, req *DeleteCategoryRequest) (*Category, error) {
+	category, err := s.repository.deleteCategory(ctx, req.ID)
+	if errors.Is(err, pkg.ErrNoRows) {
+		return nil, errors.New("no such category, or it has subcategories")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.deleteCategory")
+	}
+	return category, nil
+}
+
+// GetCategories makes the tree of the categories, the siblings are sorted by title.
+func (s *service) GetCategories(ctx context.Context, req *GetCategoriesRequest) (*GetCategoriesResponse, error) {
+	categories, err := s.repository.listCategories(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "s.repository.listCategories")
+	}
+	var byID = make(map[int32]*Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	var resp = &GetCategoriesResponse{Categories: make([]*Category, 0)}
+	for _, category := range categories {
+		parent, found := byID[category.ParentID]
+		if !found {
+			resp.Categories = append(resp.Categories, category)
+			continue
+		}
+		parent.Subcategories = append(parent.Subcategories, category)
+	}
 	return resp, nil
 }
+
+func checkCategory(category *Category) error {
+	category.Title = strings.TrimSpace(category.Title)
+	if category.Title == "" || utf8.RuneCountInString(category.Title) > 100 {
+		return errors.New("title must be 1 to 100 characters long")
+	}
+	if category.ParentID < 0 || category.ParentID != 0 && category.ParentID == category.ID {
+		return errors.New("invalid parent category")
+	}
+	return nil
+}
+
+// checkLabels removes the repeated categories and tags, the tags are trimmed and lowercased.
+func checkLabels(labels *ProductLabels) error {
+	slices.Sort(labels.CategoryIDs)
+	labels.CategoryIDs = slices.Compact(labels.CategoryIDs)
+	if len(labels.CategoryIDs) > 10 {
+		return errors.New("a product can be in 10 categories at most")
+	}
+
+	var tags = make([]string, 0, len(labels.Tags))
+	for _, tag := range labels.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > 50 {
+			return errors.New("tags must be 1 to 50 characters long")
+		}
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	labels.Tags = slices.Compact(tags)
+	if len(labels.Tags) > 20 {
+		return errors.New("a product can have 20 tags at most")
+	}
+	return nil
+}
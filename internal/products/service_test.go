@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shahzodshafizod/gocloud/pkg"
 	"github.com/shahzodshafizod/gocloud/pkg/mocks"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -12,7 +13,9 @@ import (
 
 type testConfig struct {
 	ctrl     *gomock.Controller
+	row      *mocks.MockRow
 	rows     *mocks.MockRows
+	tx       *mocks.MockTx
 	postgres *mocks.MockPostgres
 	service  Service
 }
@@ -21,7 +24,9 @@ func __SetupTestConfig(t *testing.T) *testConfig {
 	ctrl := gomock.NewController(t)
 	cfg := &testConfig{
 		ctrl:     ctrl,
+		row:      mocks.NewMockRow(ctrl),
 		rows:     mocks.NewMockRows(ctrl),
+		tx:       mocks.NewMockTx(ctrl),
 		postgres: mocks.NewMockPostgres(ctrl),
 	}
 	repository := NewRepository(cfg.postgres)
@@ -35,19 +40,20 @@ func TestGetPartnerProducts(t *testing.T) {
 	defer cfg.ctrl.Finish()
 
 	ctx := context.Background()
+	req := &GetAllRequest{}
 
 	// Test case #1
 	targetError := errors.New("r.postgres.Query")
 	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, targetError)
-	_, err := cfg.service.GetPartnerProducts(ctx)
+	_, err := cfg.service.GetPartnerProducts(ctx, req)
 	assert.True(t, errors.Is(err, targetError))
 
 	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.rows, nil).AnyTimes()
 	cfg.rows.EXPECT().Close().AnyTimes()
 
-	// Test case #2: Empty response
-	cfg.rows.EXPECT().Next().Return(false)
-	resp, err := cfg.service.GetPartnerProducts(ctx)
+	// Test case #2: Empty response, of the products and the facets
+	cfg.rows.EXPECT().Next().Return(false).Times(2)
+	resp, err := cfg.service.GetPartnerProducts(ctx, req)
 	assert.NoError(t, err)
 	assert.True(t, len(resp.Partners) == 0)
 
@@ -55,7 +61,7 @@ func TestGetPartnerProducts(t *testing.T) {
 	targetError = errors.New("Scan error")
 	cfg.rows.EXPECT().Next().Return(true).Times(1)
 	cfg.rows.EXPECT().Scan(gomock.Any()).Return(targetError).Times(1)
-	_, err = cfg.service.GetPartnerProducts(ctx)
+	_, err = cfg.service.GetPartnerProducts(ctx, req)
 	assert.True(t, errors.Is(err, targetError))
 
 	cfg.rows.EXPECT().Next().Return(true).Times(2)
@@ -63,17 +69,246 @@ func TestGetPartnerProducts(t *testing.T) {
 		*dest[0].(*int32) = 1
 		*dest[1].(*string) = "test partner title"
 		*dest[2].(*string) = "test partner brand"
-		*dest[3].(*int32) = 1
-		*dest[4].(*string) = "test product title"
-		*dest[5].(*string) = "test product description"
-		*dest[6].(*string) = "test product picture URL"
-		*dest[7].(*int32) = 100
+		*dest[3].(*float64) = 4.5
+		*dest[4].(*int32) = 2
+		*dest[5].(*int32) = 1
+		*dest[6].(*string) = "test product title"
+		*dest[7].(*string) = "test product description"
+		*dest[8].(*string) = "test product picture URL"
+		*dest[9].(*int32) = 100
+		*dest[10].(*string) = "paused"
 		return nil
 	}).Times(2)
 	cfg.rows.EXPECT().Next().Return(false).Times(1)
+	cfg.rows.EXPECT().Next().Return(true).Times(1)
+	cfg.rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "category"
+		*dest[1].(*int32) = 3
+		*dest[2].(*string) = "Pizza"
+		*dest[3].(*int32) = 2
+		return nil
+	})
+	cfg.rows.EXPECT().Next().Return(false).Times(1)
+
+	// Test case #4: Sorted by rating, with the facets of the first page
+	req.SortBy = "rating"
+	resp, err = cfg.service.GetPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Partners, 1)
+	assert.Equal(t, 4.5, resp.Partners[0].Rating)
+	assert.Equal(t, int32(2), resp.Partners[0].RatingCount)
+	assert.Equal(t, "paused", resp.Partners[0].Closed)
+	assert.Empty(t, resp.NextCursor)
+	assert.Equal(t, []*Facet{{ID: 3, Title: "Pizza", Count: 2}}, resp.Categories)
+	assert.Empty(t, resp.Tags)
+
+	// Test case #5: Relevance sort without a query
+	_, err = cfg.service.GetPartnerProducts(ctx, &GetAllRequest{SortBy: "relevance"})
+	assert.Error(t, err)
+
+	// Test case #6: The page is full, the next one starts after its last product
+	req = &GetAllRequest{Query: "pizza", SortBy: "price_asc", Limit: 1}
+	cfg.rows.EXPECT().Next().Return(true).Times(2)
+	cfg.rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int32) = 1
+		*dest[5].(*int32) = 7
+		*dest[11].(*[]float64) = []float64{0, 1, 250, 7}
+		return nil
+	})
+	cfg.rows.EXPECT().Next().Return(false)
+	resp, err = cfg.service.GetPartnerProducts(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Partners, 1)
+	assert.Len(t, resp.Partners[0].Products, 1)
+	assert.NotEmpty(t, resp.NextCursor)
+	after, err := decodeCursor("price_asc", resp.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0, 1, 250, 7}, after)
+
+	// Test case #7: The cursor is of another sort order
+	req.SortBy = "price_desc"
+	req.Cursor = resp.NextCursor
+	_, err = cfg.service.GetPartnerProducts(ctx, req)
+	assert.Error(t, err)
+}
+
+// go test -v -count=1 ./internal/products/ -run ^TestUpdateProduct$
+func TestUpdateProduct(t *testing.T) {
+	var cfg = __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &ProductInfo{ID: 1, Title: "  ", PartnerID: 2}
+
+	// Test case #1: Empty title
+	_, err := cfg.service.UpdateProduct(ctx, req)
+	assert.Error(t, err)
+
+	req.Title = " test product title "
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #2: Product of the admins' catalog or another partner's
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err = cfg.service.UpdateProduct(ctx, req)
+	assert.EqualError(t, err, "the partner has no such product")
+
+	// Test case #3: Success
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	_, err = cfg.service.UpdateProduct(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "test product title", req.Title)
+}
+
+// go test -v -count=1 ./internal/products/ -run ^TestDeleteProduct$
+func TestDeleteProduct(t *testing.T) {
+	var cfg = __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &DeleteProductRequest{ID: 1, PartnerID: 2}
+
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(cfg.tx, nil).AnyTimes()
+	cfg.tx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.tx.EXPECT().Rollback(gomock.Any()).Return(nil).AnyTimes()
+
+	// Test case #1: No such product of the partner
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err := cfg.service.DeleteProduct(ctx, req)
+	assert.EqualError(t, err, "the partner has no such product")
+
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int32) = 1
+		*dest[3].(*string) = "test product picture URL"
+		*dest[4].(*int32) = 2
+		return nil
+	}).AnyTimes()
+
+	// Test case #2
+	targetError := errors.New("DELETE FROM products error")
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pkg.ErrNoRowsAffected)
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(targetError)
+	_, err = cfg.service.DeleteProduct(ctx, req)
+	assert.True(t, errors.Is(err, targetError))
 
-	// Test case #4
-	resp, err = cfg.service.GetPartnerProducts(ctx)
+	// Test case #3: Success, sold by no one
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pkg.ErrNoRowsAffected)
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	cfg.tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	product, err := cfg.service.DeleteProduct(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "test product picture URL", product.PictureURL)
+}
+
+// go test -v -count=1 ./internal/products/ -run ^TestSetAvailable$
+func TestSetAvailable(t *testing.T) {
+	var cfg = __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &Availability{ProductID: 1, PartnerID: 2}
+
+	// Test case #1: No price
+	_, err := cfg.service.SetAvailable(ctx, req)
+	assert.Error(t, err)
+
+	req.Price = 100
+	cfg.postgres.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+
+	// Test case #2: Another partner's product
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err = cfg.service.SetAvailable(ctx, req)
+	assert.EqualError(t, err, "the partner can't sell such product")
+
+	// Test case #3: Success
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	_, err = cfg.service.SetAvailable(ctx, req)
+	assert.NoError(t, err)
+}
+
+// go test -v -count=1 ./internal/products/ -run ^TestGetCategories$
+func TestGetCategories(t *testing.T) {
+	var cfg = __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+
+	// Test case #1
+	targetError := errors.New("r.postgres.Query")
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, targetError)
+	_, err := cfg.service.GetCategories(ctx, &GetCategoriesRequest{})
+	assert.True(t, errors.Is(err, targetError))
+
+	// Test case #2: Success, the subcategories are under their parents
+	var categories = []*Category{
+		{ID: 1, Title: "Food", ProductCount: 5},
+		{ID: 3, Title: "Pizza", ParentID: 1, ProductCount: 2},
+		{ID: 2, Title: "Drinks", ProductCount: 1},
+	}
+	cfg.postgres.EXPECT().Query(gomock.Any(), gomock.Any()).Return(cfg.rows, nil)
+	cfg.rows.EXPECT().Close()
+	for _, category := range categories {
+		cfg.rows.EXPECT().Next().Return(true)
+		cfg.rows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+			*dest[0].(*int32) = category.ID
+			*dest[1].(*string) = category.Title
+			*dest[2].(*int32) = category.ParentID
+			*dest[3].(*int32) = category.ProductCount
+			return nil
+		})
+	}
+	cfg.rows.EXPECT().Next().Return(false)
+	resp, err := cfg.service.GetCategories(ctx, &GetCategoriesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Categories, 2)
+	assert.Equal(t, "Food", resp.Categories[0].Title)
+	assert.Equal(t, "Drinks", resp.Categories[1].Title)
+	assert.Len(t, resp.Categories[0].Subcategories, 1)
+	assert.Equal(t, int32(2), resp.Categories[0].Subcategories[0].ProductCount)
+}
+
+// go test -v -count=1 ./internal/products/ -run ^TestSetProductLabels$
+func TestSetProductLabels(t *testing.T) {
+	var cfg = __SetupTestConfig(t)
+	defer cfg.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &ProductLabels{ProductID: 1, PartnerID: 2, Tags: []string{"Vegan", " ", "spicy"}}
+
+	// Test case #1: Empty tag
+	_, err := cfg.service.SetProductLabels(ctx, req)
+	assert.Error(t, err)
+
+	cfg.postgres.EXPECT().Begin(gomock.Any()).Return(cfg.tx, nil).AnyTimes()
+	cfg.tx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(cfg.row).AnyTimes()
+	cfg.tx.EXPECT().Rollback(gomock.Any()).Return(nil).AnyTimes()
+
+	// Test case #2: The product is another partner's
+	req.Tags = []string{"Vegan", "spicy", "vegan "}
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(pkg.ErrNoRows)
+	_, err = cfg.service.SetProductLabels(ctx, req)
+	assert.EqualError(t, err, "the partner has no such product, or no such category")
+
+	// Test case #3: One of the categories doesn't exist
+	req.CategoryIDs = []int32{4, 3, 4}
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pkg.ErrNoRowsAffected)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int) = 1
+		return nil
+	})
+	_, err = cfg.service.SetProductLabels(ctx, req)
+	assert.Error(t, err)
+
+	// Test case #4: Success, the repeated labels are left out
+	cfg.row.EXPECT().Scan(gomock.Any()).Return(nil)
+	cfg.row.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*int) = 2
+		return nil
+	})
+	cfg.tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	cfg.tx.EXPECT().Commit(gomock.Any()).Return(nil)
+	resp, err := cfg.service.SetProductLabels(ctx, req)
 	assert.NoError(t, err)
-	assert.NotNil(t, resp)
+	assert.Equal(t, []int32{3, 4}, resp.CategoryIDs)
+	assert.Equal(t, []string{"spicy", "vegan"}, resp.Tags)
 }
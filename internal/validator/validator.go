@@ -2,6 +2,7 @@ package validator
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -21,6 +22,38 @@ func OptionalDateOnly() pkg.Validator {
 	}
 }
 
+// postal codes vary by country: allow 3-10 letters, digits, spaces or hyphens
+var postalCodeRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 \-]{1,8}[A-Za-z0-9]$`)
+
+func PostalCode() pkg.Validator {
+	return &validate{
+		tag: "postalcode",
+		fnc: func(ctx context.Context, fl validator.FieldLevel) bool {
+			return postalCodeRegex.MatchString(fl.Field().String())
+		},
+	}
+}
+
+func Latitude() pkg.Validator {
+	return &validate{
+		tag: "lat",
+		fnc: func(ctx context.Context, fl validator.FieldLevel) bool {
+			value := fl.Field().Float()
+			return value >= -90 && value <= 90
+		},
+	}
+}
+
+func Longitude() pkg.Validator {
+	return &validate{
+		tag: "lng",
+		fnc: func(ctx context.Context, fl validator.FieldLevel) bool {
+			value := fl.Field().Float()
+			return value >= -180 && value <= 180
+		},
+	}
+}
+
 type validate struct {
 	tag string
 	fnc validator.FuncCtx
@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ALTER COLUMN delivery_address TYPE VARCHAR(100) USING COALESCE(delivery_address->>'street', '')
+    , ALTER COLUMN pickup_address DROP DEFAULT
+    , ALTER COLUMN pickup_address TYPE VARCHAR(100) USING COALESCE(pickup_address->>'street', '')
+    , ALTER COLUMN pickup_address SET DEFAULT '';
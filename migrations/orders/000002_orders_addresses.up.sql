@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ALTER COLUMN delivery_address TYPE JSONB USING jsonb_build_object('street', delivery_address)
+    , ALTER COLUMN pickup_address DROP DEFAULT
+    , ALTER COLUMN pickup_address TYPE JSONB USING jsonb_build_object('street', pickup_address)
+    , ALTER COLUMN pickup_address SET DEFAULT '{}';
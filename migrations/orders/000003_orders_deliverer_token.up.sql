@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deliverer_notif_token VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE banks
+    DROP COLUMN IF EXISTS callback_secret
+    , DROP COLUMN IF EXISTS allowed_ips;
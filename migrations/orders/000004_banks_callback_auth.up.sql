@@ -0,0 +1,6 @@
+ALTER TABLE banks
+    ADD COLUMN IF NOT EXISTS callback_secret    VARCHAR(64) NOT NULL DEFAULT ''
+    , ADD COLUMN IF NOT EXISTS allowed_ips      TEXT[]      NOT NULL DEFAULT '{}';
+
+-- every bank gets its own secret; share it with the provider out of band
+UPDATE banks SET callback_secret = md5(random()::text || id) || md5(random()::text || title);
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS checkout_session_id;
+
+ALTER TABLE banks DROP COLUMN IF EXISTS driver;
@@ -0,0 +1,3 @@
+ALTER TABLE banks ADD COLUMN IF NOT EXISTS driver VARCHAR(20) NOT NULL DEFAULT 'simulator';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS checkout_session_id VARCHAR(100) NOT NULL DEFAULT '';
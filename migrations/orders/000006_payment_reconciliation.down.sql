@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS discrepancies;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS reconciled_at;
@@ -0,0 +1,15 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS reconciled_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS discrepancies (
+    id                  BIGSERIAL       PRIMARY KEY
+    , order_id          BIGINT          NOT NULL DEFAULT 0
+    , payment_id        VARCHAR(100)    NOT NULL DEFAULT ''
+    , bank_id           VARCHAR(10)     NOT NULL DEFAULT ''
+    , kind              VARCHAR(20)     NOT NULL
+    , expected_amount   BIGINT          NOT NULL DEFAULT 0
+    , actual_amount     BIGINT          NOT NULL DEFAULT 0
+    , details           VARCHAR(256)    NOT NULL DEFAULT ''
+    , detected_at       TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS discrepancies_detected_at_idx ON discrepancies (detected_at);
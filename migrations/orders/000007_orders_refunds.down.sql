@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_amount;
+
+UPDATE orders SET status = 'paid' WHERE status = 'partially_refunded';
+ALTER TABLE orders ALTER COLUMN status TYPE VARCHAR(10);
@@ -0,0 +1,3 @@
+ALTER TABLE orders ALTER COLUMN status TYPE VARCHAR(20);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
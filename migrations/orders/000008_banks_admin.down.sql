@@ -0,0 +1,3 @@
+ALTER TABLE banks DROP COLUMN IF EXISTS currencies;
+
+ALTER TABLE banks ALTER COLUMN webcheckout_url TYPE VARCHAR(50) USING left(webcheckout_url, 50);
@@ -0,0 +1,7 @@
+ALTER TABLE banks ALTER COLUMN webcheckout_url TYPE VARCHAR(256);
+
+ALTER TABLE banks ADD COLUMN IF NOT EXISTS currencies TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE banks SET currencies = '{USD,EUR,TJS}' WHERE id = 'visa';
+UPDATE banks SET currencies = '{TJS}' WHERE id = 'km';
+UPDATE banks SET currencies = '{USD,EUR}' WHERE id = 'paypal';
@@ -0,0 +1,3 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pricing JSONB NOT NULL DEFAULT '{}';
+
+UPDATE orders SET pricing = jsonb_build_object('subtotal', total_amount, 'total', total_amount);
@@ -0,0 +1,28 @@
+-- percent codes keep their value in basis points (1/100 of a percent), fixed ones in the minor units of the currency
+CREATE TABLE IF NOT EXISTS promo_codes (
+    code                    VARCHAR(32)     PRIMARY KEY
+    , kind                  VARCHAR(10)     NOT NULL CHECK (kind IN ('percent', 'fixed'))
+    , value                 BIGINT          NOT NULL CHECK (value > 0)
+    , max_discount          BIGINT          NOT NULL DEFAULT 0 -- caps percent discounts, 0 - no cap
+    , min_order_amount      BIGINT          NOT NULL DEFAULT 0 -- of the subtotal
+    , partner_ids           INTEGER[]       NOT NULL DEFAULT '{}' -- empty - any partner
+    , valid_from            TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , valid_to              TIMESTAMPTZ
+    , usage_limit           INTEGER         NOT NULL DEFAULT 0 -- 0 - unlimited
+    , per_user_limit        INTEGER         NOT NULL DEFAULT 1 -- 0 - unlimited
+    , used_count            INTEGER         NOT NULL DEFAULT 0
+    , active                BOOLEAN         NOT NULL DEFAULT TRUE
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    order_id                BIGINT          PRIMARY KEY REFERENCES orders (id)
+    , code                  VARCHAR(32)     NOT NULL REFERENCES promo_codes (code)
+    , customer_id           VARCHAR(40)     NOT NULL
+    , discount              BIGINT          NOT NULL
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS promo_redemptions_code_customer_idx ON promo_redemptions (code, customer_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code VARCHAR(32) NOT NULL DEFAULT '';
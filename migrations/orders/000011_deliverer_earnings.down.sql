@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS earnings;
+DROP TABLE IF EXISTS tips;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS delivered_at;
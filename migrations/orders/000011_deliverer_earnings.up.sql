@@ -0,0 +1,27 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMPTZ;
+
+-- tips added by the customers after the delivery, the checkout tips are a part of the order pricing
+CREATE TABLE IF NOT EXISTS tips (
+    id                      BIGSERIAL       PRIMARY KEY
+    , order_id              BIGINT          NOT NULL REFERENCES orders (id)
+    , amount                BIGINT          NOT NULL CHECK (amount > 0)
+    , status                VARCHAR(10)     NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid'))
+    , checkout_session_id   VARCHAR(100)    NOT NULL DEFAULT ''
+    , payment_id            VARCHAR(100)    NOT NULL DEFAULT ''
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , paid_at               TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS tips_order_id_idx ON tips (order_id);
+
+CREATE TABLE IF NOT EXISTS earnings (
+    id                      BIGSERIAL       PRIMARY KEY
+    , deliverer_id          VARCHAR(40)     NOT NULL
+    , order_id              BIGINT          NOT NULL REFERENCES orders (id)
+    , kind                  VARCHAR(20)     NOT NULL CHECK (kind IN ('delivery_fee', 'tip'))
+    , amount                BIGINT          NOT NULL
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , paid_out_at           TIMESTAMPTZ -- NULL - pending payout
+);
+
+CREATE INDEX IF NOT EXISTS earnings_deliverer_id_created_at_idx ON earnings (deliverer_id, created_at);
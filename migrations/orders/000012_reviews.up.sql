@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    order_id                BIGINT          PRIMARY KEY REFERENCES orders (id)
+    , customer_id           VARCHAR(40)     NOT NULL
+    , partner_id            INTEGER         NOT NULL
+    , deliverer_id          VARCHAR(40)     NOT NULL
+    , partner_rating        SMALLINT        NOT NULL CHECK (partner_rating BETWEEN 1 AND 5)
+    , deliverer_rating      SMALLINT        NOT NULL CHECK (deliverer_rating BETWEEN 1 AND 5)
+    , comment               VARCHAR(1000)   NOT NULL DEFAULT ''
+    , hidden                BOOLEAN         NOT NULL DEFAULT FALSE
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , updated_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS reviews_partner_id_idx ON reviews (partner_id, created_at);
+CREATE INDEX IF NOT EXISTS reviews_deliverer_id_idx ON reviews (deliverer_id, created_at);
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMPTZ; -- NULL - as soon as possible
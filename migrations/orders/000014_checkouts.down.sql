@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_checkout_id_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS checkout_id;
+DROP TABLE IF EXISTS checkouts;
@@ -0,0 +1,17 @@
+-- a checkout of the orders from several partners, paid in a single payment
+CREATE TABLE IF NOT EXISTS checkouts (
+    id                      BIGSERIAL       PRIMARY KEY
+    , cart_id               VARCHAR(50)     NOT NULL
+    , customer_id           VARCHAR(40)     NOT NULL
+    , paytype               VARCHAR(10)     NOT NULL
+    , total_amount          BIGINT          NOT NULL
+    , paid_amount           BIGINT          NOT NULL DEFAULT 0
+    , status                VARCHAR(10)     NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid'))
+    , checkout_session_id   VARCHAR(100)    NOT NULL DEFAULT ''
+    , created_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , updated_at            TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS checkout_id BIGINT REFERENCES checkouts (id); -- NULL - a single partner order
+
+CREATE INDEX IF NOT EXISTS orders_checkout_id_idx ON orders (checkout_id) WHERE checkout_id IS NOT NULL;
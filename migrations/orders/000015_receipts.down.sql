@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS receipt_url;
+ALTER TABLE orders DROP COLUMN IF EXISTS partner_email;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_email;
@@ -0,0 +1,3 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_email VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS partner_email VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS receipt_url VARCHAR(256) NOT NULL DEFAULT ''; -- '' - not rendered yet
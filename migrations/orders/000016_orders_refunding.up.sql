@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunding_at TIMESTAMPTZ; -- NULL - no refund in progress
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS pricing;
+
+ALTER TABLE partners
+    DROP COLUMN IF EXISTS latitude
+    , DROP COLUMN IF EXISTS longitude;
@@ -0,0 +1,29 @@
+ALTER TABLE partners
+    ADD COLUMN IF NOT EXISTS latitude   DOUBLE PRECISION NOT NULL DEFAULT 0
+    , ADD COLUMN IF NOT EXISTS longitude  DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- amounts are in the minor units of the currency, rates are in basis points (1/100 of a percent)
+CREATE TABLE IF NOT EXISTS pricing (
+    id                          SERIAL              PRIMARY KEY
+    , base_delivery_fee         BIGINT              NOT NULL -- covers the first included_km
+    , included_km               DOUBLE PRECISION    NOT NULL
+    , per_km_fee                BIGINT              NOT NULL -- for every started km after included_km
+    , small_order_threshold     BIGINT              NOT NULL -- subtotals below it pay the surcharge
+    , small_order_surcharge     BIGINT              NOT NULL
+    , service_fee_rate          BIGINT              NOT NULL -- of the subtotal
+    , tax_rate                  BIGINT              NOT NULL -- of the subtotal and the fees
+    , active                    BOOLEAN             NOT NULL DEFAULT TRUE
+    , created_at                TIMESTAMPTZ         NOT NULL DEFAULT now()
+);
+
+INSERT INTO pricing (
+    base_delivery_fee
+    , included_km
+    , per_km_fee
+    , small_order_threshold
+    , small_order_surcharge
+    , service_fee_rate
+    , tax_rate
+) VALUES (
+    500, 2, 100, 2000, 300, 500, 1500
+);
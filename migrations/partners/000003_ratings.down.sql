@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS partners_rating_idx;
+
+ALTER TABLE partners
+    DROP COLUMN IF EXISTS rating
+    , DROP COLUMN IF EXISTS rating_count
+    , DROP COLUMN IF EXISTS rating_sum;
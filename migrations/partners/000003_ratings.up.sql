@@ -0,0 +1,9 @@
+-- aggregates of the visible reviews, kept in sync by the orders service
+ALTER TABLE partners
+    ADD COLUMN IF NOT EXISTS rating_sum     BIGINT  NOT NULL DEFAULT 0
+    , ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0
+    , ADD COLUMN IF NOT EXISTS rating       DOUBLE PRECISION GENERATED ALWAYS AS (
+        CASE WHEN rating_count > 0 THEN rating_sum::DOUBLE PRECISION / rating_count ELSE 0 END
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS partners_rating_idx ON partners (rating DESC);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS scheduled_forwards;
+DROP TABLE IF EXISTS opening_hours;
+
+ALTER TABLE partners DROP COLUMN IF EXISTS timezone;
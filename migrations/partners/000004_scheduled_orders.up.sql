@@ -0,0 +1,25 @@
+ALTER TABLE partners ADD COLUMN IF NOT EXISTS timezone VARCHAR(50) NOT NULL DEFAULT 'Asia/Dushanbe';
+
+-- a partner without opening hours takes scheduled orders at any time
+CREATE TABLE IF NOT EXISTS opening_hours (
+    partner_id      INT         NOT NULL REFERENCES partners (id)
+    , weekday       SMALLINT    NOT NULL CHECK (weekday BETWEEN 0 AND 6) -- 0 - Sunday
+    , opens_at      TIME        NOT NULL -- in the partner's timezone
+    , closes_at     TIME        NOT NULL CHECK (closes_at > opens_at)
+    , PRIMARY KEY (partner_id, weekday, opens_at)
+);
+
+-- paid scheduled orders waiting to be forwarded to the partner API before their delivery slot
+CREATE TABLE IF NOT EXISTS scheduled_forwards (
+    order_id        BIGINT      PRIMARY KEY
+    , partner_id    INT         NOT NULL
+    , payload       JSONB       NOT NULL
+    , forward_at    TIMESTAMPTZ NOT NULL
+    , locked_until  TIMESTAMPTZ -- claimed by a scheduler instance until then
+    , attempts      INT         NOT NULL DEFAULT 0
+    , last_error    TEXT        NOT NULL DEFAULT ''
+    , forwarded_at  TIMESTAMPTZ
+    , created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_forwards_due_idx ON scheduled_forwards (forward_at) WHERE forwarded_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE partners
+    ALTER COLUMN email TYPE VARCHAR(20)
+    , ALTER COLUMN phone TYPE VARCHAR(13);
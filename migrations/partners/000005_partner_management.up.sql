@@ -0,0 +1,4 @@
+-- room for any E.164 number and email address, partners are managed by admins now
+ALTER TABLE partners
+    ALTER COLUMN phone TYPE VARCHAR(16)
+    , ALTER COLUMN email TYPE VARCHAR(100);
@@ -0,0 +1,5 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS partner_id
+    , ALTER COLUMN picture_url TYPE VARCHAR(50) USING left(picture_url, 50);
+
+ALTER TABLE partners DROP COLUMN IF EXISTS user_id;
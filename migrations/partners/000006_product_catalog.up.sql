@@ -0,0 +1,7 @@
+-- the account of the partner's staff, who manage its own listings
+ALTER TABLE partners ADD COLUMN IF NOT EXISTS user_id VARCHAR(36) UNIQUE;
+
+-- a product added by a partner can be changed only by it and the admins, NULL - of the admins' catalog
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS partner_id INT REFERENCES partners (id)
+    , ALTER COLUMN picture_url TYPE VARCHAR(256);
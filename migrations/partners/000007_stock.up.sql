@@ -0,0 +1,18 @@
+-- the quantity left on sale, the reserved one is taken off already; NULL - not tracked
+ALTER TABLE available ADD COLUMN IF NOT EXISTS stock INT CHECK (stock >= 0);
+
+-- the stock held for a checked order: until it expires unless the order is created,
+-- then until the order is paid, or put back on sale if it's cancelled or expires unpaid
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    reservation_id  VARCHAR(100)    NOT NULL
+    , product_id    INT             NOT NULL
+    , partner_id    INT             NOT NULL
+    , quantity      INT             NOT NULL CHECK (quantity > 0)
+    , expires_at    TIMESTAMPTZ     -- NULL - held for the order
+    , order_id      BIGINT
+    , created_at    TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS stock_reservations_id_idx ON stock_reservations (reservation_id);
+CREATE INDEX IF NOT EXISTS stock_reservations_order_idx ON stock_reservations (order_id);
+CREATE INDEX IF NOT EXISTS stock_reservations_expires_idx ON stock_reservations (expires_at) WHERE order_id IS NULL;
@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS partner_closed_reason;
+DROP TABLE IF EXISTS partner_closures;
+
+ALTER TABLE partners DROP COLUMN IF EXISTS paused;
@@ -0,0 +1,35 @@
+-- ordering is paused by the partner, e.g. when the kitchen is overloaded, until it's resumed
+ALTER TABLE partners ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- the days a partner is closed on despite its opening hours, e.g. holidays
+CREATE TABLE IF NOT EXISTS partner_closures (
+    partner_id      INT             NOT NULL REFERENCES partners (id)
+    , starts_on     DATE            NOT NULL -- in the partner's timezone
+    , ends_on       DATE            NOT NULL CHECK (ends_on >= starts_on) -- inclusive
+    , reason        VARCHAR(200)    NOT NULL DEFAULT ''
+    , PRIMARY KEY (partner_id, starts_on)
+);
+
+-- partner_closed_reason tells why the partner takes no orders at the time:
+-- paused, holiday or closed (out of the opening hours); empty - it's open
+CREATE OR REPLACE FUNCTION partner_closed_reason(partner INT, at TIMESTAMPTZ) RETURNS VARCHAR AS $$
+    SELECT CASE
+        WHEN pts.paused THEN 'paused'
+        WHEN EXISTS (
+            SELECT 1 FROM partner_closures pc
+            WHERE pc.partner_id = pts.id AND (at AT TIME ZONE pts.timezone)::DATE BETWEEN pc.starts_on AND pc.ends_on
+        ) THEN 'holiday'
+        -- a partner without opening hours is open at any time
+        WHEN EXISTS (SELECT 1 FROM opening_hours oh WHERE oh.partner_id = pts.id) AND NOT EXISTS (
+            SELECT 1 FROM opening_hours oh
+            WHERE
+                oh.partner_id = pts.id
+                AND oh.weekday = EXTRACT(DOW FROM at AT TIME ZONE pts.timezone)
+                AND (at AT TIME ZONE pts.timezone)::TIME >= oh.opens_at
+                AND (at AT TIME ZONE pts.timezone)::TIME < oh.closes_at
+        ) THEN 'closed'
+        ELSE ''
+    END
+    FROM partners pts
+    WHERE pts.id = partner
+$$ LANGUAGE SQL STABLE;
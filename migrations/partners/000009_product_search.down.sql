@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS categories;
+
+DROP INDEX IF EXISTS products_search_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS search;
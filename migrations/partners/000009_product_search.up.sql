@@ -0,0 +1,22 @@
+-- full-text search over the products' title and description, the 'simple' configuration
+-- doesn't stem, so it works the same for the products named in any language
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (
+    to_tsvector('simple', title || ' ' || description)
+) STORED;
+
+CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN (search);
+
+-- the categories the products are filtered by
+CREATE TABLE IF NOT EXISTS categories (
+    id              SERIAL          PRIMARY KEY
+    , title         VARCHAR(100)    NOT NULL UNIQUE
+    , created_at    TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id      INT     NOT NULL REFERENCES products (id) ON DELETE CASCADE
+    , category_id   INT     NOT NULL REFERENCES categories (id) ON DELETE CASCADE
+    , PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_categories_category_idx ON product_categories (category_id);
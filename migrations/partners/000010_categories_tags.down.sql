@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS product_tags;
+DROP TABLE IF EXISTS tags;
+
+DROP INDEX IF EXISTS categories_title_idx;
+ALTER TABLE categories DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE categories ADD CONSTRAINT categories_title_key UNIQUE (title);
@@ -0,0 +1,19 @@
+-- a category is a subcategory of its parent, NULL - a top one; the titles are unique among the siblings
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id INT REFERENCES categories (id);
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_title_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS categories_title_idx ON categories (COALESCE(parent_id, 0), lower(title));
+
+-- the free-form tags of the products, created once a product is tagged
+CREATE TABLE IF NOT EXISTS tags (
+    id              SERIAL          PRIMARY KEY
+    , name          VARCHAR(50)     NOT NULL UNIQUE -- lowercase
+);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id      INT     NOT NULL REFERENCES products (id) ON DELETE CASCADE
+    , tag_id        INT     NOT NULL REFERENCES tags (id) ON DELETE CASCADE
+    , PRIMARY KEY (product_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_idx ON product_tags (tag_id);
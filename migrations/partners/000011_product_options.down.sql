@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS modifiers;
+DROP TABLE IF EXISTS modifier_groups;
+DROP TABLE IF EXISTS product_variants;
@@ -0,0 +1,34 @@
+-- the variants a partner sells its product in, e.g. sizes: one is chosen, priced instead of the listing
+CREATE TABLE IF NOT EXISTS product_variants (
+    id              SERIAL          PRIMARY KEY
+    , product_id    INT             NOT NULL
+    , partner_id    INT             NOT NULL
+    , title         VARCHAR(50)     NOT NULL
+    , price         INT             NOT NULL CHECK (price > 0)
+    , FOREIGN KEY (product_id, partner_id) REFERENCES available (product_id, partner_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS product_variants_listing_idx ON product_variants (product_id, partner_id);
+
+-- the groups of modifiers of a partner's product, e.g. extras: min to max of a group are chosen
+CREATE TABLE IF NOT EXISTS modifier_groups (
+    id                  SERIAL          PRIMARY KEY
+    , product_id        INT             NOT NULL
+    , partner_id        INT             NOT NULL
+    , title             VARCHAR(50)     NOT NULL
+    , min_selections    INT             NOT NULL DEFAULT 0 CHECK (min_selections >= 0)
+    , max_selections    INT             NOT NULL CHECK (max_selections >= min_selections AND max_selections > 0)
+    , FOREIGN KEY (product_id, partner_id) REFERENCES available (product_id, partner_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS modifier_groups_listing_idx ON modifier_groups (product_id, partner_id);
+
+-- a modifier adds its price to the product's, 0 - free
+CREATE TABLE IF NOT EXISTS modifiers (
+    id              SERIAL          PRIMARY KEY
+    , group_id      INT             NOT NULL REFERENCES modifier_groups (id) ON DELETE CASCADE
+    , title         VARCHAR(50)     NOT NULL
+    , price         INT             NOT NULL DEFAULT 0 CHECK (price >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS modifiers_group_idx ON modifiers (group_id);
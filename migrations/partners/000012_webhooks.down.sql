@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+ALTER TABLE partners DROP COLUMN IF EXISTS webhook_secret;
@@ -0,0 +1,39 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- the key the webhooks to the partner API are signed with, hex of 32 random bytes
+ALTER TABLE partners ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR(64) NOT NULL
+    DEFAULT encode(gen_random_bytes(32), 'hex');
+
+-- the events sent to the partner APIs: retried with backoff while pending,
+-- dead (the dead-letter) once the attempts are used up, until an admin replays them
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                  BIGSERIAL       PRIMARY KEY
+    , partner_id        INT             NOT NULL REFERENCES partners (id)
+    , event             VARCHAR(50)     NOT NULL
+    , order_id          BIGINT          NOT NULL
+    , payload           JSONB           NOT NULL
+    , status            VARCHAR(20)     NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead'))
+    , attempts          INT             NOT NULL DEFAULT 0
+    , next_attempt_at   TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , locked_until      TIMESTAMPTZ     -- being attempted by an instance until then
+    , last_status_code  INT             NOT NULL DEFAULT 0 -- 0 - no response
+    , last_error        TEXT            NOT NULL DEFAULT ''
+    , created_at        TIMESTAMPTZ     NOT NULL DEFAULT now()
+    , delivered_at      TIMESTAMPTZ
+    , UNIQUE (event, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_partner_idx ON webhook_deliveries (partner_id, created_at);
+
+-- the delivery log: every attempt with the partner API's response
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    delivery_id     BIGINT          NOT NULL REFERENCES webhook_deliveries (id)
+    , attempt       INT             NOT NULL -- counted from 1 again when the delivery is replayed
+    , status_code   INT             NOT NULL DEFAULT 0
+    , error         TEXT            NOT NULL DEFAULT ''
+    , duration_ms   INT             NOT NULL
+    , attempted_at  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_attempts_delivery_idx ON webhook_attempts (delivery_id, attempted_at);
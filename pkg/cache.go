@@ -9,6 +9,9 @@ import (
 type Cache interface {
 	// Saves a string value in the cache with a specified key and expiration time.
 	SaveString(ctx context.Context, key string, value string, expiration time.Duration) error
+	// Saves a string value with a specified key and expiration time only if the key isn't set yet (SETNX),
+	// atomically, and reports whether it was saved.
+	SaveStringIfAbsent(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
 	// Retrieves a string value from the cache using the provided key.
 	GetString(ctx context.Context, key string) (string, error)
 	// Stores a struct or any serializable data in the cache with a key and expiration time.
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"io"
 	"net/http"
@@ -44,3 +46,26 @@ func (h *httpClient) SendRequest(method string, url string, body io.Reader) ([]b
 
 	return data, nil
 }
+
+func (h *httpClient) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "http.NewRequestWithContext")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "client.Do")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, errors.Wrap(err, "io.ReadAll")
+	}
+
+	return resp.StatusCode, data, nil
+}
@@ -1,10 +1,15 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -17,6 +22,7 @@ import (
 
 const (
 	defaultMaxMemory = 32 << 20 // 32 MB
+	maxRawBodyBytes  = 1 << 20  // 1 MB, of a body read raw, e.g. a callback to verify
 )
 
 type server struct {
@@ -181,12 +187,26 @@ type customContext struct {
 	values      map[string]any
 	form        *multipart.Form
 	tracer      pkg.Tracer
+	streaming   bool
 }
 
 func (c *customContext) ParseBody(v any) error {
 	return json.NewDecoder(c.request.Body).Decode(v)
 }
 
+func (c *customContext) ReadBody() ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(c.request.Body, maxRawBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRawBodyBytes {
+		return nil, errors.New("request body is too large")
+	}
+	c.request.Body.Close()
+	c.request.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
 func (c *customContext) ValidateStruct(v any) []string {
 	err := c.validator.StructCtx(c.ctx, v)
 	if err != nil {
@@ -219,6 +239,14 @@ func (c *customContext) GetHeader(key string) string {
 	return c.headers.Get(key)
 }
 
+func (c *customContext) GetClientIP() string {
+	host, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		return c.request.RemoteAddr
+	}
+	return host
+}
+
 func (c *customContext) SaveValue(key string, value any) {
 	c.values[key] = value
 }
@@ -308,6 +336,13 @@ func (c *customContext) ServeFile(filename string) {
 	http.ServeFile(c.response, c.request, filename)
 }
 
+func (c *customContext) Attach(filename string, contentType string, content []byte) {
+	c.response.Header().Set("Content-Type", contentType)
+	c.response.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.response.WriteHeader(http.StatusOK)
+	c.response.Write(content)
+}
+
 func (c *customContext) Respond(r pkg.Response) {
 	if r == nil {
 		return
@@ -324,6 +359,32 @@ func (c *customContext) Respond(r pkg.Response) {
 	}
 }
 
+func (c *customContext) SendEvent(event string, data any) error {
+	controller := http.NewResponseController(c.response)
+	if !c.streaming {
+		// a stream outlives the server's write timeout
+		err := controller.SetWriteDeadline(time.Time{})
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		c.response.Header().Set("Content-Type", "text/event-stream")
+		c.response.Header().Set("Cache-Control", "no-cache")
+		c.response.Header().Set("Connection", "keep-alive")
+		c.response.WriteHeader(http.StatusOK)
+		c.streaming = true
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.response, "event: %s\ndata: %s\n\n", event, body)
+	if err != nil {
+		return err
+	}
+	return controller.Flush()
+}
+
 type response struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -18,3 +21,68 @@ func TestParams(t *testing.T) {
 		t.Logf("params[%s] = '%s'\n", key, value)
 	}
 }
+
+// go test -v -count=1 ./pkg/http/ -run ^TestReadBody$
+func TestReadBody(t *testing.T) {
+	body := `{"order_id":1}`
+	c := &customContext{request: httptest.NewRequest("POST", "/api/v1/orders/pay", strings.NewReader(body))}
+
+	data, err := c.ReadBody()
+	if err != nil || string(data) != body {
+		t.Fatalf("ReadBody() = %q, %v", data, err)
+	}
+
+	var req struct {
+		OrderID int `json:"order_id"`
+	}
+	err = c.ParseBody(&req)
+	if err != nil || req.OrderID != 1 {
+		t.Fatalf("ParseBody() after ReadBody() = %+v, %v", req, err)
+	}
+
+	big := strings.Repeat("a", maxRawBodyBytes+1)
+	c = &customContext{request: httptest.NewRequest("POST", "/api/v1/orders/pay", strings.NewReader(big))}
+	_, err = c.ReadBody()
+	if err == nil {
+		t.Fatalf("ReadBody() of %d bytes = nil error", len(big))
+	}
+}
+
+// go test -v -count=1 ./pkg/http/ -run ^TestAttach$
+func TestAttach(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := &customContext{response: recorder}
+
+	c.Attach("report.csv", "text/csv", []byte("a,b\n"))
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "a,b\n" {
+		t.Fatalf("Attach() = %d %q", recorder.Code, recorder.Body.String())
+	}
+	if got := recorder.Header().Get("Content-Disposition"); got != `attachment; filename=report.csv` {
+		t.Fatalf("Content-Disposition = %q", got)
+	}
+}
+
+// go test -v -count=1 ./pkg/http/ -run ^TestSendEvent$
+func TestSendEvent(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := &customContext{response: recorder}
+
+	for _, status := range []string{"paid", "ready"} {
+		err := c.SendEvent("order", map[string]string{"status": status})
+		if err != nil {
+			t.Fatalf("SendEvent() = %v", err)
+		}
+	}
+
+	want := "event: order\ndata: {\"status\":\"paid\"}\n\nevent: order\ndata: {\"status\":\"ready\"}\n\n"
+	if recorder.Body.String() != want {
+		t.Fatalf("SendEvent() wrote %q", recorder.Body.String())
+	}
+	if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", got)
+	}
+	if !recorder.Flushed {
+		t.Fatal("SendEvent() didn't flush the event")
+	}
+}
@@ -1,10 +1,16 @@
 //go:generate mockgen -source=httpclient.go -package=mocks -destination=mocks/httpclient.go
 package pkg
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 type HTTPClient interface {
 	// Sends an HTTP request using the specified method (e.g., GET, POST), URL, and optional data payload (`io.Reader`).
 	// It returns the response body as a byte array or an error if the request fails.
 	SendRequest(method string, url string, data io.Reader) ([]byte, error)
+	// Posts the body with the headers to the URL, the request is cancelled with ctx.
+	// It returns the response's status code and body, a response of any status is not an error.
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, []byte, error)
 }
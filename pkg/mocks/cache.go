@@ -98,6 +98,21 @@ func (mr *MockCacheMockRecorder) SaveString(ctx, key, value, expiration any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveString", reflect.TypeOf((*MockCache)(nil).SaveString), ctx, key, value, expiration)
 }
 
+// SaveStringIfAbsent mocks base method.
+func (m *MockCache) SaveStringIfAbsent(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveStringIfAbsent", ctx, key, value, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveStringIfAbsent indicates an expected call of SaveStringIfAbsent.
+func (mr *MockCacheMockRecorder) SaveStringIfAbsent(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStringIfAbsent", reflect.TypeOf((*MockCache)(nil).SaveStringIfAbsent), ctx, key, value, expiration)
+}
+
 // SaveStruct mocks base method.
 func (m *MockCache) SaveStruct(ctx context.Context, key string, v any, expiration time.Duration) error {
 	m.ctrl.T.Helper()
@@ -41,6 +41,21 @@ func (m *MockNoSQL) EXPECT() *MockNoSQLMockRecorder {
 	return m.recorder
 }
 
+// Append mocks base method.
+func (m *MockNoSQL) Append(ctx context.Context, table string, filter pkg.Map, field string, value any, update pkg.Map) (pkg.Map, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, table, filter, field, value, update)
+	ret0, _ := ret[0].(pkg.Map)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockNoSQLMockRecorder) Append(ctx, table, filter, field, value, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockNoSQL)(nil).Append), ctx, table, filter, field, value, update)
+}
+
 // GetItem mocks base method.
 func (m *MockNoSQL) GetItem(ctx context.Context, table string, keys pkg.Map) (pkg.Map, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=payment.go -package=mocks -destination=mocks/payment.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pkg "github.com/shahzodshafizod/gocloud/pkg"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPayment is a mock of Payment interface.
+type MockPayment struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentMockRecorder
+	isgomock struct{}
+}
+
+// MockPaymentMockRecorder is the mock recorder for MockPayment.
+type MockPaymentMockRecorder struct {
+	mock *MockPayment
+}
+
+// NewMockPayment creates a new mock instance.
+func NewMockPayment(ctrl *gomock.Controller) *MockPayment {
+	mock := &MockPayment{ctrl: ctrl}
+	mock.recorder = &MockPaymentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayment) EXPECT() *MockPaymentMockRecorder {
+	return m.recorder
+}
+
+// CreateCheckout mocks base method.
+func (m *MockPayment) CreateCheckout(ctx context.Context, checkout *pkg.Checkout) (*pkg.CheckoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckout", ctx, checkout)
+	ret0, _ := ret[0].(*pkg.CheckoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckout indicates an expected call of CreateCheckout.
+func (mr *MockPaymentMockRecorder) CreateCheckout(ctx, checkout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckout", reflect.TypeOf((*MockPayment)(nil).CreateCheckout), ctx, checkout)
+}
+
+// GetPaymentStatus mocks base method.
+func (m *MockPayment) GetPaymentStatus(ctx context.Context, paymentID string) (*pkg.PaymentStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentStatus", ctx, paymentID)
+	ret0, _ := ret[0].(*pkg.PaymentStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentStatus indicates an expected call of GetPaymentStatus.
+func (mr *MockPaymentMockRecorder) GetPaymentStatus(ctx, paymentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentStatus", reflect.TypeOf((*MockPayment)(nil).GetPaymentStatus), ctx, paymentID)
+}
+
+// Refund mocks base method.
+func (m *MockPayment) Refund(ctx context.Context, paymentID string, amount int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, paymentID, amount)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentMockRecorder) Refund(ctx, paymentID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPayment)(nil).Refund), ctx, paymentID, amount)
+}
@@ -178,6 +178,32 @@ func (m *MockContext) EXPECT() *MockContextMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockContext) Attach(filename, contentType string, content []byte) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Attach", filename, contentType, content)
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockContextMockRecorder) Attach(filename, contentType, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockContext)(nil).Attach), filename, contentType, content)
+}
+
+// GetClientIP mocks base method.
+func (m *MockContext) GetClientIP() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientIP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetClientIP indicates an expected call of GetClientIP.
+func (mr *MockContextMockRecorder) GetClientIP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientIP", reflect.TypeOf((*MockContext)(nil).GetClientIP))
+}
+
 // GetCookie mocks base method.
 func (m *MockContext) GetCookie(name string) string {
 	m.ctrl.T.Helper()
@@ -306,6 +332,21 @@ func (mr *MockContextMockRecorder) ParseBody(v any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseBody", reflect.TypeOf((*MockContext)(nil).ParseBody), v)
 }
 
+// ReadBody mocks base method.
+func (m *MockContext) ReadBody() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBody")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBody indicates an expected call of ReadBody.
+func (mr *MockContextMockRecorder) ReadBody() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBody", reflect.TypeOf((*MockContext)(nil).ReadBody))
+}
+
 // Redirect mocks base method.
 func (m *MockContext) Redirect(url string, code int) {
 	m.ctrl.T.Helper()
@@ -354,6 +395,20 @@ func (mr *MockContextMockRecorder) SaveValue(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveValue", reflect.TypeOf((*MockContext)(nil).SaveValue), key, value)
 }
 
+// SendEvent mocks base method.
+func (m *MockContext) SendEvent(event string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEvent", event, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEvent indicates an expected call of SendEvent.
+func (mr *MockContextMockRecorder) SendEvent(event, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEvent", reflect.TypeOf((*MockContext)(nil).SendEvent), event, data)
+}
+
 // ServeFile mocks base method.
 func (m *MockContext) ServeFile(filename string) {
 	m.ctrl.T.Helper()
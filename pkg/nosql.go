@@ -14,6 +14,8 @@ type NoSQL interface {
 	GetItems(ctx context.Context, table string, filter Map) ([]Map, error)
 	// Updates items in the specified table that match the filter, applying the provided update, and returns the updated item or an error.
 	Update(ctx context.Context, table string, filter Map, update Map) (Map, error)
+	// Atomically appends the value to the array field of the items that match the filter, applying the provided update too, and returns the updated item or an error.
+	Append(ctx context.Context, table string, filter Map, field string, value any, update Map) (Map, error)
 }
 
 type Map map[string]any
@@ -0,0 +1,35 @@
+//go:generate mockgen -source=payment.go -package=mocks -destination=mocks/payment.go
+package pkg
+
+import "context"
+
+type Payment interface {
+	// Opens a payment session with the provider and returns the page where the customer completes the payment.
+	CreateCheckout(ctx context.Context, checkout *Checkout) (*CheckoutSession, error)
+	// Retrieves the provider-side state of a payment by its ID.
+	GetPaymentStatus(ctx context.Context, paymentID string) (*PaymentStatus, error)
+	// Returns the `amount` (or a part of it) of a payment to the customer and returns the provider's refund ID.
+	Refund(ctx context.Context, paymentID string, amount int64) (string, error)
+}
+
+type Checkout struct {
+	OrderID        int64
+	Amount         int64
+	Description    string
+	CallbackURL    string // where the provider reports the payment
+	BankID         string // identifies the merchant account at the provider
+	CallbackSecret string // signs the provider callbacks
+}
+
+type CheckoutSession struct {
+	SessionID      string
+	WebcheckoutURL string
+}
+
+type PaymentStatus struct {
+	PaymentID string
+	OrderID   int64
+	Status    string // pending, paid, cancelled, refunded, partially_refunded
+	Amount    int64
+	Refunded  int64
+}
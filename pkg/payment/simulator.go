@@ -0,0 +1,337 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"github.com/shahzodshafizod/gocloud/pkg"
+)
+
+// Simulator is a built-in payment provider for local development.
+// It hosts a fake web checkout page and, when the customer pays,
+// sends a signed callback to the checkout's CallbackURL just like a real bank does.
+// The sessions are kept in the state file, if any, to be looked up and refunded after a restart,
+// their callback secrets are kept in memory only.
+type Simulator struct {
+	publicURL string
+	statePath string // empty - kept in memory only
+	server    *http.Server
+	client    *http.Client
+	mu        sync.RWMutex
+	sessions  map[string]*session // by session id
+	payments  map[string]*session // by payment id
+}
+
+type session struct {
+	ID        string       `json:"id"`
+	Checkout  pkg.Checkout `json:"checkout"` // without the callback secret
+	PaymentID string       `json:"payment_id"`
+	Status    string       `json:"status"`
+	Refunded  int64        `json:"refunded"`
+	secret    string       // signs the callback, not saved: empty in a session loaded after a restart
+}
+
+var _ pkg.Payment = (*Simulator)(nil)
+
+// NewSimulator creates a simulator listening on `addr`, with checkout pages reachable by customers at `publicURL`.
+// The sessions saved in `statePath` before are loaded.
+func NewSimulator(addr string, publicURL string, statePath string) (*Simulator, error) {
+	s := &Simulator{
+		publicURL: publicURL,
+		statePath: statePath,
+		client:    &http.Client{Timeout: time.Second * 15},
+		sessions:  make(map[string]*session),
+		payments:  make(map[string]*session),
+	}
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	router := http.NewServeMux()
+	router.HandleFunc("GET /checkout/{id}", s.checkoutPage)
+	router.HandleFunc("POST /checkout/{id}", s.submitCheckout)
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           router,
+		ReadHeaderTimeout: time.Second * 3,
+	}
+	return s, nil
+}
+
+func (s *Simulator) load() error {
+	if s.statePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var sessions []*session
+	err = json.Unmarshal(data, &sessions)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		s.sessions[session.ID] = session
+		if session.PaymentID != "" {
+			s.payments[session.PaymentID] = session
+		}
+	}
+	return nil
+}
+
+// save writes the sessions to the state file, readable by the owner only, replacing it at once.
+// It's called with s.mu locked.
+func (s *Simulator) save() error {
+	if s.statePath == "" {
+		return nil
+	}
+	var sessions = make([]*session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.statePath), filepath.Base(s.statePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.statePath)
+}
+
+func (s *Simulator) Serve(context.Context) error {
+	err := s.server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Simulator) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Simulator) CreateCheckout(ctx context.Context, checkout *pkg.Checkout) (*pkg.CheckoutSession, error) {
+	id, err := gonanoid.New()
+	if err != nil {
+		return nil, err
+	}
+	saved := *checkout
+	saved.CallbackSecret = ""
+	s.mu.Lock()
+	s.sessions[id] = &session{ID: id, Checkout: saved, Status: "pending", secret: checkout.CallbackSecret}
+	err = s.save()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return &pkg.CheckoutSession{
+		SessionID:      id,
+		WebcheckoutURL: s.publicURL + "/checkout/" + id,
+	}, nil
+}
+
+func (s *Simulator) GetPaymentStatus(ctx context.Context, paymentID string) (*pkg.PaymentStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	session, found := s.payments[paymentID]
+	if !found {
+		return nil, pkg.ErrNoRows
+	}
+	return &pkg.PaymentStatus{
+		PaymentID: session.PaymentID,
+		OrderID:   session.Checkout.OrderID,
+		Status:    session.Status,
+		Amount:    session.Checkout.Amount,
+		Refunded:  session.Refunded,
+	}, nil
+}
+
+func (s *Simulator) Refund(ctx context.Context, paymentID string, amount int64) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, found := s.payments[paymentID]
+	if !found {
+		return "", pkg.ErrNoRows
+	}
+	if amount <= 0 || session.Refunded+amount > session.Checkout.Amount {
+		return "", errors.New("refund amount exceeds the paid amount")
+	}
+	refunded, status := session.Refunded, session.Status
+	session.Refunded += amount
+	session.Status = "partially_refunded"
+	if session.Refunded == session.Checkout.Amount {
+		session.Status = "refunded"
+	}
+	err := s.save()
+	if err != nil {
+		// the refund isn't made unless it's kept
+		session.Refunded, session.Status = refunded, status
+		return "", err
+	}
+	return gonanoid.New()
+}
+
+var checkoutTemplate = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Payment Simulator</title></head>
+<body>
+	<h2>Payment Simulator: {{.Checkout.BankID}}</h2>
+	<p>Order #{{.Checkout.OrderID}}: {{.Checkout.Description}}</p>
+	<p>Amount: <b>{{.Checkout.Amount}}</b></p>
+	{{if eq .Status "pending"}}
+	<form method="post">
+		<button name="action" value="pay">Pay</button>
+		<button name="action" value="cancel">Cancel</button>
+	</form>
+	{{else}}
+	<p>Status: <b>{{.Status}}</b></p>
+	{{if .Message}}<p>{{.Message}}</p>{{end}}
+	{{end}}
+</body>
+</html>`))
+
+type checkoutView struct {
+	Checkout pkg.Checkout
+	Status   string
+	Message  string
+}
+
+func (s *Simulator) checkoutPage(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	session, found := s.sessions[r.PathValue("id")]
+	var view checkoutView
+	if found {
+		view = checkoutView{Checkout: session.Checkout, Status: session.Status}
+	}
+	s.mu.RUnlock()
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	checkoutTemplate.Execute(w, view)
+}
+
+func (s *Simulator) submitCheckout(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	session, found := s.sessions[r.PathValue("id")]
+	if !found {
+		s.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	if session.Status != "pending" {
+		view := checkoutView{Checkout: session.Checkout, Status: session.Status}
+		s.mu.Unlock()
+		checkoutTemplate.Execute(w, view)
+		return
+	}
+	if r.FormValue("action") == "pay" && session.secret == "" {
+		view := checkoutView{Checkout: session.Checkout, Status: session.Status,
+			Message: "the checkout is opened before the simulator restarted, its callback can't be signed: check out again"}
+		s.mu.Unlock()
+		checkoutTemplate.Execute(w, view)
+		return
+	}
+	if r.FormValue("action") != "pay" {
+		session.Status = "cancelled"
+		view := checkoutView{Checkout: session.Checkout, Status: session.Status}
+		if err := s.save(); err != nil {
+			view.Message = "the simulator state isn't saved: " + err.Error()
+		}
+		s.mu.Unlock()
+		checkoutTemplate.Execute(w, view)
+		return
+	}
+	session.PaymentID = gonanoid.Must()
+	session.Status = "paid"
+	s.payments[session.PaymentID] = session
+	checkout, paymentID, secret := session.Checkout, session.PaymentID, session.secret
+	err := s.save()
+	if err != nil {
+		// the payment isn't taken unless it's kept, the customer can try again
+		delete(s.payments, session.PaymentID)
+		session.PaymentID, session.Status = "", "pending"
+		view := checkoutView{Checkout: checkout, Status: "failed", Message: "the simulator state isn't saved: " + err.Error()}
+		s.mu.Unlock()
+		checkoutTemplate.Execute(w, view)
+		return
+	}
+	s.mu.Unlock()
+
+	// like a real provider, the money is taken even if the merchant can't be reached
+	view := checkoutView{Checkout: checkout, Status: "paid"}
+	err = s.sendCallback(r.Context(), &checkout, secret, paymentID)
+	if err != nil {
+		view.Message = "merchant callback failed: " + err.Error()
+	}
+	checkoutTemplate.Execute(w, view)
+}
+
+func (s *Simulator) sendCallback(ctx context.Context, checkout *pkg.Checkout, secret string, paymentID string) error {
+	body, err := json.Marshal(map[string]any{
+		"order_id":    checkout.OrderID,
+		"payment_id":  paymentID,
+		"paid_amount": checkout.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce, err := gonanoid.New()
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + nonce + "." + string(body)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, checkout.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Bank-ID", checkout.BankID)
+	req.Header.Set("X-Timestamp", timestamp)
+	req.Header.Set("X-Nonce", nonce)
+	req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("callback responded with " + resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shahzodshafizod/gocloud/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -count=1 ./pkg/payment/ -run ^TestSimulator$
+func TestSimulator(t *testing.T) {
+	ctx := context.Background()
+	secret := "test callback secret"
+
+	var callbacks = make(chan *http.Request, 1)
+	var bodies = make(chan string, 1)
+	merchant := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		callbacks <- r
+		bodies <- string(body)
+	}))
+	defer merchant.Close()
+
+	statePath := filepath.Join(t.TempDir(), "simulator.json")
+	simulator, err := NewSimulator("", "", statePath)
+	assert.NoError(t, err)
+	page := httptest.NewServer(simulator.server.Handler)
+	defer page.Close()
+	simulator.publicURL = page.URL
+
+	session, err := simulator.CreateCheckout(ctx, &pkg.Checkout{
+		OrderID:        1,
+		Amount:         300,
+		Description:    "test order",
+		CallbackURL:    merchant.URL,
+		BankID:         "visa",
+		CallbackSecret: secret,
+	})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(session.WebcheckoutURL, page.URL+"/checkout/"))
+
+	// the checkout page is hosted
+	resp, err := http.Get(session.WebcheckoutURL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the customer pays and the merchant receives a signed callback
+	resp, err = http.PostForm(session.WebcheckoutURL, url.Values{"action": {"pay"}})
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	callback, body := <-callbacks, <-bodies
+	assert.Equal(t, "visa", callback.Header.Get("X-Bank-ID"))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(callback.Header.Get("X-Timestamp") + "." + callback.Header.Get("X-Nonce") + "." + body))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), callback.Header.Get("X-Signature"))
+	assert.Contains(t, body, `"paid_amount":300`)
+
+	// the payment can be looked up and refunded
+	var paymentID string
+	for id := range simulator.payments {
+		paymentID = id
+	}
+	status, err := simulator.GetPaymentStatus(ctx, paymentID)
+	assert.NoError(t, err)
+	assert.Equal(t, "paid", status.Status)
+
+	_, err = simulator.Refund(ctx, paymentID, 100)
+	assert.NoError(t, err)
+	_, err = simulator.Refund(ctx, paymentID, 300)
+	assert.Error(t, err)
+	status, _ = simulator.GetPaymentStatus(ctx, paymentID)
+	assert.Equal(t, "partially_refunded", status.Status)
+	assert.Equal(t, int64(100), status.Refunded)
+
+	_, err = simulator.GetPaymentStatus(ctx, "unknown")
+	assert.ErrorIs(t, err, pkg.ErrNoRows)
+	// the callback secret isn't saved
+	state, err := os.ReadFile(statePath)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(state), secret)
+	info, err := os.Stat(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// the payment is still known after a restart, not missing for the reconciliation
+	restarted, err := NewSimulator("", "", statePath)
+	assert.NoError(t, err)
+	status, err = restarted.GetPaymentStatus(ctx, paymentID)
+	assert.NoError(t, err)
+	assert.Equal(t, "partially_refunded", status.Status)
+	assert.Equal(t, int64(100), status.Refunded)
+	_, err = restarted.Refund(ctx, paymentID, 200)
+	assert.NoError(t, err)
+
+	// a checkout opened before a restart isn't paid, its callback can't be signed
+	pending, err := restarted.CreateCheckout(ctx, &pkg.Checkout{OrderID: 2, Amount: 100, CallbackURL: merchant.URL, BankID: "visa", CallbackSecret: secret})
+	assert.NoError(t, err)
+	restarted, err = NewSimulator("", "", statePath)
+	assert.NoError(t, err)
+	restartedPage := httptest.NewServer(restarted.server.Handler)
+	defer restartedPage.Close()
+	resp, err = http.PostForm(restartedPage.URL+"/checkout/"+pending.SessionID, url.Values{"action": {"pay"}})
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "pending", restarted.sessions[pending.SessionID].Status)
+	assert.Len(t, restarted.payments, 1) // the one paid before
+}
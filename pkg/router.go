@@ -37,6 +37,8 @@ type Middleware func(actionName string, next Handler) Handler
 type Context interface {
 	// Parses the request body into a struct or variable.
 	ParseBody(v any) error
+	// Reads the raw request body, up to 1 MB, keeping it available for a later ParseBody.
+	ReadBody() ([]byte, error)
 	// Validates a struct and returns a list of validation errors.
 	ValidateStruct(v any) []string
 	// Validates a single variable against a tag and returns an error message.
@@ -49,6 +51,8 @@ type Context interface {
 	GetQueryValue(key string) string
 	// Retrieves a header value by its key.
 	GetHeader(key string) string
+	// Returns the IP address of the client connected to the server.
+	GetClientIP() string
 	// Stores a value in the context for later retrieval.
 	SaveValue(key string, value any)
 	// Retrieves a stored value from the context by its key.
@@ -67,8 +71,12 @@ type Context interface {
 	Redirect(url string, code int)
 	// Serves a file to the client.
 	ServeFile(filename string)
+	// Sends the content to the client as a downloadable file with the given name.
+	Attach(filename string, contentType string, content []byte)
 	// Sends a response to the client using a `Response` object.
 	Respond(r Response)
+	// Sends a server-sent event with the JSON of `data`, the first call turns the response into an event stream.
+	SendEvent(event string, data any) error
 }
 
 type File interface {